@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/auth"
 
+	"github.com/concourse/atc/api/auditserver/auditserverfakes"
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
@@ -25,8 +26,11 @@ import (
 	"github.com/concourse/atc/api/teamserver/teamserverfakes"
 	"github.com/concourse/atc/api/volumeserver/volumeserverfakes"
 	"github.com/concourse/atc/api/workerserver/workerserverfakes"
+	"github.com/concourse/atc/archive/archivefakes"
 	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/auth/ldap/ldapfakes"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/credentials/credentialsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -44,6 +48,7 @@ var (
 	userContextReader             *authfakes.FakeUserContextReader
 	fakeTokenGenerator            *authfakes.FakeTokenGenerator
 	providerFactory               *authfakes.FakeProviderFactory
+	ldapAuthenticator             *ldapfakes.FakeAuthenticator
 	fakeEngine                    *enginefakes.FakeEngine
 	fakeWorkerClient              *workerfakes.FakeClient
 	teamServerDB                  *teamserverfakes.FakeTeamsDB
@@ -55,11 +60,14 @@ var (
 	teamDBFactory                 *dbfakes.FakeTeamDBFactory
 	teamDB                        *dbfakes.FakeTeamDB
 	pipelinesDB                   *dbfakes.FakePipelinesDB
+	auditDB                       *auditserverfakes.FakeAuditDB
 	buildsDB                      *authfakes.FakeBuildsDB
 	buildServerDB                 *buildserverfakes.FakeBuildsDB
 	build                         *dbfakes.FakeBuild
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
+	fakeCredentialsManager        *credentialsfakes.FakeManager
+	fakeArtifactStore             *archivefakes.FakeStore
 	configValidationErrorMessages []string
 	configValidationWarnings      []config.Warning
 	peerAddr                      string
@@ -106,12 +114,14 @@ var _ = BeforeEach(func() {
 	volumesDB = new(volumeserverfakes.FakeVolumesDB)
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
+	auditDB = new(auditserverfakes.FakeAuditDB)
 	buildsDB = new(authfakes.FakeBuildsDB)
 
 	authValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
 	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
 	providerFactory = new(authfakes.FakeProviderFactory)
+	ldapAuthenticator = new(ldapfakes.FakeAuthenticator)
 
 	configValidationErrorMessages = []string{}
 	configValidationWarnings = []config.Warning{}
@@ -123,6 +133,8 @@ var _ = BeforeEach(func() {
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeCredentialsManager = new(credentialsfakes.FakeManager)
+	fakeArtifactStore = new(archivefakes.FakeStore)
 
 	var err error
 
@@ -162,6 +174,7 @@ var _ = BeforeEach(func() {
 
 		fakeTokenGenerator,
 		providerFactory,
+		ldapAuthenticator,
 		oAuthBaseURL,
 
 		pipelineDBFactory,
@@ -174,6 +187,7 @@ var _ = BeforeEach(func() {
 		volumesDB,
 		pipeDB,
 		pipelinesDB,
+		auditDB,
 
 		func(atc.Config) ([]config.Warning, []string) {
 			return configValidationWarnings, configValidationErrorMessages
@@ -181,12 +195,14 @@ var _ = BeforeEach(func() {
 		peerAddr,
 		constructedEventHandler.Construct,
 		drain,
+		fakeArtifactStore,
 
 		fakeEngine,
 		fakeWorkerClient,
 
 		fakeSchedulerFactory,
 		fakeScannerFactory,
+		fakeCredentialsManager,
 
 		sink,
 
@@ -0,0 +1,418 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	Describe("GET /api/v1/teams/:team_name/tokens", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as a viewer of the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+				userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when getting the tokens succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns([]db.SavedAPIToken{
+						{
+							ID:     1,
+							TeamID: 42,
+							APIToken: db.APIToken{
+								Name:      "some-token",
+								Role:      atc.TeamRoleOperator,
+								TokenHash: "some-hash",
+							},
+							CreatedAt:  time.Unix(100, 0),
+							LastUsedAt: time.Unix(200, 0),
+						},
+						{
+							ID:     2,
+							TeamID: 42,
+							APIToken: db.APIToken{
+								Name:      "unused-token",
+								Role:      atc.TeamRoleViewer,
+								TokenHash: "other-hash",
+							},
+							CreatedAt: time.Unix(300, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the tokens without their hashes", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "some-token",
+							"role": "operator",
+							"created_at": 100,
+							"last_used_at": 200
+						},
+						{
+							"name": "unused-token",
+							"role": "viewer",
+							"created_at": 300
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/tokens", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"name":"some-token","role":"operator"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/tokens", "application/json", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+
+				teamDB.GetTeamReturns(db.SavedTeam{
+					ID:   42,
+					Team: db.Team{Name: "a-team"},
+				}, true, nil)
+
+				fakeTokenGenerator.GenerateAPITokenReturns("Bearer", "some-token-value", nil)
+			})
+
+			Context("when the token is created", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{
+						ID:     1,
+						TeamID: 42,
+						APIToken: db.APIToken{
+							Name: "some-token",
+							Role: atc.TeamRoleOperator,
+						},
+						CreatedAt: time.Unix(100, 0),
+					}, true, nil)
+				})
+
+				It("generates a token for the team with the requested role", func() {
+					Expect(fakeTokenGenerator.GenerateAPITokenCallCount()).To(Equal(1))
+					teamName, teamID, isAdmin, role, tokenName := fakeTokenGenerator.GenerateAPITokenArgsForCall(0)
+					Expect(teamName).To(Equal("a-team"))
+					Expect(teamID).To(Equal(42))
+					Expect(isAdmin).To(BeFalse())
+					Expect(role).To(Equal(atc.TeamRoleOperator))
+					Expect(tokenName).To(Equal("some-token"))
+				})
+
+				It("saves only the hash of the token", func() {
+					Expect(teamDB.CreateAPITokenCallCount()).To(Equal(1))
+					Expect(teamDB.CreateAPITokenArgsForCall(0)).To(Equal(db.APIToken{
+						Name:      "some-token",
+						Role:      atc.TeamRoleOperator,
+						TokenHash: auth.HashAPIToken("some-token-value"),
+					}))
+				})
+
+				It("returns 201 with the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"name": "some-token",
+						"role": "operator",
+						"token": "Bearer some-token-value",
+						"created_at": 100
+					}`))
+				})
+			})
+
+			Context("when no role is requested", func() {
+				BeforeEach(func() {
+					requestBody = `{"name":"some-token"}`
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, true, nil)
+				})
+
+				It("creates a viewer token", func() {
+					_, _, _, role, _ := fakeTokenGenerator.GenerateAPITokenArgsForCall(0)
+					Expect(role).To(Equal(atc.TeamRoleViewer))
+				})
+			})
+
+			Context("when the role is unknown", func() {
+				BeforeEach(func() {
+					requestBody = `{"name":"some-token","role":"overlord"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when no name is given", func() {
+				BeforeEach(func() {
+					requestBody = `{"role":"viewer"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team is the admin team", func() {
+				var signingKey *rsa.PrivateKey
+
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID:   42,
+						Team: db.Team{Name: "a-team", Admin: true},
+					}, true, nil)
+
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, true, nil)
+
+					var err error
+					signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeTokenGenerator.GenerateAPITokenStub = auth.NewTokenGenerator(signingKey).GenerateAPIToken
+				})
+
+				Context("when a viewer token is requested", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"some-token","role":"viewer"}`
+					})
+
+					It("does not make the token an admin", func() {
+						_, _, isAdmin, _, _ := fakeTokenGenerator.GenerateAPITokenArgsForCall(0)
+						Expect(isAdmin).To(BeFalse())
+					})
+
+					It("cannot reach admin routes with the token", func() {
+						var created atc.APIToken
+						err := json.NewDecoder(response.Body).Decode(&created)
+						Expect(err).NotTo(HaveOccurred())
+
+						fakeAPITokenDB := new(authfakes.FakeAPITokenDB)
+						fakeAPITokenDB.UseAPITokenReturns(true, nil)
+
+						adminHandler := auth.WrapHandler(
+							auth.CheckAdminHandler(
+								http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+								auth.UnauthorizedRejector{},
+							),
+							auth.JWTValidator{PublicKey: &signingKey.PublicKey, APITokenDB: fakeAPITokenDB},
+							auth.JWTReader{PublicKey: &signingKey.PublicKey},
+						)
+
+						request, err := http.NewRequest("PUT", "http://example.com/api/v1/log-level", nil)
+						Expect(err).NotTo(HaveOccurred())
+						request.Header.Set("Authorization", created.Token)
+
+						recorder := httptest.NewRecorder()
+						adminHandler.ServeHTTP(recorder, request)
+						Expect(recorder.Code).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when an owner token is requested", func() {
+					BeforeEach(func() {
+						requestBody = `{"name":"some-token","role":"owner"}`
+					})
+
+					It("makes the token an admin", func() {
+						_, _, isAdmin, _, _ := fakeTokenGenerator.GenerateAPITokenArgsForCall(0)
+						Expect(isAdmin).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when a token with the same name exists", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, false, nil)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when saving the token fails", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/tokens/:token_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/tokens/some-token", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(true, nil)
+				})
+
+				It("revokes the token", func() {
+					Expect(teamDB.DeleteAPITokenCallCount()).To(Equal(1))
+					Expect(teamDB.DeleteAPITokenArgsForCall(0)).To(Equal("some-token"))
+				})
+
+				It("returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when revoking the token fails", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(teamDB.DeleteAPITokenCallCount()).To(BeZero())
+			})
+		})
+	})
+})
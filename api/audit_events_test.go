@@ -0,0 +1,177 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events API", func() {
+	Describe("GET /api/v1/audit-events", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/audit-events" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin with a viewer role", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+				userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(auditDB.GetAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			Context("when getting the events succeeds", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns([]db.AuditEvent{
+						{
+							ID:        2,
+							Route:     atc.HijackContainer,
+							User:      "some-user",
+							Team:      "some-team",
+							Target:    "/api/v1/containers/some-handle/hijack",
+							Status:    http.StatusSwitchingProtocols,
+							CreatedAt: time.Unix(200, 0),
+						},
+						{
+							ID:        1,
+							Route:     atc.CheckResourceWebhook,
+							Target:    "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook",
+							Status:    http.StatusNotFound,
+							CreatedAt: time.Unix(100, 0),
+						},
+					}, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the events", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"route": "HijackContainer",
+							"user": "some-user",
+							"team": "some-team",
+							"target": "/api/v1/containers/some-handle/hijack",
+							"status": 101,
+							"created_at": 200
+						},
+						{
+							"id": 1,
+							"route": "CheckResourceWebhook",
+							"target": "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook",
+							"status": 404,
+							"created_at": 100
+						}
+					]`))
+				})
+
+				It("gets the most recent page of all events", func() {
+					Expect(auditDB.GetAuditEventsCallCount()).To(Equal(1))
+					filter, page := auditDB.GetAuditEventsArgsForCall(0)
+					Expect(filter).To(Equal(db.AuditEventFilter{}))
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+
+				Context("when filtering and paginating", func() {
+					BeforeEach(func() {
+						query = "?user=some-user&team=some-team&route=SaveConfig&since=5&limit=2"
+					})
+
+					It("gets the requested page of matching events", func() {
+						filter, page := auditDB.GetAuditEventsArgsForCall(0)
+						Expect(filter).To(Equal(db.AuditEventFilter{
+							Route: atc.SaveConfig,
+							User:  "some-user",
+							Team:  "some-team",
+						}))
+						Expect(page).To(Equal(db.Page{Since: 5, Limit: 2}))
+					})
+				})
+			})
+
+			Context("when there are more events", func() {
+				BeforeEach(func() {
+					query = "?user=some-user&limit=2"
+
+					auditDB.GetAuditEventsReturns([]db.AuditEvent{}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers per rfc5988 that keep the filter", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/audit-events?limit=2&since=3&user=some-user>; rel="next"`,
+						`<https://example.com/api/v1/audit-events?limit=2&until=4&user=some-user>; rel="previous"`,
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					auditDB.GetAuditEventsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 2, false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(auditDB.GetAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
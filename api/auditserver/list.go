@@ -0,0 +1,87 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	auditQueryRoute = "route"
+	auditQueryUser  = "user"
+	auditQueryTeam  = "team"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	filter := db.AuditEventFilter{
+		Route: r.FormValue(auditQueryRoute),
+		User:  r.FormValue(auditQueryUser),
+		Team:  r.FormValue(auditQueryTeam),
+	}
+
+	events, pagination, err := s.auditDB.GetAuditEvents(filter, db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addLink(w, filter, atc.PaginationQuerySince, pagination.Next.Since, limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, filter, atc.PaginationQueryUntil, pagination.Previous.Until, limit, atc.LinkRelPrevious)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presented[i] = present.AuditEvent(event)
+	}
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) addLink(w http.ResponseWriter, filter db.AuditEventFilter, pageQuery string, id int, limit int, rel string) {
+	query := url.Values{}
+	query.Set(pageQuery, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	if filter.Route != "" {
+		query.Set(auditQueryRoute, filter.Route)
+	}
+
+	if filter.User != "" {
+		query.Set(auditQueryUser, filter.User)
+	}
+
+	if filter.Team != "" {
+		query.Set(auditQueryTeam, filter.Team)
+	}
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit-events?%s>; rel="%s"`,
+		s.externalURL,
+		query.Encode(),
+		rel,
+	))
+}
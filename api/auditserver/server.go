@@ -0,0 +1,32 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	GetAuditEvents(filter db.AuditEventFilter, page db.Page) ([]db.AuditEvent, db.Pagination, error)
+}
+
+type Server struct {
+	logger lager.Logger
+
+	externalURL string
+
+	auditDB AuditDB
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	auditDB AuditDB,
+) *Server {
+	return &Server{
+		logger:      logger,
+		externalURL: externalURL,
+		auditDB:     auditDB,
+	}
+}
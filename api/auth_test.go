@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,11 +71,13 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(role).To(Equal(atc.TeamRoleOwner))
+						Expect(user).To(BeEmpty())
 					})
 				})
 
@@ -87,6 +91,94 @@ var _ = Describe("Auth API", func() {
 					})
 				})
 
+				Context("when the team has roles", func() {
+					BeforeEach(func() {
+						savedTeam.Roles = atc.TeamRoleBindings{
+							{Role: atc.TeamRoleMember, Users: []string{"some-user"}},
+						}
+						teamDB.GetTeamReturns(savedTeam, true, nil)
+					})
+
+					It("makes whoever cannot be identified a viewer", func() {
+						_, _, _, _, role, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(role).To(Equal(atc.TeamRoleViewer))
+					})
+
+					Context("when the request is made with basic auth", func() {
+						BeforeEach(func() {
+							request.SetBasicAuth("some-user", "some-password")
+						})
+
+						It("generates a token for the user with the role bound to them", func() {
+							_, _, _, _, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.TeamRoleMember))
+							Expect(user).To(Equal("some-user"))
+						})
+					})
+
+					Context("when the team has ldap auth", func() {
+						BeforeEach(func() {
+							savedTeam.LDAPAuth = &db.LDAPAuth{Host: "ldap.example.com:636"}
+							savedTeam.Roles = append(savedTeam.Roles, atc.TeamRoleBinding{
+								Role:   atc.TeamRoleOperator,
+								Groups: []string{"some-group"},
+							})
+							teamDB.GetTeamReturns(savedTeam, true, nil)
+
+							request.SetBasicAuth("some-ldap-user", "some-password")
+						})
+
+						Context("when the ldap server authenticates the user", func() {
+							BeforeEach(func() {
+								ldapAuthenticator.AuthenticateReturns(verifier.Identity{
+									User:   "Some-LDAP-User",
+									Groups: []string{"some-group"},
+								}, true, nil)
+							})
+
+							It("asks the ldap server who the user is", func() {
+								Expect(ldapAuthenticator.AuthenticateCallCount()).To(Equal(1))
+								config, username, password := ldapAuthenticator.AuthenticateArgsForCall(0)
+								Expect(config).To(Equal(savedTeam.LDAPAuth))
+								Expect(username).To(Equal("some-ldap-user"))
+								Expect(password).To(Equal("some-password"))
+							})
+
+							It("generates a token with the role bound to the user's groups", func() {
+								_, _, _, _, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+								Expect(role).To(Equal(atc.TeamRoleOperator))
+								Expect(user).To(Equal("Some-LDAP-User"))
+							})
+						})
+
+						Context("when the ldap server does not authenticate the user", func() {
+							BeforeEach(func() {
+								ldapAuthenticator.AuthenticateReturns(verifier.Identity{}, false, nil)
+							})
+
+							It("falls back to the basic auth user", func() {
+								_, _, _, _, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+								Expect(role).To(Equal(atc.TeamRoleViewer))
+								Expect(user).To(Equal("some-ldap-user"))
+							})
+						})
+					})
+
+					Context("when the request is made with a token for the team", func() {
+						BeforeEach(func() {
+							userContextReader.GetTeamReturns("some-team", 0, false, true)
+							userContextReader.GetRoleReturns(atc.TeamRoleOperator, true)
+							userContextReader.GetUserReturns("some-user", true)
+						})
+
+						It("generates a token for the same user with the same role", func() {
+							_, _, _, _, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(role).To(Equal(atc.TeamRoleOperator))
+							Expect(user).To(Equal("some-user"))
+						})
+					})
+				})
+
 				Context("when the team can't be found", func() {
 					BeforeEach(func() {
 						fakeTokenGenerator.GenerateTokenReturns("", "", errors.New("nope"))
@@ -144,6 +236,9 @@ var _ = Describe("Auth API", func() {
 							ClientSecret: "client-secret",
 							DisplayName:  "custom secure auth",
 						},
+						LDAPAuth: &db.LDAPAuth{
+							Host: "ldap.example.com:636",
+						},
 					},
 				}
 
@@ -197,6 +292,11 @@ var _ = Describe("Auth API", func() {
 						"type": "basic",
 						"display_name": "Basic Auth",
 						"auth_url": "https://example.com/teams/some-team/login"
+					},
+					{
+						"type": "basic",
+						"display_name": "LDAP",
+						"auth_url": "https://example.com/teams/some-team/login"
 					}
 				]`))
 			})
@@ -378,6 +478,19 @@ var _ = Describe("Auth API", func() {
 
 								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"}}`))
 							})
+
+							Context("when the token identifies the user", func() {
+								BeforeEach(func() {
+									userContextReader.GetUserReturns("some-user", true)
+								})
+
+								It("returns the user's name", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{"name":"some-user","team":{"id":5,"name":"some-team"}}`))
+								})
+							})
 						})
 					})
 				})
@@ -8,6 +8,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,7 +35,9 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin)
+	role, user := s.tokenIdentity(logger, r, team)
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin, role, user)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -53,3 +57,29 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(token)
 }
+
+// tokenIdentity is the role within the team and the name of whoever
+// authenticated the request: the LDAP or basic auth user, or the bearer of a
+// token for the team.
+func (s *Server) tokenIdentity(logger lager.Logger, r *http.Request, team db.SavedTeam) (atc.TeamRole, string) {
+	if username, password, ok := r.BasicAuth(); ok {
+		if team.LDAPAuth != nil {
+			// ask again, as the validator doesn't keep the user's groups
+			identity, authenticated, err := s.ldapAuthenticator.Authenticate(team.LDAPAuth, username, password)
+			if err != nil {
+				logger.Error("failed-to-identify-ldap-user", err)
+			} else if authenticated {
+				return team.Roles.RoleFor(identity.User, identity.Groups), identity.User
+			}
+		}
+
+		return team.Roles.RoleFor(username, nil), username
+	}
+
+	if authTeam, found := auth.GetTeam(r); found && authTeam.Name() == team.Name {
+		user, _ := auth.GetUser(r)
+		return authTeam.Role(), user
+	}
+
+	return team.Roles.RoleFor("", nil), ""
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -132,5 +133,22 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	// LDAP users log in with a username and password, just like basic auth
+	if team.LDAPAuth != nil {
+		path, err := web.Routes.CreatePathForRoute(
+			web.TeamLogIn,
+			rata.Params{"team_name": team.Name},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeBasic,
+			DisplayName: ldap.DisplayName,
+			AuthURL:     s.externalURL + path,
+		})
+	}
+
 	return methods, nil
 }
@@ -5,17 +5,19 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/db"
 )
 
 type Server struct {
-	logger          lager.Logger
-	externalURL     string
-	oAuthBaseURL    string
-	tokenGenerator  auth.TokenGenerator
-	providerFactory auth.ProviderFactory
-	teamDBFactory   db.TeamDBFactory
-	expire          time.Duration
+	logger            lager.Logger
+	externalURL       string
+	oAuthBaseURL      string
+	tokenGenerator    auth.TokenGenerator
+	providerFactory   auth.ProviderFactory
+	ldapAuthenticator ldap.Authenticator
+	teamDBFactory     db.TeamDBFactory
+	expire            time.Duration
 }
 
 func NewServer(
@@ -24,16 +26,18 @@ func NewServer(
 	oAuthBaseURL string,
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
+	ldapAuthenticator ldap.Authenticator,
 	teamDBFactory db.TeamDBFactory,
 	expire time.Duration,
 ) *Server {
 	return &Server{
-		logger:          logger,
-		externalURL:     externalURL,
-		oAuthBaseURL:    oAuthBaseURL,
-		tokenGenerator:  tokenGenerator,
-		providerFactory: providerFactory,
-		teamDBFactory:   teamDBFactory,
-		expire:          expire,
+		logger:            logger,
+		externalURL:       externalURL,
+		oAuthBaseURL:      oAuthBaseURL,
+		tokenGenerator:    tokenGenerator,
+		providerFactory:   providerFactory,
+		ldapAuthenticator: ldapAuthenticator,
+		teamDBFactory:     teamDBFactory,
+		expire:            expire,
 	}
 }
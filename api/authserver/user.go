@@ -36,7 +36,9 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 			hLog.Error("team-not-found-in-db", errors.New("team-not-found-in-db"))
 		} else {
 			presentedTeam := present.Team(savedTeam)
+			userName, _ := auth.GetUser(r)
 			user = User{
+				Name: userName,
 				Team: &presentedTeam,
 			}
 		}
@@ -47,6 +49,7 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 }
 
 type User struct {
+	Name   string    `json:"name,omitempty"`
 	Team   *atc.Team `json:"team,omitempty"`
 	System *bool     `json:"system,omitempty"`
 }
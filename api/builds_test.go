@@ -16,6 +16,7 @@ import (
 	"github.com/onsi/gomega/ghttp"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/archive"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -736,7 +737,7 @@ var _ = Describe("Builds API", func() {
 
 		Context("when authenticated", func() {
 			BeforeEach(func() {
-				authValidator.IsAuthenticatedReturns(false)
+				authValidator.IsAuthenticatedReturns(true)
 				userContextReader.GetTeamReturns("some-team", 5, false, true)
 			})
 
@@ -853,6 +854,290 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/tests", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.JobNameReturns("job1")
+			build.TeamNameReturns("some-team")
+			buildsDB.GetBuildByIDReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/builds/3/tests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+			})
+
+			Context("when the job is private", func() {
+				BeforeEach(func() {
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the job is public", func() {
+				BeforeEach(func() {
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: true},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 5, false, true)
+			})
+
+			Context("when getting the test results succeeds", func() {
+				BeforeEach(func() {
+					build.GetTestResultsReturns([]atc.TestResult{
+						{PlanID: "some-plan", Suite: "some-suite", Name: "some-test", Status: atc.TestStatusPassed, Duration: 1.5},
+						{PlanID: "some-plan", Suite: "some-suite", Name: "other-test", Status: atc.TestStatusFailed, Duration: 0.25},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the test results", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"plan_id": "some-plan", "suite": "some-suite", "name": "some-test", "status": "passed", "duration": 1.5},
+						{"plan_id": "some-plan", "suite": "some-suite", "name": "other-test", "status": "failed", "duration": 0.25}
+					]`))
+				})
+			})
+
+			Context("when getting the test results fails", func() {
+				BeforeEach(func() {
+					build.GetTestResultsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.JobNameReturns("job1")
+			build.TeamNameReturns("some-team")
+			buildsDB.GetBuildByIDReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/builds/3/artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated and the job is private", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+				build.GetConfigReturns(atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "job1", Public: false},
+					},
+				}, 1, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 5, false, true)
+			})
+
+			Context("when getting the artifacts succeeds", func() {
+				BeforeEach(func() {
+					build.GetArtifactsReturns([]db.BuildArtifact{
+						{Name: "some-output", CreatedAt: time.Unix(100, 0)},
+						{Name: "other-output", CreatedAt: time.Unix(200, 0)},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the artifacts", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"name": "some-output", "created_at": 100},
+						{"name": "other-output", "created_at": 200}
+					]`))
+				})
+			})
+
+			Context("when getting the artifacts fails", func() {
+				BeforeEach(func() {
+					build.GetArtifactsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:name", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.IDReturns(3)
+			build.JobNameReturns("job1")
+			build.TeamNameReturns("some-team")
+			buildsDB.GetBuildByIDReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/builds/3/artifacts/some-output")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated and the job is private", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+				build.GetConfigReturns(atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "job1", Public: false},
+					},
+				}, 1, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 5, false, true)
+			})
+
+			Context("when the build retained the artifact", func() {
+				BeforeEach(func() {
+					build.GetArtifactsReturns([]db.BuildArtifact{
+						{Name: "some-output", CreatedAt: time.Unix(100, 0)},
+					}, nil)
+				})
+
+				Context("when the store has it", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(ioutil.NopCloser(bytes.NewBufferString("some-tarball")), nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("streams the tarball", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/x-tar"))
+						Expect(response.Header.Get("Content-Disposition")).To(Equal(`attachment; filename="some-output.tar"`))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-tarball"))
+					})
+
+					It("reads it from the artifact's key", func() {
+						Expect(fakeArtifactStore.GetCallCount()).To(Equal(1))
+						Expect(fakeArtifactStore.GetArgsForCall(0)).To(Equal(archive.BuildArtifactKey(3, "some-output")))
+					})
+				})
+
+				Context("when the store does not have it", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, archive.ErrNotFound)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when reading from the store fails", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the build did not retain the artifact", func() {
+				BeforeEach(func() {
+					build.GetArtifactsReturns([]db.BuildArtifact{
+						{Name: "other-output", CreatedAt: time.Unix(100, 0)},
+					}, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not read from the store", func() {
+					Expect(fakeArtifactStore.GetCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the artifacts fails", func() {
+				BeforeEach(func() {
+					build.GetArtifactsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/events", func() {
 		var (
 			request  *http.Request
@@ -1172,6 +1457,7 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     db.BuildPreparationStatusBlocking,
 					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					QueuePosition:       3,
 				}
 				buildsDB.GetBuildByIDReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1280,7 +1566,8 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
-					}
+					},
+					"queue_position": 3
 				}`))
 				})
 
@@ -1490,4 +1777,156 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/builds/search", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = "?q=connection+refused&pipeline=some-pipeline&job=some-job"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/builds/search" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the search succeeds", func() {
+				BeforeEach(func() {
+					teamDB.SearchBuildLogsReturns([]db.BuildLogMatches{
+						{
+							BuildID: 3,
+							Lines: []db.BuildLogLine{
+								{OriginID: "some-step", Excerpt: "dial tcp: connection refused"},
+								{OriginID: "some-step", Excerpt: "Connection refused, retrying"},
+							},
+						},
+						{
+							BuildID: 1,
+							Lines: []db.BuildLogLine{
+								{OriginID: "other-step", Excerpt: "connection refused"},
+							},
+						},
+					}, nil)
+				})
+
+				It("searches the team's build logs", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+
+					Expect(teamDB.SearchBuildLogsCallCount()).To(Equal(1))
+					Expect(teamDB.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+						Query:        "connection refused",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Limit:        100,
+					}))
+				})
+
+				It("returns the matching builds with excerpts of the matching lines", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"build_id": 3,
+							"matches": [
+								{"origin_id": "some-step", "excerpt": "dial tcp: connection refused"},
+								{"origin_id": "some-step", "excerpt": "Connection refused, retrying"}
+							]
+						},
+						{
+							"build_id": 1,
+							"matches": [
+								{"origin_id": "other-step", "excerpt": "connection refused"}
+							]
+						}
+					]`))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						query = "?q=connection+refused&limit=5"
+					})
+
+					It("limits the number of builds", func() {
+						Expect(teamDB.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+							Query: "connection refused",
+							Limit: 5,
+						}))
+					})
+				})
+
+				Context("when the limit is too large", func() {
+					BeforeEach(func() {
+						query = "?q=connection+refused&limit=100000"
+					})
+
+					It("limits the number of builds to the default", func() {
+						Expect(teamDB.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+							Query: "connection refused",
+							Limit: 100,
+						}))
+					})
+				})
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not search", func() {
+					Expect(teamDB.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the search fails", func() {
+				BeforeEach(func() {
+					teamDB.SearchBuildLogsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
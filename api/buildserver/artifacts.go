@@ -0,0 +1,91 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildArtifacts(build db.Build) http.Handler {
+	logger := s.logger.Session("list-build-artifacts")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artifacts, err := build.GetArtifacts()
+		if err != nil {
+			logger.Error("failed-to-get-artifacts", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.BuildArtifact, len(artifacts))
+		for i, artifact := range artifacts {
+			presented[i] = present.BuildArtifact(artifact)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(presented)
+	})
+}
+
+func (s *Server) GetBuildArtifact(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-artifact")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":name")
+
+		if s.artifactStore == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifacts, err := build.GetArtifacts()
+		if err != nil {
+			logger.Error("failed-to-get-artifacts", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		found := false
+		for _, artifact := range artifacts {
+			if artifact.Name == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		content, err := s.artifactStore.Get(archive.BuildArtifactKey(build.ID(), name))
+		if err == archive.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-artifact", err, lager.Data{"build": build.ID(), "artifact": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer content.Close()
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar"`, name))
+		w.WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(w, content)
+		if err != nil {
+			logger.Info("failed-to-stream-artifact", lager.Data{"error": err.Error()})
+		}
+	})
+}
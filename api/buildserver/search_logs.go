@@ -0,0 +1,49 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) SearchBuildLogs(teamDB db.TeamDB) http.Handler {
+	logger := s.logger.Session("search-build-logs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		search := db.BuildLogSearch{
+			Query:        r.FormValue("q"),
+			PipelineName: r.FormValue("pipeline"),
+			JobName:      r.FormValue("job"),
+		}
+
+		if search.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		search.Limit, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if search.Limit <= 0 || search.Limit > atc.PaginationAPIDefaultLimit {
+			search.Limit = atc.PaginationAPIDefaultLimit
+		}
+
+		matches, err := teamDB.SearchBuildLogs(search)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err, lager.Data{"query": search.Query})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		results := make([]atc.BuildLogSearchResult, len(matches))
+		for i, match := range matches {
+			results[i] = present.BuildLogSearchResult(match)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/archive"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
@@ -30,6 +31,7 @@ type Server struct {
 	buildsDB            BuildsDB
 	eventHandlerFactory EventHandlerFactory
 	drain               <-chan struct{}
+	artifactStore       archive.Store
 	rejector            auth.Rejector
 
 	httpClient *http.Client
@@ -44,6 +46,7 @@ func NewServer(
 	buildsDB BuildsDB,
 	eventHandlerFactory EventHandlerFactory,
 	drain <-chan struct{},
+	artifactStore archive.Store,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -56,6 +59,7 @@ func NewServer(
 		buildsDB:            buildsDB,
 		eventHandlerFactory: eventHandlerFactory,
 		drain:               drain,
+		artifactStore:       artifactStore,
 
 		rejector: auth.UnauthorizedRejector{},
 
@@ -0,0 +1,25 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) BuildTestResults(build db.Build) http.Handler {
+	logger := s.logger.Session("build-test-results")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := build.GetTestResults()
+		if err != nil {
+			logger.Error("failed-to-get-test-results", err, lager.Data{"build": build.ID()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -172,6 +173,29 @@ var _ = Describe("Config API", func() {
 				})
 			})
 
+			Context("when the config has webhook tokens", func() {
+				BeforeEach(func() {
+					pipelineConfig.Resources[0].WebhookToken = "some-token"
+
+					teamDB.GetConfigReturns(
+						pipelineConfig,
+						atc.RawConfig(`{"resources":[{"name":"some-resource","webhook_token":"some-token"}]}`),
+						1,
+						nil,
+					)
+				})
+
+				It("redacts them", func() {
+					var actualConfigResponse atc.ConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(actualConfigResponse.Config.Resources[0].WebhookToken).To(Equal(config.RedactedValue))
+					Expect(actualConfigResponse.RawConfig).To(MatchJSON(`{"resources":[{"name":"some-resource","webhook_token":"((redacted))"}]}`))
+					Expect(actualConfigResponse.RawConfig).NotTo(ContainSubstring("some-token"))
+				})
+			})
+
 			Context("when getting the config fails", func() {
 				BeforeEach(func() {
 					teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
@@ -244,6 +268,7 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(true)
 				userContextReader.GetTeamReturns("a-team", 42, true, true)
+				userContextReader.GetUserReturns("some-user", true)
 			})
 
 			Context("when a config version is specified", func() {
@@ -272,7 +297,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -296,7 +321,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -317,18 +342,57 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							author, name, savedConfig, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+							Expect(author).To(Equal("some-user"))
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when its webhook tokens were redacted", func() {
+							BeforeEach(func() {
+								savedConfig := pipelineConfig
+								savedConfig.Resources = atc.ResourceConfigs{pipelineConfig.Resources[0]}
+								savedConfig.Resources[0].WebhookToken = "some-token"
+								teamDB.GetConfigReturns(savedConfig, atc.RawConfig(""), 42, nil)
+
+								pipelineConfig.Resources[0].WebhookToken = config.RedactedValue
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves the tokens that were saved before", func() {
+								Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("a-pipeline"))
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+								_, _, savedConfig, _, _ := teamDB.SaveConfigAsArgsForCall(0)
+								Expect(savedConfig.Resources[0].WebhookToken).To(Equal("some-token"))
+							})
+
+							Context("and getting the saved config fails", func() {
+								BeforeEach(func() {
+									teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+
+								It("does not save it", func() {
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -352,7 +416,7 @@ var _ = Describe("Config API", func() {
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -379,7 +443,84 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+							})
+						})
+						Context("when a passed constraint references a job in another pipeline", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Plan[0].Passed = []string{"other-pipeline/other-job"}
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the team has a matching pipeline", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelinesReturns([]db.SavedPipeline{
+										{
+											Pipeline: db.Pipeline{
+												Name: "other-pipeline",
+												Config: atc.Config{
+													Resources: atc.ResourceConfigs{
+														{Name: "other-resource", Type: "some-type", Source: pipelineConfig.Resources[0].Source},
+													},
+													Jobs: atc.JobConfigs{
+														{
+															Name: "other-job",
+															Plan: atc.PlanSequence{
+																{Get: "other-resource"},
+															},
+														},
+													},
+												},
+											},
+										},
+									}, nil)
+								})
+
+								It("returns 200", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+
+								It("saves it", func() {
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the team has no such pipeline", func() {
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									var saveConfigResponse struct {
+										Errors []string `json:"errors"`
+									}
+									err := json.NewDecoder(response.Body).Decode(&saveConfigResponse)
+									Expect(err).NotTo(HaveOccurred())
+									Expect(saveConfigResponse.Errors).To(HaveLen(1))
+									Expect(saveConfigResponse.Errors[0]).To(ContainSubstring("references an unknown pipeline ('other-pipeline')"))
+								})
+
+								It("does not save it", func() {
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when getting the team's pipelines fails", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelinesReturns(nil, errors.New("oh no!"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+
+								It("does not save it", func() {
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+								})
 							})
 						})
 					})
@@ -399,9 +540,10 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							author, name, savedConfig, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+							Expect(author).To(Equal("some-user"))
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -409,9 +551,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := teamDB.SaveConfigArgsForCall(0)
+							_, _, savedConfig, _, _ := teamDB.SaveConfigAsArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -446,9 +588,10 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								author, name, savedConfig, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+								Expect(author).To(Equal("some-user"))
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -499,7 +642,7 @@ jobs:
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -509,7 +652,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -540,7 +683,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -584,9 +727,10 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								author, name, savedConfig, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+								Expect(author).To(Equal("some-user"))
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -605,7 +749,7 @@ jobs:
 											Version: db.ConfigVersion(42),
 										},
 									}
-									teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+									teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -615,7 +759,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+									teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -645,7 +789,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 								})
 							})
 
@@ -775,7 +919,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -814,7 +958,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -836,7 +980,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 					})
 				})
 
@@ -867,7 +1011,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 					})
 				})
 			})
@@ -891,7 +1035,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 				})
 			})
 
@@ -914,7 +1058,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 				})
 			})
 		})
@@ -929,7 +1073,506 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/diff", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.DiffConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "application/json")
+					request.Body = gbytes.BufferWithBytes([]byte(`{`))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("returns error JSON", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["malformed config"]}`))
+				})
+			})
+
+			Context("when the config can be decoded", func() {
+				BeforeEach(func() {
+					newConfig := atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   "some-type",
+								Source: atc.Source{"password": "new-secret"},
+							},
+						},
+						Jobs: atc.JobConfigs{
+							{Name: "some-job"},
+						},
+					}
+
+					payload, err := json.Marshal(newConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Header.Set("Content-Type", "application/json")
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				Context("when there is a saved config", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigReturns(atc.Config{
+							Resources: atc.ResourceConfigs{
+								{
+									Name:   "some-resource",
+									Type:   "some-type",
+									Source: atc.Source{"password": "old-secret"},
+								},
+							},
+							Jobs: atc.JobConfigs{
+								{Name: "some-job"},
+								{Name: "old-job"},
+							},
+						}, atc.RawConfig("raw-config"), 7, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the current config version", func() {
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("7"))
+					})
+
+					It("looks up the pipeline's config", func() {
+						Expect(teamDB.GetConfigCallCount()).To(Equal(1))
+						Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("a-pipeline"))
+					})
+
+					It("returns the diff with sources redacted", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"diff": {
+								"groups": [],
+								"resources": [
+									{
+										"name": "some-resource",
+										"change": "changed",
+										"before": {"name": "some-resource", "type": "some-type", "source": {"password": "((redacted))"}, "check_every": "", "webhook_token": ""},
+										"after": {"name": "some-resource", "type": "some-type", "source": {"password": "((redacted))"}, "check_every": "", "webhook_token": ""}
+									}
+								],
+								"resource_types": [],
+								"jobs": [
+									{
+										"name": "old-job",
+										"change": "removed",
+										"before": {"name": "old-job"}
+									}
+								]
+							}
+						}`))
+					})
+
+					It("does not save anything", func() {
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+					})
+
+					Context("when the config is invalid", func() {
+						BeforeEach(func() {
+							configValidationErrorMessages = []string{"totally invalid"}
+							configValidationWarnings = []config.Warning{
+								{Type: "deprecation", Message: "old stuff"},
+							}
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns the errors and warnings alongside the diff", func() {
+							var diffResponse struct {
+								Errors   []string         `json:"errors"`
+								Warnings []config.Warning `json:"warnings"`
+							}
+							err := json.NewDecoder(response.Body).Decode(&diffResponse)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(diffResponse.Errors).To(Equal([]string{"totally invalid"}))
+							Expect(diffResponse.Warnings).To(Equal([]config.Warning{
+								{Type: "deprecation", Message: "old stuff"},
+							}))
+						})
+					})
+				})
+
+				Context("when the saved config is malformed", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig("bogus"), 7, atc.MalformedConfigError{UnmarshalError: errors.New("nope")})
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("diffs against an empty config", func() {
+						var diffResponse struct {
+							Diff atc.ConfigDiff `json:"diff"`
+						}
+						err := json.NewDecoder(response.Body).Decode(&diffResponse)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(diffResponse.Diff.Resources).To(HaveLen(1))
+						Expect(diffResponse.Diff.Resources[0].Change).To(Equal(atc.ConfigChangeAdded))
+						Expect(diffResponse.Diff.Jobs).To(HaveLen(1))
+						Expect(diffResponse.Diff.Jobs[0].Change).To(Equal(atc.ConfigChangeAdded))
+					})
+				})
+
+				Context("when getting the saved config fails", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the team's pipelines fails", func() {
+					BeforeEach(func() {
+						teamDB.GetPipelinesReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the pipeline exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns([]db.PipelineConfigVersion{
+						{Version: 2, Author: "a-team", CreatedAt: time.Unix(200, 0), RawConfig: "{}"},
+						{Version: 1, CreatedAt: time.Unix(100, 0), RawConfig: "{}"},
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the pipeline's versions", func() {
+					Expect(teamDB.GetConfigVersionsArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns the versions without their configs", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"version": 2, "author": "a-team", "created_at": 200},
+						{"version": 1, "created_at": 100}
+					]`))
+				})
+			})
+
+			Context("when the pipeline does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the versions fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns(nil, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:version", func() {
+		var (
+			version  string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			version = "2"
+		})
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+				"version":       version,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version:   2,
+						Author:    "a-team",
+						CreatedAt: time.Unix(200, 0),
+						RawConfig: `{"jobs":[{"name":"some-job"}]}`,
+					}, true, nil)
+				})
+
+				It("looks up the version", func() {
+					pipelineName, configVersion := teamDB.GetConfigVersionArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(configVersion).To(Equal(db.ConfigVersion(2)))
+				})
+
+				It("returns the config", func() {
+					var versionResponse atc.PipelineConfigVersionResponse
+					err := json.NewDecoder(response.Body).Decode(&versionResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(versionResponse.Version).To(Equal(2))
+					Expect(versionResponse.Author).To(Equal("a-team"))
+					Expect(versionResponse.CreatedAt).To(Equal(int64(200)))
+					Expect(versionResponse.Config).To(Equal(&atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					}))
+					Expect(versionResponse.RawConfig).To(Equal(atc.RawConfig(`{"jobs":[{"name":"some-job"}]}`)))
+					Expect(versionResponse.Errors).To(BeEmpty())
+				})
+			})
+
+			Context("when the version is malformed", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version:   2,
+						RawConfig: `{bogus`,
+					}, true, nil)
+				})
+
+				It("returns the raw config with an error", func() {
+					var versionResponse atc.PipelineConfigVersionResponse
+					err := json.NewDecoder(response.Body).Decode(&versionResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(versionResponse.Config).To(BeNil())
+					Expect(versionResponse.RawConfig).To(Equal(atc.RawConfig(`{bogus`)))
+					Expect(versionResponse.Errors).To(HaveLen(1))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is not a number", func() {
+				BeforeEach(func() {
+					version = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/versions/:version/rollback", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+				"version":       "2",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{
+						Version:   2,
+						RawConfig: atc.RawConfig(payload),
+					}, true, nil)
+
+					teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig("{}"), 5, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the old config over the current version", func() {
+					Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+					author, name, savedConfig, from, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+					Expect(author).To(Equal("a-team"))
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(pipelineConfig))
+					Expect(from).To(Equal(db.ConfigVersion(5)))
+					Expect(pipelineState).To(Equal(db.PipelineNoChange))
+				})
+
+				Context("when the old config is no longer valid", func() {
+					BeforeEach(func() {
+						configValidationErrorMessages = []string{"totally invalid"}
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("returns error JSON", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["totally invalid"]}`))
+					})
+
+					It("does not save it", func() {
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when getting the current config fails", func() {
+					BeforeEach(func() {
+						teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not save it", func() {
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
 	})
@@ -0,0 +1,65 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/tedsuo/rata"
+)
+
+type DiffConfigResponse struct {
+	Diff     atc.ConfigDiff   `json:"diff"`
+	Errors   []string         `json:"errors,omitempty"`
+	Warnings []config.Warning `json:"warnings,omitempty"`
+}
+
+// DiffConfig previews saving a config: it reports how the config differs from
+// the one currently saved, and whether it's valid, without saving anything.
+func (s *Server) DiffConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("diff-config")
+
+	newConfig, _, ok := s.decodeConfig(w, r, session)
+	if !ok {
+		return
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	warnings, errorMessages := s.validate(newConfig)
+
+	crossPipelineErrors, err := validateCrossPipelinePassed(teamDB, pipelineName, newConfig)
+	if err != nil {
+		session.Error("failed-to-get-team-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	errorMessages = append(errorMessages, crossPipelineErrors...)
+
+	savedConfig, _, version, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			session.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// saving would replace the malformed config outright
+		session.Info("diffing-against-malformed-config", lager.Data{"error": err.Error()})
+		savedConfig = atc.Config{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", version))
+
+	json.NewEncoder(w).Encode(DiffConfigResponse{
+		Diff:     config.Diff(savedConfig, config.RestoreWebhookTokens(newConfig, savedConfig)),
+		Errors:   errorMessages,
+		Warnings: warnings,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/tedsuo/rata"
 )
 
@@ -13,12 +14,12 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("get-config")
 	pipelineName := rata.Param(r, "pipeline_name")
 	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
-	config, rawConfig, id, err := teamDB.GetConfig(pipelineName)
+	pipelineConfig, rawConfig, id, err := teamDB.GetConfig(pipelineName)
 	if err != nil {
 		if malformedErr, ok := err.(atc.MalformedConfigError); ok {
 			getConfigResponse := atc.ConfigResponse{
 				Errors:    []string{malformedErr.Error()},
-				RawConfig: rawConfig,
+				RawConfig: config.RedactRawWebhookTokens(rawConfig),
 			}
 
 			responseJSON, err := json.Marshal(getConfigResponse)
@@ -40,8 +41,10 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
 
+	redactedConfig := config.RedactWebhookTokens(pipelineConfig)
+
 	json.NewEncoder(w).Encode(atc.ConfigResponse{
-		Config:    &config,
-		RawConfig: rawConfig,
+		Config:    &redactedConfig,
+		RawConfig: config.RedactRawWebhookTokens(rawConfig),
 	})
 }
@@ -0,0 +1,42 @@
+package configserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// RollbackConfig saves an earlier version of the pipeline's config as its
+// newest, validating it as though it were being set anew.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+
+	configVersion, found := s.lookupConfigVersion(w, r, session)
+	if !found {
+		return
+	}
+
+	config, err := configVersion.Config()
+	if err != nil {
+		session.Info("ignoring-malformed-config-version", lager.Data{"error": err.Error()})
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	_, _, currentVersion, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			session.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	s.saveConfig(w, session, teamDB, configAuthor(r), pipelineName, config, currentVersion, db.PipelineNoChange)
+}
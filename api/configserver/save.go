@@ -13,6 +13,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/mitchellh/mapstructure"
@@ -65,31 +66,128 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pipelineConfig, pausedState, ok := s.decodeConfig(w, r, session)
+	if !ok {
+		return
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	if config.HasRedactedWebhookTokens(pipelineConfig) {
+		// the config was fetched with its webhook tokens redacted; keep the
+		// tokens that are saved rather than saving the placeholder
+		savedConfig, _, _, err := teamDB.GetConfig(pipelineName)
+		if err != nil {
+			if _, ok := err.(atc.MalformedConfigError); !ok {
+				session.Error("failed-to-get-config", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		pipelineConfig = config.RestoreWebhookTokens(pipelineConfig, savedConfig)
+	}
+
+	s.saveConfig(w, session, teamDB, configAuthor(r), pipelineName, pipelineConfig, version, pausedState)
+}
+
+// saveConfig validates the config and saves it as the pipeline's new config
+// on behalf of the author.
+func (s *Server) saveConfig(
+	w http.ResponseWriter,
+	session lager.Logger,
+	teamDB db.TeamDB,
+	author string,
+	pipelineName string,
+	config atc.Config,
+	version db.ConfigVersion,
+	pausedState db.PipelinePausedState,
+) {
+	warnings, errorMessages := s.validate(config)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config")
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	errorMessages, err := validateCrossPipelinePassed(teamDB, pipelineName, config)
+	if err != nil {
+		session.Error("failed-to-get-team-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-cross-pipeline-passed")
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	session.Info("saving")
+
+	_, created, err := teamDB.SaveConfigAs(author, pipelineName, config, version, pausedState)
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	session.Info("saved")
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
+
+// configAuthor is who is saving a config, as recorded in the pipeline's
+// config history: the user, if the token identifies one, or else the team.
+func configAuthor(r *http.Request) string {
+	if user, found := auth.GetUser(r); found {
+		return user
+	}
+
+	team, found := auth.GetTeam(r)
+	if !found {
+		return ""
+	}
+
+	return team.Name()
+}
+
+// decodeConfig reads the config from the request, responding on its own if
+// it cannot.
+func (s *Server) decodeConfig(w http.ResponseWriter, r *http.Request, session lager.Logger) (atc.Config, db.PipelinePausedState, bool) {
 	config, pausedState, err := saveConfigRequestUnmarshaler(r)
 
 	switch err {
 	case ErrStatusUnsupportedMediaType:
 		w.WriteHeader(http.StatusUnsupportedMediaType)
-		return
+		return atc.Config{}, db.PipelineNoChange, false
 	case ErrMalformedRequestPayload:
 		session.Error("malformed-request-payload", err, lager.Data{
 			"content-type": r.Header.Get("Content-Type"),
 		})
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
-		return
+		return atc.Config{}, db.PipelineNoChange, false
 	case ErrFailedToConstructDecoder:
 		session.Error("failed-to-construct-decoder", err)
 		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return atc.Config{}, db.PipelineNoChange, false
 	case ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
-		return
+		return atc.Config{}, db.PipelineNoChange, false
 	case ErrInvalidPausedValue:
 		session.Error("invalid-paused-value", err)
 		s.handleBadRequest(w, []string{"invalid paused value"}, session)
-		return
+		return atc.Config{}, db.PipelineNoChange, false
 	default:
 		if err != nil {
 			if eke, ok := err.(ExtraKeysError); ok {
@@ -99,40 +197,25 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusInternalServerError)
 			}
 
-			return
+			return atc.Config{}, db.PipelineNoChange, false
 		}
 	}
 
-	warnings, errorMessages := s.validate(config)
-	if len(errorMessages) > 0 {
-		session.Error("ignoring-invalid-config", err)
-		s.handleBadRequest(w, errorMessages, session)
-		return
-	}
-
-	session.Info("saving")
-
-	pipelineName := rata.Param(r, "pipeline_name")
-	teamName := rata.Param(r, "team_name")
+	return config, pausedState, true
+}
 
-	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+func validateCrossPipelinePassed(teamDB db.TeamDB, pipelineName string, pipelineConfig atc.Config) ([]string, error) {
+	pipelines, err := teamDB.GetPipelines()
 	if err != nil {
-		session.Error("failed-to-save-config", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, "failed to save config: %s", err)
-		return
+		return nil, err
 	}
 
-	session.Info("saved")
-
-	if created {
-		w.WriteHeader(http.StatusCreated)
-	} else {
-		w.WriteHeader(http.StatusOK)
+	teamPipelines := map[string]atc.Config{}
+	for _, pipeline := range pipelines {
+		teamPipelines[pipeline.Name] = pipeline.Config
 	}
 
-	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+	return config.ValidateCrossPipelinePassed(pipelineConfig, pipelineName, teamPipelines), nil
 }
 
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
@@ -237,6 +320,7 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			atc.SanitizeDecodeHook,
 			atc.VersionConfigDecodeHook,
+			atc.AttemptsConfigDecodeHook,
 		),
 	}
 
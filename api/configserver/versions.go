@@ -0,0 +1,92 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	versions, found, err := teamDB.GetConfigVersions(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	presented := make([]atc.PipelineConfigVersion, len(versions))
+	for i, version := range versions {
+		presented[i] = present.PipelineConfigVersion(version)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+
+	configVersion, found := s.lookupConfigVersion(w, r, logger)
+	if !found {
+		return
+	}
+
+	response := atc.PipelineConfigVersionResponse{
+		PipelineConfigVersion: present.PipelineConfigVersion(configVersion),
+		RawConfig:             config.RedactRawWebhookTokens(configVersion.RawConfig),
+	}
+
+	pipelineConfig, err := configVersion.Config()
+	if err != nil {
+		response.Errors = []string{err.Error()}
+	} else {
+		redactedConfig := config.RedactWebhookTokens(pipelineConfig)
+		response.Config = &redactedConfig
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// lookupConfigVersion finds the version of the pipeline's config named by the
+// request, responding on its own if it cannot.
+func (s *Server) lookupConfigVersion(w http.ResponseWriter, r *http.Request, logger lager.Logger) (db.PipelineConfigVersion, bool) {
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	version, err := strconv.Atoi(rata.Param(r, "version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	configVersion, found, err := teamDB.GetConfigVersion(pipelineName, db.ConfigVersion(version))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return db.PipelineConfigVersion{}, false
+	}
+
+	return configVersion, true
+}
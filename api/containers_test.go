@@ -114,6 +114,10 @@ var _ = Describe("Containers API", func() {
 									ResourceName: "some-resource",
 									WorkerName:   "some-other-worker-guid",
 									Handle:       "some-other-handle",
+									ContainerLimits: atc.ContainerLimits{
+										CPU:    512,
+										Memory: 1073741824,
+									},
 								},
 							},
 
@@ -173,7 +177,11 @@ var _ = Describe("Containers API", func() {
 									"validity_in_seconds": 0,
 									"worker_name": "some-other-worker-guid",
 									"pipeline_name": "some-other-pipeline",
-									"resource_name": "some-resource"
+									"resource_name": "some-resource",
+									"container_limits": {
+										"cpu": 512,
+										"memory": 1073741824
+									}
 								}
 							]
 						`))
@@ -9,6 +9,7 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -22,9 +23,14 @@ import (
 	"github.com/concourse/atc/api/resourceserver"
 	"github.com/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/atc/api/teamserver"
+	"github.com/concourse/atc/api/tokenserver"
 	"github.com/concourse/atc/api/volumeserver"
+	"github.com/concourse/atc/api/webhookserver"
 	"github.com/concourse/atc/api/workerserver"
+	"github.com/concourse/atc/archive"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/mainredirect"
@@ -41,6 +47,7 @@ func NewHandler(
 
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
+	ldapAuthenticator ldap.Authenticator,
 	oAuthBaseURL string,
 
 	pipelineDBFactory db.PipelineDBFactory,
@@ -53,17 +60,20 @@ func NewHandler(
 	volumesDB volumeserver.VolumesDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
+	auditDB auditserver.AuditDB,
 
 	configValidator configserver.ConfigValidator,
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
 	drain <-chan struct{},
+	artifactStore archive.Store,
 
 	engine engine.Engine,
 	workerClient worker.Client,
 
 	schedulerFactory jobserver.SchedulerFactory,
 	scannerFactory resourceserver.ScannerFactory,
+	credentialsManager credentials.Manager,
 
 	sink *lager.ReconfigurableSink,
 
@@ -87,6 +97,7 @@ func NewHandler(
 		oAuthBaseURL,
 		tokenGenerator,
 		providerFactory,
+		ldapAuthenticator,
 		teamDBFactory,
 		expire,
 	)
@@ -100,10 +111,11 @@ func NewHandler(
 		buildsDB,
 		eventHandlerFactory,
 		drain,
+		artifactStore,
 	)
 
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, credentialsManager)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
@@ -125,28 +137,45 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	webhookServer := webhookserver.NewServer(logger)
+
+	tokenServer := tokenserver.NewServer(logger, tokenGenerator)
+
+	auditServer := auditserver.NewServer(logger, externalURL, auditDB)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.DiffConfig: http.HandlerFunc(configServer.DiffConfig),
+
+		atc.ListConfigVersions: http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   http.HandlerFunc(configServer.GetConfigVersion),
+		atc.RollbackConfig:     http.HandlerFunc(configServer.RollbackConfig),
 
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
+		atc.BuildTestResults:    buildHandlerFactory.HandlerFor(buildServer.BuildTestResults),
+		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.ListBuildArtifacts),
+		atc.GetBuildArtifact:    buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifact),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+		atc.SearchBuildLogs:     teamHandlerFactory.HandlerFor(buildServer.SearchBuildLogs),
 
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
 		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
 		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
 		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.ListJobTests:   pipelineHandlerFactory.HandlerFor(jobServer.ListJobTests),
 		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.RerunJobBuild:  pipelineHandlerFactory.HandlerFor(jobServer.RerunJobBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
@@ -168,11 +197,15 @@ func NewHandler(
 		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
 		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.UnpinResource:   pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 
+		atc.CheckResourceWebhook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebhook),
+
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 
@@ -199,6 +232,18 @@ func NewHandler(
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+
+		atc.ListWebhooks:          teamHandlerFactory.HandlerFor(webhookServer.ListWebhooks),
+		atc.SetWebhook:            teamHandlerFactory.HandlerFor(webhookServer.SetWebhook),
+		atc.DeleteWebhook:         teamHandlerFactory.HandlerFor(webhookServer.DeleteWebhook),
+		atc.ListWebhookDeliveries: teamHandlerFactory.HandlerFor(webhookServer.ListWebhookDeliveries),
+		atc.RedeliverWebhook:      teamHandlerFactory.HandlerFor(webhookServer.RedeliverWebhook),
+
+		atc.ListAPITokens:  teamHandlerFactory.HandlerFor(tokenServer.ListAPITokens),
+		atc.CreateAPIToken: teamHandlerFactory.HandlerFor(tokenServer.CreateAPIToken),
+		atc.RevokeAPIToken: teamHandlerFactory.HandlerFor(tokenServer.RevokeAPIToken),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
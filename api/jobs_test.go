@@ -222,6 +222,29 @@ var _ = Describe("Jobs API", func() {
 
 					})
 
+					Context("when the job has cron triggers", func() {
+						BeforeEach(func() {
+							pipelineDB.GetJobReturns(db.SavedJob{
+								PipelineName: "some-pipeline",
+								Job: db.Job{
+									Name: "some-job",
+								},
+								Config: atc.JobConfig{
+									Name:     "some-job",
+									Triggers: []atc.TriggerConfig{{Cron: "* * * * *"}},
+								},
+							}, true, nil)
+						})
+
+						It("returns the next time the job will be triggered", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(time.Unix(job.NextTriggerTime, 0)).To(BeTemporally("~", time.Now(), time.Minute))
+						})
+					})
+
 					Context("when there are no running or finished builds", func() {
 						BeforeEach(func() {
 							pipelineDB.GetJobFinishedAndNextBuildReturns(nil, nil, nil)
@@ -1278,6 +1301,214 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name/rerun", func() {
+		var request *http.Request
+		var response *http.Response
+
+		var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/3/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+			fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+			pipelineDB.ConfigReturns(atc.Config{
+				Jobs: []atc.JobConfig{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get: "some-input",
+							},
+						},
+					},
+				},
+
+				Resources: atc.ResourceConfigs{
+					{Name: "resource-1", Type: "some-type"},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the original build exists", func() {
+				var originalBuild *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					originalBuild = new(dbfakes.FakeBuild)
+					originalBuild.IDReturns(3)
+					originalBuild.StatusReturns(db.StatusSucceeded)
+					originalBuild.GetResourcesReturns([]db.BuildInput{
+						{
+							Name: "some-input",
+							VersionedResource: db.VersionedResource{
+								Resource: "resource-1",
+								Type:     "some-type",
+								Version:  db.Version{"ref": "abc"},
+							},
+						},
+					}, nil, nil)
+					pipelineDB.GetJobBuildReturns(originalBuild, true, nil)
+				})
+
+				Context("when re-running the build succeeds", func() {
+					BeforeEach(func() {
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(42)
+						build.NameReturns("4")
+						build.JobNameReturns("some-job")
+						build.PipelineNameReturns("a-pipeline")
+						build.TeamNameReturns("some-team")
+						build.StatusReturns(db.StatusPending)
+						build.RerunOfReturns(3)
+						fakeScheduler.RerunImmediatelyReturns(build, nil, nil)
+					})
+
+					It("re-runs the requested build using the current config", func() {
+						jobName, buildName := pipelineDB.GetJobBuildArgsForCall(0)
+						Expect(jobName).To(Equal("some-job"))
+						Expect(buildName).To(Equal("3"))
+
+						Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(1))
+
+						_, build, job, resources, _ := fakeScheduler.RerunImmediatelyArgsForCall(0)
+						Expect(build).To(Equal(originalBuild))
+						Expect(job.Name).To(Equal("some-job"))
+						Expect(resources).To(Equal(atc.ResourceConfigs{
+							{Name: "resource-1", Type: "some-type"},
+						}))
+					})
+
+					It("returns the new build, linked to the original", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"name": "4",
+							"job_name": "some-job",
+							"status": "pending",
+							"url": "/teams/some-team/pipelines/a-pipeline/jobs/some-job/builds/4",
+							"api_url": "/api/v1/builds/42",
+							"pipeline_name": "a-pipeline",
+							"team_name": "some-team",
+							"rerun_of": 3
+						}`))
+					})
+				})
+
+				Context("when re-running the build fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.RerunImmediatelyReturns(nil, nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the original build has not finished", func() {
+					BeforeEach(func() {
+						originalBuild.StatusReturns(db.StatusStarted)
+						originalBuild.IsRunningReturns(true)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the original build has no inputs", func() {
+					BeforeEach(func() {
+						originalBuild.GetResourcesReturns([]db.BuildInput{}, nil, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when getting the original build's inputs fails", func() {
+					BeforeEach(func() {
+						originalBuild.GetResourcesReturns(nil, nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when manual triggering is disabled", func() {
+					BeforeEach(func() {
+						pipelineDB.ConfigReturns(atc.Config{
+							Jobs: []atc.JobConfig{
+								{Name: "some-job", DisableManualTrigger: true},
+							},
+						})
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the original build does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the original build fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildReturns(nil, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeScheduler.RerunImmediatelyCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
 
@@ -1472,6 +1703,126 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tests", func() {
+		var response *http.Response
+		var queryString string
+
+		BeforeEach(func() {
+			queryString = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/tests" + queryString)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the job exists", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job"},
+						},
+					})
+				})
+
+				Context("when getting the test runs succeeds", func() {
+					BeforeEach(func() {
+						pipelineDB.GetJobTestRunsReturns([]db.JobTestRun{
+							{BuildID: 3, BuildName: "3", Result: atc.TestResult{Suite: "some-suite", Name: "some-test", Status: atc.TestStatusFailed, Duration: 2}},
+							{BuildID: 3, BuildName: "3", Result: atc.TestResult{Suite: "some-suite", Name: "other-test", Status: atc.TestStatusPassed, Duration: 1}},
+							{BuildID: 2, BuildName: "2", Result: atc.TestResult{Suite: "some-suite", Name: "some-test", Status: atc.TestStatusPassed, Duration: 1.5}},
+							{BuildID: 2, BuildName: "2", Result: atc.TestResult{Suite: "some-suite", Name: "other-test", Status: atc.TestStatusSkipped, Duration: 0}},
+						}, nil)
+					})
+
+					It("looks up the runs of the job's latest 25 builds", func() {
+						Expect(pipelineDB.GetJobTestRunsCallCount()).To(Equal(1))
+						jobName, builds := pipelineDB.GetJobTestRunsArgsForCall(0)
+						Expect(jobName).To(Equal("some-job"))
+						Expect(builds).To(Equal(25))
+					})
+
+					It("returns each test's history, flagging flaky tests", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"suite": "some-suite",
+								"name": "some-test",
+								"flaky": true,
+								"runs": [
+									{"build_id": 3, "build_name": "3", "status": "failed", "duration": 2},
+									{"build_id": 2, "build_name": "2", "status": "passed", "duration": 1.5}
+								]
+							},
+							{
+								"suite": "some-suite",
+								"name": "other-test",
+								"flaky": false,
+								"runs": [
+									{"build_id": 3, "build_name": "3", "status": "passed", "duration": 1},
+									{"build_id": 2, "build_name": "2", "status": "skipped", "duration": 0}
+								]
+							}
+						]`))
+					})
+
+					Context("when the number of builds is given", func() {
+						BeforeEach(func() {
+							queryString = "?builds=5"
+						})
+
+						It("looks up the runs of that many builds", func() {
+							_, builds := pipelineDB.GetJobTestRunsArgsForCall(0)
+							Expect(builds).To(Equal(5))
+						})
+					})
+				})
+
+				Context("when getting the test runs fails", func() {
+					BeforeEach(func() {
+						pipelineDB.GetJobTestRunsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -0,0 +1,42 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const defaultTestHistoryBuilds = 25
+
+func (s *Server) ListJobTests(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("list-job-tests")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		_, found := pipelineDB.Config().Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		builds, _ := strconv.Atoi(r.FormValue("builds"))
+		if builds <= 0 {
+			builds = defaultTestHistoryBuilds
+		}
+
+		runs, err := pipelineDB.GetJobTestRuns(jobName, builds)
+		if err != nil {
+			logger.Error("failed-to-get-job-test-runs", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(present.JobTestHistory(runs))
+	})
+}
@@ -0,0 +1,81 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) RerunJobBuild(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+		buildName := r.FormValue(":build_name")
+
+		logger := s.logger.Session("rerun-job-build", lager.Data{
+			"job":   jobName,
+			"build": buildName,
+		})
+
+		config := pipelineDB.Config()
+
+		job, found := config.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if job.DisableManualTrigger {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		originalBuild, found, err := pipelineDB.GetJobBuild(jobName, buildName)
+		if err != nil {
+			logger.Error("failed-to-get-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if originalBuild.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "build has not finished")
+			return
+		}
+
+		inputs, _, err := originalBuild.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-build-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// without its inputs the rerun would start from nothing rather than
+		// the versions the original build ran with
+		if len(inputs) == 0 {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "build has no inputs to rerun with")
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
+
+		build, _, err := scheduler.RerunImmediately(logger, originalBuild, job, config.Resources, config.ResourceTypes)
+		if err != nil {
+			logger.Error("failed-to-rerun", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to rerun: %s", err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(present.Build(build))
+	})
+}
@@ -91,6 +91,23 @@ var _ = Describe("Log Level API", func() {
 				})
 			})
 
+			Context("is admin with a viewer role", func() {
+				BeforeEach(func() {
+					logLevelPayload = string(atc.LogLevelError)
+
+					userContextReader.GetTeamReturns("main", 42, true, true)
+					userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("does not change the level", func() {
+					Expect(sink.GetMinLevel()).To(Equal(lager.DEBUG))
+				})
+			})
+
 			Context("is not admin", func() {
 				It("return 403 Forbidden", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
@@ -112,6 +112,7 @@ var _ = Describe("Pipelines API", func() {
 
 		Context("when team is set in user context", func() {
 			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
 				userContextReader.GetTeamReturns("some-team", 5, false, true)
 			})
 
@@ -121,6 +122,23 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when the token carries a team but is no longer valid, e.g. a revoked API token", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("main", 5, false, true)
+			})
+
+			It("returns only public pipelines", func() {
+				Expect(teamDBFactory.GetTeamDBCallCount()).To(BeZero())
+				Expect(pipelinesDB.GetAllPublicPipelinesCallCount()).To(Equal(1))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).NotTo(ContainSubstring("private-pipeline"))
+			})
+		})
+
 		Context("when not authenticated", func() {
 			BeforeEach(func() {
 				userContextReader.GetTeamReturns("", 5, false, false)
@@ -403,6 +421,18 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when the token carries the requested team but is no longer valid, e.g. a revoked API token", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("a-team", 1, true, true)
+				pipelineDB.IsPublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
 		Context("when authenticated as requested team", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(true)
@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:        event.ID,
+		Route:     event.Route,
+		User:      event.User,
+		Team:      event.Team,
+		Target:    event.Target,
+		Status:    event.Status,
+		CreatedAt: event.CreatedAt.Unix(),
+	}
+}
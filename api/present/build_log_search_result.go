@@ -0,0 +1,22 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildLogSearchResult(matches db.BuildLogMatches) atc.BuildLogSearchResult {
+	presented := atc.BuildLogSearchResult{
+		BuildID: matches.BuildID,
+		Matches: make([]atc.BuildLogMatch, len(matches.Lines)),
+	}
+
+	for i, line := range matches.Lines {
+		presented.Matches[i] = atc.BuildLogMatch{
+			OriginID: line.OriginID,
+			Excerpt:  line.Excerpt,
+		}
+	}
+
+	return presented
+}
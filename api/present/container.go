@@ -10,6 +10,13 @@ func Container(container db.SavedContainer) atc.Container {
 	if container.Type != db.ContainerTypeCheck {
 		stepType = container.Type.String()
 	}
+
+	var limits *atc.ContainerLimits
+	if container.ContainerLimits != (atc.ContainerLimits{}) {
+		containerLimits := container.ContainerLimits
+		limits = &containerLimits
+	}
+
 	return atc.Container{
 		ID:                   container.Handle,
 		TTLInSeconds:         int64(container.ExpiresIn.Seconds()),
@@ -26,5 +33,6 @@ func Container(container db.SavedContainer) atc.Container {
 		EnvironmentVariables: container.EnvironmentVariables,
 		Attempts:             container.Attempts,
 		User:                 container.User,
+		ContainerLimits:      limits,
 	}
 }
@@ -1,8 +1,11 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
 	"github.com/tedsuo/rata"
@@ -69,6 +72,14 @@ func Job(
 		})
 	}
 
+	var nextTriggerTime int64
+
+	// triggers are validated when the config is saved
+	nextTrigger, fires, err := cron.NextTrigger(job.Config.Triggers, time.Now())
+	if err == nil && fires {
+		nextTriggerTime = nextTrigger.Unix()
+	}
+
 	return atc.Job{
 		Name:                 job.Name,
 		URL:                  req.URL.String(),
@@ -77,6 +88,7 @@ func Job(
 		FirstLoggedBuildID:   job.FirstLoggedBuildID,
 		FinishedBuild:        presentedFinishedBuild,
 		NextBuild:            presentedNextBuild,
+		NextTriggerTime:      nextTriggerTime,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
@@ -0,0 +1,57 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// JobTestHistory groups a job's test runs by test, in the order the tests
+// were first seen.
+func JobTestHistory(runs []db.JobTestRun) []atc.JobTestHistory {
+	histories := []atc.JobTestHistory{}
+	indices := map[[2]string]int{}
+
+	for _, run := range runs {
+		key := [2]string{run.Result.Suite, run.Result.Name}
+
+		i, found := indices[key]
+		if !found {
+			i = len(histories)
+			indices[key] = i
+
+			histories = append(histories, atc.JobTestHistory{
+				Suite: run.Result.Suite,
+				Name:  run.Result.Name,
+				Runs:  []atc.TestRun{},
+			})
+		}
+
+		histories[i].Runs = append(histories[i].Runs, atc.TestRun{
+			BuildID:   run.BuildID,
+			BuildName: run.BuildName,
+			Status:    run.Result.Status,
+			Duration:  run.Result.Duration,
+		})
+	}
+
+	for i, history := range histories {
+		histories[i].Flaky = isFlaky(history.Runs)
+	}
+
+	return histories
+}
+
+func isFlaky(runs []atc.TestRun) bool {
+	var passed, failed bool
+
+	for _, run := range runs {
+		switch run.Status {
+		case atc.TestStatusPassed:
+			passed = true
+		case atc.TestStatusFailed, atc.TestStatusErrored:
+			failed = true
+		}
+	}
+
+	return passed && failed
+}
@@ -45,6 +45,8 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 
 		Paused: resource.Paused,
 
+		PinnedVersion: resource.PinnedVersion,
+
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
@@ -0,0 +1,38 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// Webhook presents a webhook without its secret, which is write-only.
+func Webhook(webhook db.SavedWebhook) atc.Webhook {
+	return atc.Webhook{
+		Name: webhook.Name,
+		URL:  webhook.URL,
+	}
+}
+
+func WebhookDelivery(delivery db.WebhookDelivery) atc.WebhookDelivery {
+	presented := atc.WebhookDelivery{
+		ID:             delivery.ID,
+		BuildID:        delivery.BuildID,
+		BuildStatus:    string(delivery.BuildStatus),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt.Unix(),
+	}
+
+	if delivery.Status == db.WebhookDeliveryPending {
+		presented.NextAttemptAt = delivery.NextAttemptAt.Unix()
+	}
+
+	if !delivery.DeliveredAt.IsZero() {
+		presented.DeliveredAt = delivery.DeliveredAt.Unix()
+	}
+
+	return presented
+}
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials/credentialsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -330,6 +331,7 @@ var _ = Describe("Resources API", func() {
 						Config: atc.ResourceConfig{
 							Type: "type-1",
 						},
+						PinnedVersion: atc.Version{"version": "v1"},
 					}, true, nil)
 					fakePipelineDB.ConfigReturns(atc.Config{
 						Groups: []atc.GroupConfig{
@@ -360,6 +362,7 @@ var _ = Describe("Resources API", func() {
 								"groups": ["group-1", "group-2"],
 								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
 								"paused": true,
+								"pinned_version": {"version": "v1"},
 								"failing_to_check": true,
 								"check_error": "sup"
 							}`))
@@ -532,6 +535,81 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{
+					Name: "resource-name",
+				},
+				PinnedVersion: atc.Version{"version": "1"},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceReturns(nil)
+				})
+
+				It("unpinned the right resource", func() {
+					Expect(fakePipelineDB.UnpinResourceArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakePipelineDB.UnpinResourceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
@@ -704,4 +782,191 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			webhookToken = "some-token"
+
+			fakePipelineDB.GetResourceReturns(db.SavedResource{
+				Resource: db.Resource{Name: "resource-name"},
+				Config: atc.ResourceConfig{
+					Name:         "resource-name",
+					WebhookToken: "some-token",
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			Context("when the token matches", func() {
+				It("looks up the pipeline by the team in the url", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("a-pipeline"))
+					Expect(fakePipelineDB.GetResourceArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("scans from the latest version", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualResourceName).To(Equal("resource-name"))
+					Expect(actualFromVersion).To(BeNil())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when the resource already has versions", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+							VersionedResource: db.VersionedResource{
+								Version: db.Version{"some": "version"},
+							},
+						}, true, nil)
+					})
+
+					It("scans from the latest version", func() {
+						_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+						Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
+					})
+				})
+
+				Context("when checking fails with ErrResourceScriptFailed", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionReturns(resource.ErrResourceScriptFailed{
+							ExitStatus: 42,
+							Stderr:     "my tooth",
+						})
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the token does not match", func() {
+				BeforeEach(func() {
+					webhookToken = "wrong-token"
+				})
+
+				It("returns 401 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource has no webhook token", func() {
+				BeforeEach(func() {
+					webhookToken = ""
+
+					fakePipelineDB.GetResourceReturns(db.SavedResource{
+						Config: atc.ResourceConfig{Name: "resource-name"},
+					}, true, nil)
+				})
+
+				It("returns 401 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the webhook token is a variable", func() {
+				var fakeVariables *credentialsfakes.FakeVariables
+
+				BeforeEach(func() {
+					fakeVariables = new(credentialsfakes.FakeVariables)
+					fakeCredentialsManager.VariablesReturns(fakeVariables)
+
+					fakePipelineDB.TeamNameReturns("a-team")
+					fakePipelineDB.GetPipelineNameReturns("a-pipeline")
+
+					fakePipelineDB.GetResourceReturns(db.SavedResource{
+						Resource: db.Resource{Name: "resource-name"},
+						Config: atc.ResourceConfig{
+							Name:         "resource-name",
+							WebhookToken: "((webhook-token))",
+						},
+					}, true, nil)
+				})
+
+				Context("when the variable is a string", func() {
+					BeforeEach(func() {
+						fakeVariables.GetReturns("some-token", true, nil)
+					})
+
+					It("looks up the pipeline's variables", func() {
+						teamName, pipelineName := fakeCredentialsManager.VariablesArgsForCall(0)
+						Expect(teamName).To(Equal("a-team"))
+						Expect(pipelineName).To(Equal("a-pipeline"))
+						Expect(fakeVariables.GetArgsForCall(0)).To(Equal("webhook-token"))
+					})
+
+					It("compares against its value", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					})
+
+					Context("when the variable's name is given as the token", func() {
+						BeforeEach(func() {
+							webhookToken = "((webhook-token))"
+						})
+
+						It("returns 401 without scanning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the variable is undefined", func() {
+					BeforeEach(func() {
+						fakeVariables.GetReturns(nil, false, nil)
+					})
+
+					It("returns 500 without scanning", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the resource fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceReturns(db.SavedResource{}, false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
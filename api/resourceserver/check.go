@@ -39,25 +39,29 @@ func (s *Server) CheckResource(pipelineDB db.PipelineDB) http.Handler {
 			}
 		}
 
-		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
-
-		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
-		switch scanErr := err.(type) {
-		case resource.ErrResourceScriptFailed:
-			checkResponseBody := atc.CheckResponseBody{
-				ExitStatus: scanErr.ExitStatus,
-				Stderr:     scanErr.Stderr,
-			}
+		s.scan(logger, w, pipelineDB, resourceName, fromVersion)
+	})
+}
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(checkResponseBody)
-		case db.ResourceNotFoundError:
-			w.WriteHeader(http.StatusNotFound)
-		case error:
-			w.WriteHeader(http.StatusInternalServerError)
-		default:
-			w.WriteHeader(http.StatusOK)
+func (s *Server) scan(logger lager.Logger, w http.ResponseWriter, pipelineDB db.PipelineDB, resourceName string, fromVersion atc.Version) {
+	scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+
+	err := scanner.ScanFromVersion(logger, resourceName, fromVersion)
+	switch scanErr := err.(type) {
+	case resource.ErrResourceScriptFailed:
+		checkResponseBody := atc.CheckResponseBody{
+			ExitStatus: scanErr.ExitStatus,
+			Stderr:     scanErr.Stderr,
 		}
-	})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(checkResponseBody)
+	case db.ResourceNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	case error:
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
 }
@@ -0,0 +1,66 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// CheckResourceWebhook is not authenticated; instead the caller must present
+// the resource's configured webhook_token, once its variables are evaluated.
+// Resources without a token cannot be checked this way.
+func (s *Server) CheckResourceWebhook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		savedResource, found, err := pipelineDB.GetResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		variables := credentials.VariablesFor(s.credentials, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+
+		configuredToken, err := credentials.EvaluateString(variables, savedResource.Config.WebhookToken)
+		if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err, lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if configuredToken == "" || subtle.ConstantTimeCompare([]byte(configuredToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var fromVersion atc.Version
+
+		latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
+		if err != nil {
+			logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found {
+			fromVersion = atc.Version(latestVersion.Version)
+		}
+
+		s.scan(logger, w, pipelineDB, resourceName, fromVersion)
+	})
+}
@@ -502,6 +502,104 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("LDAP Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						LDAPAuth: &atc.LDAPAuth{
+							Host:             "ldap.example.com:636",
+							UserSearchBaseDN: "ou=people,dc=example,dc=com",
+						},
+					}
+				})
+
+				Context("when passed a valid team with LDAP Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("Host not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Host = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("UserSearchBaseDN not filled in", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.UserSearchBaseDN = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Groups without a GroupSearchBaseDN", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.Groups = []string{"some-group"}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("CACert is invalid", func() {
+					BeforeEach(func() {
+						team.LDAPAuth.CACert = "not a certificate"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("roles", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						Roles: atc.TeamRoleBindings{
+							{Role: atc.TeamRoleViewer, Groups: []string{"some-org"}},
+						},
+					}
+				})
+
+				Context("when passed valid roles", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+
+					It("creates the team with the roles", func() {
+						Expect(teamServerDB.CreateTeamCallCount()).To(Equal(1))
+						Expect(teamServerDB.CreateTeamArgsForCall(0).Roles).To(Equal(team.Roles))
+					})
+				})
+
+				Context("when a role is unknown", func() {
+					BeforeEach(func() {
+						team.Roles[0].Role = "overlord"
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when a role is not bound to anyone", func() {
+					BeforeEach(func() {
+						team.Roles[0].Groups = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -538,6 +636,7 @@ var _ = Describe("Teams API", func() {
 					var gitHubAuth *atc.GitHubAuth
 					var uaaAuth *atc.UAAAuth
 					var genericOAuth *atc.GenericOAuth
+					var ldapAuth *atc.LDAPAuth
 
 					BeforeEach(func() {
 						basicAuth = &atc.BasicAuth{
@@ -569,6 +668,15 @@ var _ = Describe("Teams API", func() {
 							DisplayName:   "CSI",
 							Scope:         "readonly",
 						}
+
+						ldapAuth = &atc.LDAPAuth{
+							Host:              "ldap.example.com:636",
+							BindDN:            "cn=concourse,dc=example,dc=com",
+							BindPassword:      "Giant Boy Detective",
+							UserSearchBaseDN:  "ou=people,dc=example,dc=com",
+							GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+							Groups:            []string{"CSI"},
+						}
 					})
 
 					Context("when passed basic auth credentials", func() {
@@ -669,6 +777,44 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed LDAP auth config", func() {
+						BeforeEach(func() {
+							teamDB.UpdateLDAPAuthStub = func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+								team.Name = teamName
+								Expect(ldapAuth.Host).To(Equal(team.LDAPAuth.Host))
+								Expect(ldapAuth.BindDN).To(Equal(team.LDAPAuth.BindDN))
+								Expect(ldapAuth.BindPassword).To(Equal(team.LDAPAuth.BindPassword))
+								Expect(ldapAuth.UserSearchBaseDN).To(Equal(team.LDAPAuth.UserSearchBaseDN))
+								Expect(ldapAuth.GroupSearchBaseDN).To(Equal(team.LDAPAuth.GroupSearchBaseDN))
+								Expect(ldapAuth.Groups).To(Equal(team.LDAPAuth.Groups))
+
+								savedTeam.LDAPAuth = ldapAuth
+								return savedTeam, nil
+							}
+
+							team.LDAPAuth = ldapAuth
+						})
+
+						It("updates the LDAP auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateLDAPAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed roles", func() {
+						BeforeEach(func() {
+							team.Roles = atc.TeamRoleBindings{
+								{Role: atc.TeamRoleOwner, Users: []string{"Dean Venture"}},
+							}
+						})
+
+						It("updates the roles for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateRolesCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateRolesArgsForCall(0)).To(Equal(team.Roles))
+						})
+					})
+
 				})
 			})
 
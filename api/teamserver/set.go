@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
@@ -102,6 +103,16 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateLDAPAuth(team.LDAPAuth)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamDB.UpdateRoles(team.Roles)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,5 +177,41 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.LDAPAuth != nil {
+		if team.LDAPAuth.Host == "" {
+			return errors.New("LDAP auth requires a Host")
+		}
+
+		if team.LDAPAuth.UserSearchBaseDN == "" {
+			return errors.New("LDAP auth requires a UserSearchBaseDN")
+		}
+
+		if len(team.LDAPAuth.Groups) > 0 && team.LDAPAuth.GroupSearchBaseDN == "" {
+			return errors.New("LDAP auth requires a GroupSearchBaseDN to restrict access to Groups")
+		}
+
+		if team.LDAPAuth.CACert != "" {
+			block, _ := pem.Decode([]byte(team.LDAPAuth.CACert))
+			if block == nil {
+				return errors.New("LDAP CA certificate is invalid")
+			}
+
+			_, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return errors.New("LDAP CA certificate is invalid")
+			}
+		}
+	}
+
+	for _, binding := range team.Roles {
+		if !binding.Role.IsValid() {
+			return fmt.Errorf("unknown role '%s'", binding.Role)
+		}
+
+		if len(binding.Users) == 0 && len(binding.Groups) == 0 {
+			return fmt.Errorf("role '%s' must be bound to at least one User or Group", binding.Role)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,92 @@
+package tokenserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+// CreateAPIToken generates a token for the team with the requested role,
+// which defaults to viewer.
+func (s *Server) CreateAPIToken(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("create-api-token")
+
+		var request atc.APIToken
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if request.Name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "token name must be specified")
+			return
+		}
+
+		if request.Role == "" {
+			request.Role = atc.TeamRoleViewer
+		}
+
+		if !request.Role.IsValid() {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown role: %s", request.Role)
+			return
+		}
+
+		team, found, err := teamDB.GetTeam()
+		if err != nil {
+			logger.Error("failed-to-get-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// a token scoped to a lesser role must not be able to administer the
+		// whole ATC just because it belongs to the admin team
+		isAdmin := team.Admin && request.Role == atc.TeamRoleOwner
+
+		tokenType, tokenValue, err := s.tokenGenerator.GenerateAPIToken(team.Name, team.ID, isAdmin, request.Role, request.Name)
+		if err != nil {
+			logger.Error("failed-to-generate-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		savedToken, created, err := teamDB.CreateAPIToken(db.APIToken{
+			Name:      request.Name,
+			Role:      request.Role,
+			TokenHash: auth.HashAPIToken(tokenValue),
+		})
+		if err != nil {
+			logger.Error("failed-to-save-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !created {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "token already exists: %s", request.Name)
+			return
+		}
+
+		presented := present.APIToken(savedToken)
+		presented.Token = string(tokenType) + " " + string(tokenValue)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		json.NewEncoder(w).Encode(presented)
+	})
+}
@@ -0,0 +1,33 @@
+package tokenserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) RevokeAPIToken(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenName := rata.Param(r, "token_name")
+
+		logger := s.logger.Session("revoke-api-token", lager.Data{
+			"token": tokenName,
+		})
+
+		found, err := teamDB.DeleteAPIToken(tokenName)
+		if err != nil {
+			logger.Error("failed-to-delete-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,21 @@
+package tokenserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth"
+)
+
+type Server struct {
+	logger         lager.Logger
+	tokenGenerator auth.TokenGenerator
+}
+
+func NewServer(
+	logger lager.Logger,
+	tokenGenerator auth.TokenGenerator,
+) *Server {
+	return &Server{
+		logger:         logger,
+		tokenGenerator: tokenGenerator,
+	}
+}
@@ -0,0 +1,357 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks API", func() {
+	Describe("GET /api/v1/teams/:team_name/webhooks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/webhooks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("another-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when getting the webhooks succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetWebhooksReturns([]db.SavedWebhook{
+						{
+							ID:     1,
+							TeamID: 42,
+							Webhook: db.Webhook{
+								Name:   "some-webhook",
+								URL:    "https://example.com/hook",
+								Secret: "shh",
+							},
+						},
+					}, nil)
+				})
+
+				It("returns the webhooks without their secrets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "some-webhook",
+							"url": "https://example.com/hook"
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the webhooks fails", func() {
+				BeforeEach(func() {
+					teamDB.GetWebhooksReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"url":"https://example.com/hook","secret":"shh"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/webhooks/some-webhook", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the webhook is created", func() {
+				BeforeEach(func() {
+					teamDB.SaveWebhookReturns(db.SavedWebhook{
+						ID: 1,
+						Webhook: db.Webhook{
+							Name: "some-webhook",
+							URL:  "https://example.com/hook",
+						},
+					}, true, nil)
+				})
+
+				It("saves the webhook with the name from the url", func() {
+					Expect(teamDB.SaveWebhookCallCount()).To(Equal(1))
+					Expect(teamDB.SaveWebhookArgsForCall(0)).To(Equal(db.Webhook{
+						Name:   "some-webhook",
+						URL:    "https://example.com/hook",
+						Secret: "shh",
+					}))
+				})
+
+				It("returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+			})
+
+			Context("when the webhook is updated", func() {
+				BeforeEach(func() {
+					teamDB.SaveWebhookReturns(db.SavedWebhook{}, false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the url is not http(s)", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"ftp://example.com/hook"}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					teamDB.SaveWebhookReturns(db.SavedWebhook{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/webhooks/some-webhook", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					teamDB.DeleteWebhookReturns(true, nil)
+				})
+
+				It("deletes it and returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(teamDB.DeleteWebhookArgsForCall(0)).To(Equal("some-webhook"))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					teamDB.DeleteWebhookReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks/:webhook_name/deliveries", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/webhooks/some-webhook/deliveries")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					teamDB.GetWebhookDeliveriesReturns([]db.WebhookDelivery{
+						{
+							ID:             3,
+							WebhookID:      1,
+							BuildID:        128,
+							BuildStatus:    db.StatusFailed,
+							Payload:        `{"id":128}`,
+							Status:         db.WebhookDeliverySucceeded,
+							Attempts:       2,
+							ResponseStatus: 200,
+							CreatedAt:      time.Unix(100, 0),
+							NextAttemptAt:  time.Unix(110, 0),
+							DeliveredAt:    time.Unix(120, 0),
+						},
+					}, true, nil)
+				})
+
+				It("returns the delivery log", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(teamDB.GetWebhookDeliveriesArgsForCall(0)).To(Equal("some-webhook"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 3,
+							"build_id": 128,
+							"build_status": "failed",
+							"payload": "{\"id\":128}",
+							"status": "succeeded",
+							"attempts": 2,
+							"response_status": 200,
+							"created_at": 100,
+							"delivered_at": 120
+						}
+					]`))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetWebhookDeliveriesReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/webhooks/:webhook_name/deliveries/:delivery_id/redeliver", func() {
+		var deliveryID string
+		var response *http.Response
+
+		BeforeEach(func() {
+			deliveryID = "3"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/webhooks/some-webhook/deliveries/"+deliveryID+"/redeliver", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+			})
+
+			Context("when the delivery exists", func() {
+				BeforeEach(func() {
+					teamDB.RedeliverWebhookDeliveryReturns(db.WebhookDelivery{
+						ID:     4,
+						Status: db.WebhookDeliveryPending,
+					}, true, nil)
+				})
+
+				It("queues a redelivery and returns 201", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					webhookName, id := teamDB.RedeliverWebhookDeliveryArgsForCall(0)
+					Expect(webhookName).To(Equal("some-webhook"))
+					Expect(id).To(Equal(3))
+				})
+			})
+
+			Context("when the delivery does not exist", func() {
+				BeforeEach(func() {
+					teamDB.RedeliverWebhookDeliveryReturns(db.WebhookDelivery{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the delivery id is not a number", func() {
+				BeforeEach(func() {
+					deliveryID = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.RedeliverWebhookDeliveryCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+})
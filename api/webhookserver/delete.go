@@ -0,0 +1,33 @@
+package webhookserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) DeleteWebhook(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := rata.Param(r, "webhook_name")
+
+		logger := s.logger.Session("delete-webhook", lager.Data{
+			"webhook": webhookName,
+		})
+
+		found, err := teamDB.DeleteWebhook(webhookName)
+		if err != nil {
+			logger.Error("failed-to-delete-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,75 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListWebhookDeliveries(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := rata.Param(r, "webhook_name")
+
+		logger := s.logger.Session("list-webhook-deliveries", lager.Data{
+			"webhook": webhookName,
+		})
+
+		deliveries, found, err := teamDB.GetWebhookDeliveries(webhookName)
+		if err != nil {
+			logger.Error("failed-to-get-webhook-deliveries", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		presentedDeliveries := make([]atc.WebhookDelivery, len(deliveries))
+		for i, delivery := range deliveries {
+			presentedDeliveries[i] = present.WebhookDelivery(delivery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(presentedDeliveries)
+	})
+}
+
+func (s *Server) RedeliverWebhook(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := rata.Param(r, "webhook_name")
+
+		logger := s.logger.Session("redeliver-webhook", lager.Data{
+			"webhook": webhookName,
+		})
+
+		deliveryID, err := strconv.Atoi(rata.Param(r, "delivery_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		delivery, found, err := teamDB.RedeliverWebhookDelivery(webhookName, deliveryID)
+		if err != nil {
+			logger.Error("failed-to-redeliver", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(present.WebhookDelivery(delivery))
+	})
+}
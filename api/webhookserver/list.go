@@ -0,0 +1,31 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListWebhooks(teamDB db.TeamDB) http.Handler {
+	logger := s.logger.Session("list-webhooks")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhooks, err := teamDB.GetWebhooks()
+		if err != nil {
+			logger.Error("failed-to-get-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedWebhooks := make([]atc.Webhook, len(webhooks))
+		for i, webhook := range webhooks {
+			presentedWebhooks[i] = present.Webhook(webhook)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(presentedWebhooks)
+	})
+}
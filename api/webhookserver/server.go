@@ -0,0 +1,13 @@
+package webhookserver
+
+import "code.cloudfoundry.org/lager"
+
+type Server struct {
+	logger lager.Logger
+}
+
+func NewServer(logger lager.Logger) *Server {
+	return &Server{
+		logger: logger,
+	}
+}
@@ -0,0 +1,78 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) SetWebhook(teamDB db.TeamDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhookName := rata.Param(r, "webhook_name")
+
+		logger := s.logger.Session("set-webhook", lager.Data{
+			"webhook": webhookName,
+		})
+
+		var webhook atc.Webhook
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = validateURL(webhook.URL)
+		if err != nil {
+			logger.Info("invalid-url", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err)
+			return
+		}
+
+		savedWebhook, created, err := teamDB.SaveWebhook(db.Webhook{
+			Name:   webhookName,
+			URL:    webhook.URL,
+			Secret: webhook.Secret,
+		})
+		if err != nil {
+			logger.Error("failed-to-save-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		json.NewEncoder(w).Encode(present.Webhook(savedWebhook))
+	})
+}
+
+func validateURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %s", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("url must be http or https: %q", webhookURL)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("url has no host: %q", webhookURL)
+	}
+
+	return nil
+}
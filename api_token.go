@@ -0,0 +1,11 @@
+package atc
+
+// APIToken is a long-lived token for automation to use in place of logging
+// in. Token is only ever shown in the response to creating it.
+type APIToken struct {
+	Name       string   `json:"name"`
+	Role       TeamRole `json:"role"`
+	Token      string   `json:"token,omitempty"`
+	CreatedAt  int64    `json:"created_at"`
+	LastUsedAt int64    `json:"last_used_at,omitempty"`
+}
@@ -0,0 +1,9 @@
+package archive
+
+import "fmt"
+
+// BuildArtifactKey is the key under which a build's retained output is
+// stored, as a tarball of the output's directory.
+func BuildArtifactKey(buildID int, name string) string {
+	return fmt.Sprintf("build-artifacts/%d/%s.tar", buildID, name)
+}
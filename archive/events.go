@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc/event"
+)
+
+// BuildEventsKey is the key under which a build's event stream is archived.
+func BuildEventsKey(buildID int) string {
+	return fmt.Sprintf("build-events/%d.json.gz", buildID)
+}
+
+// EventWriter encodes event envelopes as gzipped, newline-delimited JSON.
+type EventWriter struct {
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+func NewEventWriter(w io.Writer) *EventWriter {
+	gz := gzip.NewWriter(w)
+
+	return &EventWriter{
+		gz:  gz,
+		enc: json.NewEncoder(gz),
+	}
+}
+
+func (writer *EventWriter) Write(ev event.Envelope) error {
+	return writer.enc.Encode(ev)
+}
+
+// Close flushes the compressed stream. It does not close the underlying
+// writer.
+func (writer *EventWriter) Close() error {
+	return writer.gz.Close()
+}
+
+// EventReader decodes a stream written by an EventWriter.
+type EventReader struct {
+	blob io.ReadCloser
+	gz   *gzip.Reader
+	dec  *json.Decoder
+}
+
+func NewEventReader(blob io.ReadCloser) (*EventReader, error) {
+	gz, err := gzip.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return nil, err
+	}
+
+	return &EventReader{
+		blob: blob,
+		gz:   gz,
+		dec:  json.NewDecoder(gz),
+	}, nil
+}
+
+// Read returns the next event, or io.EOF once the stream is exhausted.
+func (reader *EventReader) Read() (event.Envelope, error) {
+	var ev event.Envelope
+	err := reader.dec.Decode(&ev)
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
+
+func (reader *EventReader) Close() error {
+	reader.gz.Close()
+	return reader.blob.Close()
+}
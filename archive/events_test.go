@@ -0,0 +1,60 @@
+package archive_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Events", func() {
+	envelope := func(payload string) event.Envelope {
+		data := json.RawMessage(payload)
+
+		return event.Envelope{
+			Data:    &data,
+			Event:   "log",
+			Version: "5.0",
+		}
+	}
+
+	It("reads back the events that were written, in order", func() {
+		buf := new(bytes.Buffer)
+
+		writer := archive.NewEventWriter(buf)
+		Expect(writer.Write(envelope(`{"payload":"hello"}`))).To(Succeed())
+		Expect(writer.Write(envelope(`{"payload":"world"}`))).To(Succeed())
+		Expect(writer.Close()).To(Succeed())
+
+		reader, err := archive.NewEventReader(ioutil.NopCloser(buf))
+		Expect(err).NotTo(HaveOccurred())
+
+		defer reader.Close()
+
+		ev, err := reader.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).To(Equal(envelope(`{"payload":"hello"}`)))
+
+		ev, err = reader.Read()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).To(Equal(envelope(`{"payload":"world"}`)))
+
+		_, err = reader.Read()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	It("fails to read a blob that is not compressed", func() {
+		_, err := archive.NewEventReader(ioutil.NopCloser(bytes.NewBufferString("bogus")))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("keys archived build events by build ID", func() {
+		Expect(archive.BuildEventsKey(42)).To(Equal("build-events/42.json.gz"))
+	})
+})
@@ -0,0 +1,70 @@
+package local
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/archive"
+)
+
+// Store keeps archived blobs as files beneath a directory, with keys mapping
+// to relative paths.
+type Store struct {
+	Dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{
+		Dir: dir,
+	}
+}
+
+func (store *Store) Put(key string, content io.Reader) error {
+	path := store.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written blob is
+	// never visible under the key
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".partial-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (store *Store) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, archive.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *Store) path(key string) string {
+	return filepath.Join(store.Dir, filepath.FromSlash(key))
+}
@@ -0,0 +1,68 @@
+package local_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/archive/local"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir   string
+		store *local.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "local-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = local.NewStore(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("stores blobs in files beneath the directory", func() {
+		err := store.Put("some/key", bytes.NewBufferString("some-content"))
+		Expect(err).NotTo(HaveOccurred())
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "some", "key"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+
+		blob, err := store.Get("some/key")
+		Expect(err).NotTo(HaveOccurred())
+
+		defer blob.Close()
+
+		content, err = ioutil.ReadAll(blob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("replaces existing blobs", func() {
+		err := store.Put("some-key", bytes.NewBufferString("old-content"))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Put("some-key", bytes.NewBufferString("new-content"))
+		Expect(err).NotTo(HaveOccurred())
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "some-key"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("new-content"))
+	})
+
+	It("returns ErrNotFound for missing blobs", func() {
+		_, err := store.Get("bogus-key")
+		Expect(err).To(Equal(archive.ErrNotFound))
+	})
+})
@@ -0,0 +1,173 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc/archive"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// Store keeps archived blobs in a bucket of an S3-compatible API, addressed
+// path-style (ENDPOINT/BUCKET/KEY) so that it works with self-hosted
+// implementations as well as AWS. Requests are signed with AWS Signature
+// Version 4.
+type Store struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	Client *http.Client
+}
+
+func NewStore(endpoint string, region string, bucket string, accessKeyID string, secretAccessKey string) *Store {
+	return &Store{
+		Endpoint:        strings.TrimRight(endpoint, "/"),
+		Region:          region,
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+
+		Client: http.DefaultClient,
+	}
+}
+
+func (store *Store) Put(key string, content io.Reader) error {
+	// the API requires the length of the body up front, so the content is
+	// spooled to disk rather than buffered in memory
+	spool, err := ioutil.TempFile("", "archive-upload")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(spool, hash), content)
+	if err != nil {
+		return err
+	}
+
+	_, err = spool.Seek(0, 0)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", store.objectURL(key), ioutil.NopCloser(spool))
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+
+	store.sign(req, hex.EncodeToString(hash.Sum(nil)), time.Now())
+
+	resp, err := store.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload %s: %s", key, resp.Status)
+	}
+
+	return nil
+}
+
+func (store *Store) Get(key string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", store.objectURL(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	store.sign(req, emptyPayloadHash, time.Now())
+
+	resp, err := store.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, archive.ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", key, resp.Status)
+	}
+}
+
+func (store *Store) objectURL(key string) string {
+	return store.Endpoint + "/" + store.Bucket + "/" + key
+}
+
+func (store *Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + store.Region + "/s3/aws4_request"
+
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+store.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, store.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		store.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
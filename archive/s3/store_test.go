@@ -0,0 +1,103 @@
+package s3_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/archive/s3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		s3Server *ghttp.Server
+		store    *s3.Store
+	)
+
+	BeforeEach(func() {
+		s3Server = ghttp.NewServer()
+
+		store = s3.NewStore(s3Server.URL(), "some-region", "some-bucket", "some-access-key-id", "some-secret-access-key")
+	})
+
+	AfterEach(func() {
+		s3Server.Close()
+	})
+
+	verifySigned := func() http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("Authorization")).To(MatchRegexp(
+				`^AWS4-HMAC-SHA256 Credential=some-access-key-id/\d{8}/some-region/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`,
+			))
+			Expect(r.Header.Get("X-Amz-Date")).NotTo(BeEmpty())
+		}
+	}
+
+	Describe("Put", func() {
+		It("uploads the content to the bucket with its length and hash", func() {
+			s3Server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/some/key"),
+					verifySigned(),
+					ghttp.VerifyHeaderKV("X-Amz-Content-Sha256", "0a8cac771ca188eacc57e2c96c31f5611925c5ecedccb16b8c236d6c0d325112"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.ContentLength).To(Equal(int64(len("some-content"))))
+
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-content"))
+					},
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			err := store.Put("some/key", bytes.NewBufferString("some-content"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s3Server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when the upload is rejected", func() {
+			s3Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, ""),
+			)
+
+			err := store.Put("some/key", bytes.NewBufferString("some-content"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Get", func() {
+		It("downloads the content from the bucket", func() {
+			s3Server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-bucket/some/key"),
+					verifySigned(),
+					ghttp.RespondWith(http.StatusOK, "some-content"),
+				),
+			)
+
+			blob, err := store.Get("some/key")
+			Expect(err).NotTo(HaveOccurred())
+
+			defer blob.Close()
+
+			content, err := ioutil.ReadAll(blob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("some-content"))
+		})
+
+		It("returns ErrNotFound for missing blobs", func() {
+			s3Server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, ""),
+			)
+
+			_, err := store.Get("some/key")
+			Expect(err).To(Equal(archive.ErrNotFound))
+		})
+	})
+})
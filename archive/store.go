@@ -0,0 +1,18 @@
+package archive
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("archived blob not found")
+
+//go:generate counterfeiter . Store
+
+// A Store holds archived blobs, such as the event streams of builds whose
+// logs have been reaped from the database, or the outputs retained as
+// artifacts of builds.
+type Store interface {
+	Put(key string, content io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+}
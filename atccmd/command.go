@@ -19,10 +19,17 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/archive/local"
+	"github.com/concourse/atc/archive/s3"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/file"
+	"github.com/concourse/atc/credentials/vault"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -37,9 +44,11 @@ import (
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/web"
 	"github.com/concourse/atc/web/publichandler"
 	"github.com/concourse/atc/web/robotstxt"
+	"github.com/concourse/atc/webhooks"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
 	"github.com/concourse/atc/wrappa"
@@ -81,6 +90,9 @@ type ATCCommand struct {
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
+	MaxConcurrentBuilds        int `long:"max-concurrent-builds"          description:"Maximum number of job builds running at once across all teams. Pending builds are queued by job priority and then age."`
+	MaxConcurrentBuildsPerTeam int `long:"max-concurrent-builds-per-team" description:"Maximum number of job builds running at once within each team."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -104,6 +116,41 @@ type ATCCommand struct {
 
 	GenericOAuth atc.GenericOAuthFlag `group:"Generic OAuth Authentication (Allows access to ALL authenticated users)" namespace:"generic-oauth"`
 
+	LDAPAuth atc.LDAPAuthFlag `group:"LDAP Authentication" namespace:"ldap-auth"`
+
+	Credentials struct {
+		File FileFlag `long:"credentials-file" description:"YAML file containing team and pipeline variables to interpolate into pipeline configs."`
+
+		VaultURL         URLFlag `long:"vault-url"          description:"Vault server address used to look up pipeline variables."`
+		VaultClientToken string  `long:"vault-client-token" description:"Client token used to read secrets from the Vault server."`
+		VaultPathPrefix  string  `long:"vault-path-prefix"  default:"/concourse" description:"Path under which team and pipeline secrets are looked up."`
+	} `group:"Credential Management"`
+
+	BuildLogArchive struct {
+		Dir DirFlag `long:"build-log-archive-dir" description:"Directory in which to archive the events of builds reaped by build_logs_to_retain, rather than discarding them."`
+
+		S3Endpoint        URLFlag `long:"build-log-archive-s3-endpoint"          description:"S3-compatible API endpoint to which the events of reaped builds are archived."`
+		S3Region          string  `long:"build-log-archive-s3-region"            default:"us-east-1" description:"Region of the S3 bucket."`
+		S3Bucket          string  `long:"build-log-archive-s3-bucket"            description:"S3 bucket in which to archive build events."`
+		S3AccessKeyID     string  `long:"build-log-archive-s3-access-key-id"     description:"Access key ID used to authenticate with the S3 API."`
+		S3SecretAccessKey string  `long:"build-log-archive-s3-secret-access-key" description:"Secret access key used to authenticate with the S3 API."`
+	} `group:"Build Log Archival"`
+
+	BuildArtifacts struct {
+		Dir DirFlag `long:"build-artifacts-dir" description:"Directory in which to store task outputs retained as build artifacts."`
+
+		S3Endpoint        URLFlag `long:"build-artifacts-s3-endpoint"          description:"S3-compatible API endpoint to which build artifacts are uploaded."`
+		S3Region          string  `long:"build-artifacts-s3-region"            default:"us-east-1" description:"Region of the S3 bucket."`
+		S3Bucket          string  `long:"build-artifacts-s3-bucket"            description:"S3 bucket in which to store build artifacts."`
+		S3AccessKeyID     string  `long:"build-artifacts-s3-access-key-id"     description:"Access key ID used to authenticate with the S3 API."`
+		S3SecretAccessKey string  `long:"build-artifacts-s3-secret-access-key" description:"Secret access key used to authenticate with the S3 API."`
+	} `group:"Build Artifacts"`
+
+	Webhooks struct {
+		Timeout                  time.Duration `long:"webhook-timeout"                    default:"30s" description:"Time after which a webhook delivery is given up on and retried."`
+		AllowPrivateDestinations bool          `long:"webhook-allow-private-destinations" description:"Allow webhooks to be delivered to loopback, private, and link-local addresses, e.g. when receivers are on the ATC's own network."`
+	} `group:"Build Webhooks"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -115,6 +162,9 @@ type ATCCommand struct {
 		RiemannHost          string `long:"riemann-host"                description:"Riemann server address to emit metrics to."`
 		RiemannPort          uint16 `long:"riemann-port" default:"5555" description:"Port of the Riemann server to emit metrics to."`
 		RiemannServicePrefix string `long:"riemann-service-prefix" default:"" description:"An optional prefix for emitted Riemann services"`
+
+		PrometheusBindIP   IPFlag `long:"prometheus-bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for Prometheus metrics scrapes."`
+		PrometheusBindPort uint16 `long:"prometheus-bind-port"                   description:"Port on which to listen for Prometheus metrics scrapes. If not specified, Prometheus metrics are disabled."`
 	} `group:"Metrics & Diagnostics"`
 
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
@@ -140,7 +190,13 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
 	if cmd.Metrics.RiemannHost != "" {
-		cmd.configureMetrics(logger)
+		cmd.configureRiemann()
+	}
+
+	var prometheusEmitter *metric.PrometheusEmitter
+	if cmd.Metrics.PrometheusBindPort != 0 {
+		prometheusEmitter = metric.NewPrometheusEmitter()
+		metric.RegisterEmitter(prometheusEmitter)
 	}
 
 	dbConn, err := cmd.constructDBConn(logger)
@@ -160,27 +216,38 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	listener := pq.NewListener(cmd.PostgresDataSource, time.Second, time.Minute, nil)
 	bus := db.NewNotificationsBus(listener, dbConn)
 
-	sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+	eventArchive := cmd.constructEventArchive()
+	artifactStore := cmd.constructArtifactStore()
+
+	sqlDB := db.NewSQL(dbConn, bus, lockFactory, eventArchive)
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
-	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, eventArchive)
 	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory)
 
 	tracker := trackerFactory.TrackerFor(workerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
-	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
+	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, eventArchive)
+	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory, artifactStore)
+
+	credentialsManager, err := cmd.constructCredentialsManager()
+	if err != nil {
+		return nil, err
+	}
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		engine,
+		credentialsManager,
+		buildqueue.NewQueue(sqlDB, cmd.MaxConcurrentBuilds, cmd.MaxConcurrentBuildsPerTeam),
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
+		credentialsManager,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -221,8 +288,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		engine,
 		workerClient,
 		drain,
+		artifactStore,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialsManager,
 	)
 
 	if err != nil {
@@ -293,6 +362,11 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		)
 	}
 
+	var buildArchiver buildreaper.BuildArchiver
+	if eventArchive != nil {
+		buildArchiver = buildreaper.NewBuildArchiver(eventArchive)
+	}
+
 	members := []grouper.Member{
 		{"drainer", drainer(drain)},
 
@@ -358,6 +432,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				500,
+				buildArchiver,
 			),
 			"build-reaper",
 			sqlDB,
@@ -376,12 +451,38 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			60*time.Second,
 		)},
+
+		{"webhooks", lockrunner.NewRunner(
+			logger.Session("webhook-deliverer-runner"),
+			webhooks.NewDeliverer(
+				logger.Session("webhook-deliverer"),
+				sqlDB,
+				webhooks.NewClient(cmd.Webhooks.Timeout, cmd.Webhooks.AllowPrivateDestinations),
+				10,
+				10*time.Second,
+				time.Hour,
+			),
+			"webhook-deliverer",
+			sqlDB,
+			clock.NewClock(),
+			10*time.Second,
+		)},
 	}
 
 	if cmd.Worker.GardenURL.URL() != nil {
 		members = cmd.appendStaticWorker(logger, sqlDB, members)
 	}
 
+	if prometheusEmitter != nil {
+		prometheusMux := http.NewServeMux()
+		prometheusMux.Handle("/metrics", prometheusEmitter.Handler())
+
+		members = append(members, grouper.Member{"prometheus", http_server.New(
+			cmd.prometheusBindAddr(),
+			prometheusMux,
+		)})
+	}
+
 	if httpsHandler != nil {
 		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
 		if err != nil {
@@ -415,6 +516,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			logData["https"] = cmd.tlsBindAddr()
 		}
 
+		if prometheusEmitter != nil {
+			logData["prometheus"] = cmd.prometheusBindAddr()
+		}
+
 		logger.Info("listening", logData)
 	}), nil
 }
@@ -449,7 +554,7 @@ func (cmd *ATCCommand) oauthBaseURL() string {
 }
 
 func (cmd *ATCCommand) authConfigured() bool {
-	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured()
+	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured() || cmd.LDAPAuth.IsConfigured()
 }
 
 func (cmd *ATCCommand) validate() error {
@@ -458,7 +563,7 @@ func (cmd *ATCCommand) validate() error {
 	if !cmd.authConfigured() && !cmd.Developer.DevelopmentMode {
 		errs = multierror.Append(
 			errs,
-			errors.New("must configure basic auth, OAuth, UAAAuth, or turn on development mode"),
+			errors.New("must configure basic auth, OAuth, UAAAuth, LDAP, or turn on development mode"),
 		)
 	}
 
@@ -497,6 +602,48 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.LDAPAuth.IsConfigured() {
+		err := cmd.LDAPAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if cmd.BuildLogArchive.Dir != "" && cmd.BuildLogArchive.S3Endpoint.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --build-log-archive-dir or --build-log-archive-s3-endpoint"),
+		)
+	}
+
+	if cmd.BuildLogArchive.S3Endpoint.URL() != nil && cmd.BuildLogArchive.S3Bucket == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --build-log-archive-s3-bucket to archive build logs to S3"),
+		)
+	}
+
+	if cmd.BuildArtifacts.Dir != "" && cmd.BuildArtifacts.S3Endpoint.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --build-artifacts-dir or --build-artifacts-s3-endpoint"),
+		)
+	}
+
+	if cmd.BuildArtifacts.S3Endpoint.URL() != nil && cmd.BuildArtifacts.S3Bucket == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --build-artifacts-s3-bucket to store build artifacts in S3"),
+		)
+	}
+
+	if cmd.Credentials.File != "" && cmd.Credentials.VaultURL.URL() != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --credentials-file or --vault-url"),
+		)
+	}
+
 	tlsFlagCount := 0
 	if cmd.TLSBindPort != 0 {
 		tlsFlagCount++
@@ -549,6 +696,10 @@ func (cmd *ATCCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
+func (cmd *ATCCommand) prometheusBindAddr() string {
+	return fmt.Sprintf("%s:%d", cmd.Metrics.PrometheusBindIP, cmd.Metrics.PrometheusBindPort)
+}
+
 func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
 	logger := lager.NewLogger("atc")
 
@@ -568,20 +719,71 @@ func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSin
 	return logger, reconfigurableSink
 }
 
-func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
+func (cmd *ATCCommand) configureRiemann() {
 	host := cmd.Metrics.HostName
 	if host == "" {
 		host, _ = os.Hostname()
 	}
 
-	metric.Initialize(
-		logger.Session("metrics"),
+	metric.RegisterEmitter(metric.NewRiemannEmitter(
 		fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
 		host,
 		cmd.Metrics.Tags,
 		cmd.Metrics.Attributes,
 		cmd.Metrics.RiemannServicePrefix,
-	)
+	))
+}
+
+func (cmd *ATCCommand) constructCredentialsManager() (credentials.Manager, error) {
+	if cmd.Credentials.File != "" {
+		return file.NewManager(string(cmd.Credentials.File))
+	}
+
+	if cmd.Credentials.VaultURL.URL() != nil {
+		return vault.NewManager(
+			cmd.Credentials.VaultURL.String(),
+			cmd.Credentials.VaultClientToken,
+			cmd.Credentials.VaultPathPrefix,
+		), nil
+	}
+
+	return nil, nil
+}
+
+func (cmd *ATCCommand) constructEventArchive() archive.Store {
+	if cmd.BuildLogArchive.Dir != "" {
+		return local.NewStore(cmd.BuildLogArchive.Dir.Path())
+	}
+
+	if cmd.BuildLogArchive.S3Endpoint.URL() != nil {
+		return s3.NewStore(
+			cmd.BuildLogArchive.S3Endpoint.String(),
+			cmd.BuildLogArchive.S3Region,
+			cmd.BuildLogArchive.S3Bucket,
+			cmd.BuildLogArchive.S3AccessKeyID,
+			cmd.BuildLogArchive.S3SecretAccessKey,
+		)
+	}
+
+	return nil
+}
+
+func (cmd *ATCCommand) constructArtifactStore() archive.Store {
+	if cmd.BuildArtifacts.Dir != "" {
+		return local.NewStore(cmd.BuildArtifacts.Dir.Path())
+	}
+
+	if cmd.BuildArtifacts.S3Endpoint.URL() != nil {
+		return s3.NewStore(
+			cmd.BuildArtifacts.S3Endpoint.String(),
+			cmd.BuildArtifacts.S3Region,
+			cmd.BuildArtifacts.S3Bucket,
+			cmd.BuildArtifacts.S3AccessKeyID,
+			cmd.BuildArtifacts.S3SecretAccessKey,
+		)
+	}
+
+	return nil
 }
 
 func (cmd *ATCCommand) constructDBConn(logger lager.Logger) (db.Conn, error) {
@@ -754,6 +956,40 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 		return err
 	}
 
+	var ldapAuth *db.LDAPAuth
+	if cmd.LDAPAuth.IsConfigured() {
+		caCert := ""
+		if cmd.LDAPAuth.CACert != "" {
+			caCertFileContents, err := ioutil.ReadFile(string(cmd.LDAPAuth.CACert))
+			if err != nil {
+				return err
+			}
+			caCert = string(caCertFileContents)
+		}
+
+		ldapAuth = &db.LDAPAuth{
+			Host:                 cmd.LDAPAuth.Host,
+			InsecureNoSSL:        cmd.LDAPAuth.InsecureNoSSL,
+			InsecureSkipVerify:   cmd.LDAPAuth.InsecureSkipVerify,
+			CACert:               caCert,
+			BindDN:               cmd.LDAPAuth.BindDN,
+			BindPassword:         cmd.LDAPAuth.BindPassword,
+			UserSearchBaseDN:     cmd.LDAPAuth.UserSearchBaseDN,
+			UserSearchFilter:     cmd.LDAPAuth.UserSearchFilter,
+			UserSearchUsername:   cmd.LDAPAuth.UserSearchUsername,
+			GroupSearchBaseDN:    cmd.LDAPAuth.GroupSearchBaseDN,
+			GroupSearchFilter:    cmd.LDAPAuth.GroupSearchFilter,
+			GroupSearchGroupAttr: cmd.LDAPAuth.GroupSearchGroupAttr,
+			GroupSearchNameAttr:  cmd.LDAPAuth.GroupSearchNameAttr,
+			Groups:               cmd.LDAPAuth.Groups,
+		}
+	}
+
+	_, err = teamDB.UpdateLDAPAuth(ldapAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -762,6 +998,7 @@ func (cmd *ATCCommand) constructEngine(
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
 	teamDBFactory db.TeamDBFactory,
+	artifactStore archive.Store,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
@@ -771,7 +1008,7 @@ func (cmd *ATCCommand) constructEngine(
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(artifactStore),
 		teamDBFactory,
 		cmd.ExternalURL.String(),
 	)
@@ -814,14 +1051,19 @@ func (cmd *ATCCommand) constructAPIHandler(
 	engine engine.Engine,
 	workerClient worker.Client,
 	drain <-chan struct{},
+	artifactStore archive.Store,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
+	credentialsManager credentials.Manager,
 ) (http.Handler, error) {
 	authValidator := auth.JWTValidator{
-		PublicKey: &signingKey.PublicKey,
+		PublicKey:  &signingKey.PublicKey,
+		APITokenDB: sqlDB,
 	}
 
-	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator)
+	ldapAuthenticator := ldap.NewAuthenticator(ldap.NewDialer())
+
+	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator, ldapAuthenticator)
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(
 		pipelineDBFactory,
@@ -834,6 +1076,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPIAuditWrappa(logger, sqlDB),
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
@@ -852,6 +1095,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		auth.NewTokenGenerator(signingKey),
 		providerFactory,
+		ldapAuthenticator,
 		cmd.oauthBaseURL(),
 
 		pipelineDBFactory,
@@ -864,16 +1108,19 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // volumeserver.VolumesDB
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
+		sqlDB, // auditserver.AuditDB
 
 		config.ValidateConfig,
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
 		drain,
+		artifactStore,
 
 		engine,
 		workerClient,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialsManager,
 
 		reconfigurableSink,
 
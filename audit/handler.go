@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	SaveAuditEvent(event db.AuditEvent) error
+}
+
+// AuditHandler records who called the route, on what, and the status it
+// responded with, once the request has been handled. It expects to be wrapped
+// by the route's auth handlers, so that only requests they let through are
+// recorded.
+type AuditHandler struct {
+	Logger  lager.Logger
+	AuditDB AuditDB
+
+	Route   string
+	Handler http.Handler
+}
+
+func WrapHandler(logger lager.Logger, auditDB AuditDB, route string, handler http.Handler) http.Handler {
+	return AuditHandler{
+		Logger:  logger,
+		AuditDB: auditDB,
+		Route:   route,
+		Handler: handler,
+	}
+}
+
+func (handler AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{ResponseWriter: w}
+
+	handler.Handler.ServeHTTP(recorder, r)
+
+	event := db.AuditEvent{
+		Route:  handler.Route,
+		Target: r.URL.Path,
+		Status: recorder.Status(),
+	}
+
+	if team, found := auth.GetTeam(r); found {
+		event.Team = team.Name()
+	}
+
+	if user, found := auth.GetUser(r); found {
+		event.User = user
+	}
+
+	err := handler.AuditDB.SaveAuditEvent(event)
+	if err != nil {
+		handler.Logger.Error("failed-to-save-audit-event", err, lager.Data{
+			"route":  event.Route,
+			"target": event.Target,
+		})
+	}
+}
+
+// statusRecorder notes the status a handler responds with. Hijacked
+// connections, as used for hijacking containers, are recorded as switching
+// protocols.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) Status() int {
+	if recorder.status == 0 {
+		return http.StatusOK
+	}
+
+	return recorder.status
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(b []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+
+	return recorder.ResponseWriter.Write(b)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response cannot be hijacked")
+	}
+
+	if recorder.status == 0 {
+		recorder.status = http.StatusSwitchingProtocols
+	}
+
+	return hijacker.Hijack()
+}
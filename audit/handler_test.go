@@ -0,0 +1,134 @@
+package audit_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditHandler", func() {
+	var (
+		fakeAuditDB           *auditfakes.FakeAuditDB
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeValidator         *authfakes.FakeValidator
+
+		server *httptest.Server
+
+		status             int
+		savedBeforeHandled chan int
+
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeAuditDB = new(auditfakes.FakeAuditDB)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeValidator = new(authfakes.FakeValidator)
+
+		status = http.StatusTeapot
+		savedBeforeHandled = make(chan int, 1)
+
+		simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			savedBeforeHandled <- fakeAuditDB.SaveAuditEventCallCount()
+
+			w.WriteHeader(status)
+			w.Write([]byte("handled"))
+		})
+
+		server = httptest.NewServer(auth.WrapHandler(
+			audit.WrapHandler(
+				lagertest.NewTestLogger("test"),
+				fakeAuditDB,
+				atc.PausePipeline,
+				simpleHandler,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/pause", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err = http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("handles the request", func() {
+		Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+		Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("handled")))
+	})
+
+	It("records the event once the request has been handled", func() {
+		Expect(<-savedBeforeHandled).To(BeZero())
+		Eventually(fakeAuditDB.SaveAuditEventCallCount).Should(Equal(1))
+	})
+
+	Context("when the request is rejected", func() {
+		BeforeEach(func() {
+			status = http.StatusBadRequest
+		})
+
+		It("records the status it was rejected with", func() {
+			Eventually(fakeAuditDB.SaveAuditEventCallCount).Should(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0).Status).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the requester is identified", func() {
+		BeforeEach(func() {
+			fakeValidator.IsAuthenticatedReturns(true)
+			fakeUserContextReader.GetTeamReturns("some-team", 42, false, true)
+			fakeUserContextReader.GetUserReturns("some-user", true)
+		})
+
+		It("records the route, user, team, and target", func() {
+			Eventually(fakeAuditDB.SaveAuditEventCallCount).Should(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				Route:  atc.PausePipeline,
+				User:   "some-user",
+				Team:   "some-team",
+				Target: "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+				Status: http.StatusTeapot,
+			}))
+		})
+	})
+
+	Context("when the requester is not identified", func() {
+		It("records the route and target", func() {
+			Eventually(fakeAuditDB.SaveAuditEventCallCount).Should(Equal(1))
+			Expect(fakeAuditDB.SaveAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				Route:  atc.PausePipeline,
+				Target: "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+				Status: http.StatusTeapot,
+			}))
+		})
+	})
+
+	Context("when recording the event fails", func() {
+		BeforeEach(func() {
+			fakeAuditDB.SaveAuditEventReturns(errors.New("nope"))
+		})
+
+		It("still handles the request", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package atc
+
+// AuditEvent records who called an API route that changes something, on
+// what, and the HTTP status the route responded with.
+type AuditEvent struct {
+	ID        int    `json:"id"`
+	Route     string `json:"route"`
+	User      string `json:"user,omitempty"`
+	Team      string `json:"team,omitempty"`
+	Target    string `json:"target"`
+	Status    int    `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+}
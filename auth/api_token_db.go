@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	UseAPIToken(tokenHash string) (bool, error)
+}
+
+// HashAPIToken is how API tokens are stored, so that the tokens themselves
+// can't be recovered from the database.
+func HashAPIToken(token TokenValue) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
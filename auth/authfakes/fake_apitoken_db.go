@@ -0,0 +1,78 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth"
+)
+
+type FakeAPITokenDB struct {
+	UseAPITokenStub        func(tokenHash string) (bool, error)
+	useAPITokenMutex       sync.RWMutex
+	useAPITokenArgsForCall []struct {
+		tokenHash string
+	}
+	useAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenDB) UseAPIToken(tokenHash string) (bool, error) {
+	fake.useAPITokenMutex.Lock()
+	fake.useAPITokenArgsForCall = append(fake.useAPITokenArgsForCall, struct {
+		tokenHash string
+	}{tokenHash})
+	fake.recordInvocation("UseAPIToken", []interface{}{tokenHash})
+	fake.useAPITokenMutex.Unlock()
+	if fake.UseAPITokenStub != nil {
+		return fake.UseAPITokenStub(tokenHash)
+	} else {
+		return fake.useAPITokenReturns.result1, fake.useAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeAPITokenDB) UseAPITokenCallCount() int {
+	fake.useAPITokenMutex.RLock()
+	defer fake.useAPITokenMutex.RUnlock()
+	return len(fake.useAPITokenArgsForCall)
+}
+
+func (fake *FakeAPITokenDB) UseAPITokenArgsForCall(i int) string {
+	fake.useAPITokenMutex.RLock()
+	defer fake.useAPITokenMutex.RUnlock()
+	return fake.useAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeAPITokenDB) UseAPITokenReturns(result1 bool, result2 error) {
+	fake.UseAPITokenStub = nil
+	fake.useAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.useAPITokenMutex.RLock()
+	defer fake.useAPITokenMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAPITokenDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.APITokenDB = new(FakeAPITokenDB)
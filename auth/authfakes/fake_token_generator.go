@@ -5,39 +5,58 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
 type FakeTokenGenerator struct {
-	GenerateTokenStub        func(expiration time.Time, teamName string, teamID int, isAdmin bool) (auth.TokenType, auth.TokenValue, error)
+	GenerateTokenStub        func(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (auth.TokenType, auth.TokenValue, error)
 	generateTokenMutex       sync.RWMutex
 	generateTokenArgsForCall []struct {
 		expiration time.Time
 		teamName   string
 		teamID     int
 		isAdmin    bool
+		role       atc.TeamRole
+		user       string
 	}
 	generateTokenReturns struct {
 		result1 auth.TokenType
 		result2 auth.TokenValue
 		result3 error
 	}
+	GenerateAPITokenStub        func(teamName string, teamID int, isAdmin bool, role atc.TeamRole, tokenName string) (auth.TokenType, auth.TokenValue, error)
+	generateAPITokenMutex       sync.RWMutex
+	generateAPITokenArgsForCall []struct {
+		teamName  string
+		teamID    int
+		isAdmin   bool
+		role      atc.TeamRole
+		tokenName string
+	}
+	generateAPITokenReturns struct {
+		result1 auth.TokenType
+		result2 auth.TokenValue
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (auth.TokenType, auth.TokenValue, error) {
+func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (auth.TokenType, auth.TokenValue, error) {
 	fake.generateTokenMutex.Lock()
 	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
 		expiration time.Time
 		teamName   string
 		teamID     int
 		isAdmin    bool
-	}{expiration, teamName, teamID, isAdmin})
-	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, teamID, isAdmin})
+		role       atc.TeamRole
+		user       string
+	}{expiration, teamName, teamID, isAdmin, role, user})
+	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, teamID, isAdmin, role, user})
 	fake.generateTokenMutex.Unlock()
 	if fake.GenerateTokenStub != nil {
-		return fake.GenerateTokenStub(expiration, teamName, teamID, isAdmin)
+		return fake.GenerateTokenStub(expiration, teamName, teamID, isAdmin, role, user)
 	} else {
 		return fake.generateTokenReturns.result1, fake.generateTokenReturns.result2, fake.generateTokenReturns.result3
 	}
@@ -49,10 +68,10 @@ func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
 	return len(fake.generateTokenArgsForCall)
 }
 
-func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, int, bool) {
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, int, bool, atc.TeamRole, string) {
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
-	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].teamID, fake.generateTokenArgsForCall[i].isAdmin
+	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].teamID, fake.generateTokenArgsForCall[i].isAdmin, fake.generateTokenArgsForCall[i].role, fake.generateTokenArgsForCall[i].user
 }
 
 func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
@@ -64,11 +83,52 @@ func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTokenGenerator) GenerateAPIToken(teamName string, teamID int, isAdmin bool, role atc.TeamRole, tokenName string) (auth.TokenType, auth.TokenValue, error) {
+	fake.generateAPITokenMutex.Lock()
+	fake.generateAPITokenArgsForCall = append(fake.generateAPITokenArgsForCall, struct {
+		teamName  string
+		teamID    int
+		isAdmin   bool
+		role      atc.TeamRole
+		tokenName string
+	}{teamName, teamID, isAdmin, role, tokenName})
+	fake.recordInvocation("GenerateAPIToken", []interface{}{teamName, teamID, isAdmin, role, tokenName})
+	fake.generateAPITokenMutex.Unlock()
+	if fake.GenerateAPITokenStub != nil {
+		return fake.GenerateAPITokenStub(teamName, teamID, isAdmin, role, tokenName)
+	} else {
+		return fake.generateAPITokenReturns.result1, fake.generateAPITokenReturns.result2, fake.generateAPITokenReturns.result3
+	}
+}
+
+func (fake *FakeTokenGenerator) GenerateAPITokenCallCount() int {
+	fake.generateAPITokenMutex.RLock()
+	defer fake.generateAPITokenMutex.RUnlock()
+	return len(fake.generateAPITokenArgsForCall)
+}
+
+func (fake *FakeTokenGenerator) GenerateAPITokenArgsForCall(i int) (string, int, bool, atc.TeamRole, string) {
+	fake.generateAPITokenMutex.RLock()
+	defer fake.generateAPITokenMutex.RUnlock()
+	return fake.generateAPITokenArgsForCall[i].teamName, fake.generateAPITokenArgsForCall[i].teamID, fake.generateAPITokenArgsForCall[i].isAdmin, fake.generateAPITokenArgsForCall[i].role, fake.generateAPITokenArgsForCall[i].tokenName
+}
+
+func (fake *FakeTokenGenerator) GenerateAPITokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
+	fake.GenerateAPITokenStub = nil
+	fake.generateAPITokenReturns = struct {
+		result1 auth.TokenType
+		result2 auth.TokenValue
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTokenGenerator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
+	fake.generateAPITokenMutex.RLock()
+	defer fake.generateAPITokenMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     atc.TeamRole
+	rejector Rejector
+}
+
+// CheckRoleHandler rejects requests from teams whose role within the team
+// does not allow the given role. Requests without a team are left to the
+// wrapped handler to authenticate.
+func CheckRoleHandler(
+	handler http.Handler,
+	role atc.TeamRole,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	team, found := GetTeam(r)
+	if found && !team.Role().Allows(h.role) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
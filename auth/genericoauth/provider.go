@@ -36,7 +36,8 @@ func NewProvider(
 	}
 
 	return Provider{
-		Verifier: oauthVerifier,
+		Verifier:   oauthVerifier,
+		Identifier: SubjectIdentifier{},
 		Config: ConfigOverride{
 			Config: oauth2.Config{
 				ClientID:     genericOAuth.ClientID,
@@ -51,6 +52,7 @@ func NewProvider(
 
 type Provider struct {
 	verifier.Verifier
+	verifier.Identifier
 	Config ConfigOverride
 }
 
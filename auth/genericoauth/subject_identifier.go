@@ -0,0 +1,58 @@
+package genericoauth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+)
+
+// SubjectIdentifier identifies users by the subject of their access token,
+// and groups them by the token's scopes. Access tokens that are not JWTs
+// identify no one.
+type SubjectIdentifier struct{}
+
+type GenericOAuthSubjectToken struct {
+	Subject string   `json:"sub"`
+	Scopes  []string `json:"scope"`
+}
+
+func (identifier SubjectIdentifier) Identify(logger lager.Logger, httpClient *http.Client) (verifier.Identity, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return verifier.Identity{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) < 2 {
+		logger.Info("access-token-is-not-a-jwt")
+		return verifier.Identity{}, nil
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	var oauthToken GenericOAuthSubjectToken
+	err = json.Unmarshal(decodedClaims, &oauthToken)
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	return verifier.Identity{
+		User:   oauthToken.Subject,
+		Groups: oauthToken.Scopes,
+	}, nil
+}
@@ -1,11 +1,16 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 type Team interface {
 	Name() string
 	ID() int
 	IsAdmin() bool
+	Role() atc.TeamRole
 	IsAuthorized(teamName string) bool
 }
 
@@ -13,6 +18,7 @@ type team struct {
 	name    string
 	teamID  int
 	isAdmin bool
+	role    atc.TeamRole
 }
 
 func (t *team) Name() string {
@@ -27,6 +33,10 @@ func (t *team) IsAdmin() bool {
 	return t.isAdmin
 }
 
+func (t *team) Role() atc.TeamRole {
+	return t.role
+}
+
 func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
@@ -36,6 +46,12 @@ func GetTeam(r *http.Request) (Team, bool) {
 	teamID, teamIDPresent := r.Context().Value(teamIDKey).(int)
 	isAdmin, adminPresent := r.Context().Value(isAdminKey).(bool)
 
+	// tokens from before roles existed were only ever given to owners
+	role, rolePresent := r.Context().Value(roleKey).(atc.TeamRole)
+	if !rolePresent {
+		role = atc.TeamRoleOwner
+	}
+
 	if !(namePresent && teamIDPresent && adminPresent) {
 		return nil, false
 	}
@@ -44,5 +60,6 @@ func GetTeam(r *http.Request) (Team, bool) {
 		name:    teamName,
 		teamID:  teamID,
 		isAdmin: isAdmin,
+		role:    role,
 	}, true
 }
@@ -0,0 +1,10 @@
+package auth
+
+import "net/http"
+
+// GetUser returns who the team's auth provider identified the requester as,
+// if anyone.
+func GetUser(r *http.Request) (string, bool) {
+	user, present := r.Context().Value(userKey).(string)
+	return user, present
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+)
+
+type UserIdentifier struct {
+	gitHubClient Client
+}
+
+// NewUserIdentifier identifies users by their GitHub login, and groups them
+// by their organizations and by their teams as "org/team".
+func NewUserIdentifier(gitHubClient Client) verifier.Identifier {
+	return UserIdentifier{
+		gitHubClient: gitHubClient,
+	}
+}
+
+func (identifier UserIdentifier) Identify(logger lager.Logger, httpClient *http.Client) (verifier.Identity, error) {
+	currentUser, err := identifier.gitHubClient.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return verifier.Identity{}, err
+	}
+
+	groups, err := identifier.gitHubClient.Organizations(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-organizations", err)
+		return verifier.Identity{}, err
+	}
+
+	teams, err := identifier.gitHubClient.Teams(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return verifier.Identity{}, err
+	}
+
+	for organization, teamNames := range teams {
+		for _, teamName := range teamNames {
+			groups = append(groups, organization+"/"+teamName)
+		}
+	}
+
+	return verifier.Identity{
+		User:   currentUser,
+		Groups: groups,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package github_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/github/githubfakes"
+	"github.com/concourse/atc/auth/verifier"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserIdentifier", func() {
+	var (
+		fakeClient *githubfakes.FakeClient
+
+		identifier verifier.Identifier
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(githubfakes.FakeClient)
+
+		identifier = NewUserIdentifier(fakeClient)
+	})
+
+	Describe("Identify", func() {
+		var (
+			httpClient *http.Client
+
+			identity    verifier.Identity
+			identifyErr error
+		)
+
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+
+			fakeClient.CurrentUserReturns("some-user", nil)
+			fakeClient.OrganizationsReturns([]string{"some-org", "other-org"}, nil)
+			fakeClient.TeamsReturns(OrganizationTeams{
+				"some-org":  {"some-team", "other-team"},
+				"other-org": {"some-team"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			identity, identifyErr = identifier.Identify(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		It("identifies the user by login", func() {
+			Expect(identifyErr).NotTo(HaveOccurred())
+			Expect(identity.User).To(Equal("some-user"))
+			Expect(fakeClient.CurrentUserArgsForCall(0)).To(Equal(httpClient))
+		})
+
+		It("groups the user by organization and team", func() {
+			Expect(identity.Groups).To(ConsistOf(
+				"some-org",
+				"other-org",
+				"some-org/some-team",
+				"some-org/other-team",
+				"other-org/some-team",
+			))
+		})
+
+		Context("when getting the current user fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.CurrentUserReturns("", disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(identifyErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when getting the teams fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.TeamsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(identifyErr).To(Equal(disaster))
+			})
+		})
+	})
+})
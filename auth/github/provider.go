@@ -22,6 +22,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	Identifier
 }
 
 type OAuthClient interface {
@@ -34,6 +35,10 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type Identifier interface {
+	Identify(lager.Logger, *http.Client) (verifier.Identity, error)
+}
+
 func NewProvider(
 	gitHubAuth *db.GitHubAuth,
 	redirectURL string,
@@ -52,6 +57,7 @@ func NewProvider(
 			NewOrganizationVerifier(gitHubAuth.Organizations, client),
 			NewUserVerifier(gitHubAuth.Users, client),
 		),
+		Identifier: NewUserIdentifier(client),
 		Config: &oauth2.Config{
 			ClientID:     gitHubAuth.ClientID,
 			ClientSecret: gitHubAuth.ClientSecret,
@@ -70,6 +76,7 @@ type gitHubProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+	verifier.Identifier
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -3,10 +3,16 @@ package auth
 import (
 	"crypto/rsa"
 	"net/http"
+
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 type JWTValidator struct {
 	PublicKey *rsa.PublicKey
+
+	// APITokenDB is consulted for API tokens, which don't expire but can be
+	// revoked. Without it API tokens are not accepted.
+	APITokenDB APITokenDB
 }
 
 func (validator JWTValidator) IsAuthenticated(r *http.Request) bool {
@@ -15,5 +21,19 @@ func (validator JWTValidator) IsAuthenticated(r *http.Request) bool {
 		return false
 	}
 
-	return token.Valid
+	if !token.Valid {
+		return false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	if _, isAPIToken := claims[apiTokenClaimKey]; isAPIToken {
+		if validator.APITokenDB == nil {
+			return false
+		}
+
+		found, err := validator.APITokenDB.UseAPIToken(HashAPIToken(TokenValue(token.Raw)))
+		return err == nil && found
+	}
+
+	return true
 }
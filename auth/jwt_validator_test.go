@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWTValidator", func() {
+	var (
+		signingKey     *rsa.PrivateKey
+		tokenGenerator auth.TokenGenerator
+		fakeAPITokenDB *authfakes.FakeAPITokenDB
+
+		validator auth.JWTValidator
+		request   *http.Request
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenGenerator = auth.NewTokenGenerator(signingKey)
+		fakeAPITokenDB = new(authfakes.FakeAPITokenDB)
+
+		validator = auth.JWTValidator{
+			PublicKey:  &signingKey.PublicKey,
+			APITokenDB: fakeAPITokenDB,
+		}
+
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	authorize := func(tokenType auth.TokenType, tokenValue auth.TokenValue, err error) {
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Authorization", string(tokenType)+" "+string(tokenValue))
+	}
+
+	It("rejects requests without a token", func() {
+		Expect(validator.IsAuthenticated(request)).To(BeFalse())
+	})
+
+	Context("with a session token", func() {
+		It("accepts it until it expires", func() {
+			authorize(tokenGenerator.GenerateToken(time.Now().Add(time.Hour), "some-team", 1, false, atc.TeamRoleOwner, ""))
+			Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+			authorize(tokenGenerator.GenerateToken(time.Now().Add(-time.Hour), "some-team", 1, false, atc.TeamRoleOwner, ""))
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		It("does not consult the APITokenDB", func() {
+			authorize(tokenGenerator.GenerateToken(time.Now().Add(time.Hour), "some-team", 1, false, atc.TeamRoleOwner, ""))
+			validator.IsAuthenticated(request)
+			Expect(fakeAPITokenDB.UseAPITokenCallCount()).To(BeZero())
+		})
+	})
+
+	Context("with an API token", func() {
+		var tokenValue auth.TokenValue
+
+		BeforeEach(func() {
+			tokenType, value, err := tokenGenerator.GenerateAPIToken("some-team", 1, false, atc.TeamRoleViewer, "some-token")
+
+			tokenValue = value
+			authorize(tokenType, tokenValue, err)
+		})
+
+		It("looks it up by its hash", func() {
+			validator.IsAuthenticated(request)
+			Expect(fakeAPITokenDB.UseAPITokenCallCount()).To(Equal(1))
+			Expect(fakeAPITokenDB.UseAPITokenArgsForCall(0)).To(Equal(auth.HashAPIToken(tokenValue)))
+		})
+
+		It("accepts it when it is found", func() {
+			fakeAPITokenDB.UseAPITokenReturns(true, nil)
+			Expect(validator.IsAuthenticated(request)).To(BeTrue())
+		})
+
+		It("rejects it when it has been revoked", func() {
+			fakeAPITokenDB.UseAPITokenReturns(false, nil)
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		It("rejects it when looking it up fails", func() {
+			fakeAPITokenDB.UseAPITokenReturns(true, errors.New("nope"))
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		It("rejects it when there is no APITokenDB", func() {
+			validator.APITokenDB = nil
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+
+		It("carries the team and role like a session token", func() {
+			reader := auth.JWTReader{PublicKey: &signingKey.PublicKey}
+
+			teamName, teamID, isAdmin, found := reader.GetTeam(request)
+			Expect(found).To(BeTrue())
+			Expect(teamName).To(Equal("some-team"))
+			Expect(teamID).To(Equal(1))
+			Expect(isAdmin).To(BeFalse())
+
+			role, found := reader.GetRole(request)
+			Expect(found).To(BeTrue())
+			Expect(role).To(Equal(atc.TeamRoleViewer))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+)
+
+const ProviderName = "ldap"
+const DisplayName = "LDAP"
+
+const (
+	DefaultUserSearchUsername   = "uid"
+	DefaultGroupSearchGroupAttr = "member"
+	DefaultGroupSearchNameAttr  = "cn"
+)
+
+//go:generate counterfeiter . Authenticator
+
+type Authenticator interface {
+	// Authenticate checks the user's password by binding as them, and returns
+	// who they are and the groups they belong to. It returns false if the
+	// user doesn't exist, the password is wrong, or the user isn't in any of
+	// the configured groups.
+	Authenticate(config *db.LDAPAuth, username string, password string) (verifier.Identity, bool, error)
+}
+
+type authenticator struct {
+	dialer Dialer
+}
+
+func NewAuthenticator(dialer Dialer) Authenticator {
+	return authenticator{
+		dialer: dialer,
+	}
+}
+
+func (a authenticator) Authenticate(config *db.LDAPAuth, username string, password string) (verifier.Identity, bool, error) {
+	// binding with an empty password is an unauthenticated bind, which many
+	// servers allow for any DN
+	if username == "" || password == "" {
+		return verifier.Identity{}, false, nil
+	}
+
+	conn, err := a.dialer.Dial(config)
+	if err != nil {
+		return verifier.Identity{}, false, err
+	}
+
+	defer conn.Close()
+
+	err = bindForSearch(conn, config)
+	if err != nil {
+		return verifier.Identity{}, false, err
+	}
+
+	usernameAttr := withDefault(config.UserSearchUsername, DefaultUserSearchUsername)
+
+	users, err := conn.Search(
+		config.UserSearchBaseDN,
+		and(config.UserSearchFilter, equals(usernameAttr, username)),
+		[]string{usernameAttr},
+	)
+	if err != nil {
+		return verifier.Identity{}, false, err
+	}
+
+	if len(users) == 0 {
+		return verifier.Identity{}, false, nil
+	}
+
+	if len(users) > 1 {
+		return verifier.Identity{}, false, fmt.Errorf("found %d users with %s=%s", len(users), usernameAttr, username)
+	}
+
+	user := users[0]
+
+	err = conn.Bind(user.DN, password)
+	if err == ErrInvalidCredentials {
+		return verifier.Identity{}, false, nil
+	}
+
+	if err != nil {
+		return verifier.Identity{}, false, err
+	}
+
+	identity := verifier.Identity{User: username}
+
+	// use the directory's spelling, which may differ in case
+	if names := user.Attributes[usernameAttr]; len(names) > 0 {
+		identity.User = names[0]
+	}
+
+	if config.GroupSearchBaseDN != "" {
+		identity.Groups, err = searchGroups(conn, config, user.DN)
+		if err != nil {
+			return verifier.Identity{}, false, err
+		}
+	}
+
+	if len(config.Groups) > 0 && !memberOfAny(identity.Groups, config.Groups) {
+		return verifier.Identity{}, false, nil
+	}
+
+	return identity, true, nil
+}
+
+// bindForSearch binds as the configured service account, if any; otherwise
+// searches are made anonymously, or as whoever was last bound.
+func bindForSearch(conn Conn, config *db.LDAPAuth) error {
+	if config.BindDN == "" {
+		return nil
+	}
+
+	err := conn.Bind(config.BindDN, config.BindPassword)
+	if err == ErrInvalidCredentials {
+		return fmt.Errorf("failed to bind as %s: %s", config.BindDN, err)
+	}
+
+	return err
+}
+
+func searchGroups(conn Conn, config *db.LDAPAuth, userDN string) ([]string, error) {
+	// the user may not be allowed to search for groups themselves
+	err := bindForSearch(conn, config)
+	if err != nil {
+		return nil, err
+	}
+
+	groupAttr := withDefault(config.GroupSearchGroupAttr, DefaultGroupSearchGroupAttr)
+	nameAttr := withDefault(config.GroupSearchNameAttr, DefaultGroupSearchNameAttr)
+
+	groups, err := conn.Search(
+		config.GroupSearchBaseDN,
+		and(config.GroupSearchFilter, equals(groupAttr, userDN)),
+		[]string{nameAttr},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, group := range groups {
+		names = append(names, group.Attributes[nameAttr]...)
+	}
+
+	return names, nil
+}
+
+func memberOfAny(groups []string, allowed []string) bool {
+	for _, group := range groups {
+		for _, allowedGroup := range allowed {
+			if group == allowedGroup {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func withDefault(value string, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
+
+func and(filter string, clause string) string {
+	if filter == "" {
+		return clause
+	}
+
+	if !strings.HasPrefix(filter, "(") {
+		filter = "(" + filter + ")"
+	}
+
+	return "(&" + filter + clause + ")"
+}
+
+func equals(attr string, value string) string {
+	return "(" + attr + "=" + escapeFilter(value) + ")"
+}
+
+// escapeFilter escapes the characters that are special in filter values, per
+// RFC 4515.
+func escapeFilter(value string) string {
+	escaped := ""
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\', '*', '(', ')', 0:
+			escaped += fmt.Sprintf("\\%02x", c)
+		default:
+			escaped += string(c)
+		}
+	}
+
+	return escaped
+}
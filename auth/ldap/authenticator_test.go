@@ -0,0 +1,243 @@
+package ldap_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authenticator", func() {
+	var (
+		directory     *stubDirectory
+		config        *db.LDAPAuth
+		authenticator ldap.Authenticator
+
+		username string
+		password string
+
+		identity      verifier.Identity
+		authenticated bool
+		authErr       error
+	)
+
+	BeforeEach(func() {
+		directory = &stubDirectory{
+			entries: []stubEntry{
+				{
+					dn:       "cn=concourse,ou=services,dc=example,dc=com",
+					password: "service-password",
+				},
+				{
+					dn:       "uid=Some-User,ou=people,dc=example,dc=com",
+					password: "some-password",
+					attributes: map[string][]string{
+						"objectClass": {"person"},
+						"uid":         {"Some-User"},
+						"mail":        {"some-user@example.com"},
+					},
+				},
+				{
+					dn:       "uid=other-user,ou=people,dc=example,dc=com",
+					password: "other-password",
+					attributes: map[string][]string{
+						"objectClass": {"person"},
+						"uid":         {"other-user"},
+					},
+				},
+				{
+					dn: "cn=some-group,ou=groups,dc=example,dc=com",
+					attributes: map[string][]string{
+						"objectClass": {"groupOfNames"},
+						"cn":          {"some-group"},
+						"member":      {"uid=Some-User,ou=people,dc=example,dc=com"},
+					},
+				},
+				{
+					dn: "cn=other-group,ou=groups,dc=example,dc=com",
+					attributes: map[string][]string{
+						"objectClass": {"groupOfNames"},
+						"cn":          {"other-group"},
+						"member": {
+							"uid=Some-User,ou=people,dc=example,dc=com",
+							"uid=other-user,ou=people,dc=example,dc=com",
+						},
+					},
+				},
+			},
+		}
+
+		config = &db.LDAPAuth{
+			Host:             "ldap.example.com:636",
+			BindDN:           "cn=concourse,ou=services,dc=example,dc=com",
+			BindPassword:     "service-password",
+			UserSearchBaseDN: "ou=people,dc=example,dc=com",
+			UserSearchFilter: "(objectClass=person)",
+		}
+
+		authenticator = ldap.NewAuthenticator(directory)
+
+		username = "some-user"
+		password = "some-password"
+	})
+
+	JustBeforeEach(func() {
+		identity, authenticated, authErr = authenticator.Authenticate(config, username, password)
+	})
+
+	It("dials the configured server, and hangs up", func() {
+		Expect(directory.dialed).To(Equal([]*db.LDAPAuth{config}))
+		Expect(directory.closed).To(Equal(1))
+	})
+
+	It("binds as the service account to find the user, then as the user", func() {
+		Expect(directory.binds).To(Equal([]string{
+			"cn=concourse,ou=services,dc=example,dc=com",
+			"uid=Some-User,ou=people,dc=example,dc=com",
+		}))
+	})
+
+	It("authenticates the user with the directory's spelling of their name", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(authenticated).To(BeTrue())
+		Expect(identity).To(Equal(verifier.Identity{User: "Some-User"}))
+	})
+
+	Context("when the password is wrong", func() {
+		BeforeEach(func() {
+			password = "bogus-password"
+		})
+
+		It("does not authenticate the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the password is empty", func() {
+		BeforeEach(func() {
+			password = ""
+		})
+
+		It("does not authenticate the user, without asking the directory", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+			Expect(directory.dialed).To(BeEmpty())
+		})
+	})
+
+	Context("when the user does not exist", func() {
+		BeforeEach(func() {
+			username = "nobody"
+		})
+
+		It("does not authenticate the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the user is outside the user search", func() {
+		BeforeEach(func() {
+			config.UserSearchFilter = "(mail=*)"
+			username = "other-user"
+			password = "other-password"
+		})
+
+		It("does not authenticate the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the username would inject into the filter", func() {
+		BeforeEach(func() {
+			username = "*)(uid=*"
+		})
+
+		It("does not authenticate anyone", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the user search finds more than one user", func() {
+		BeforeEach(func() {
+			config.UserSearchUsername = "objectClass"
+			username = "person"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when the service account's password is wrong", func() {
+		BeforeEach(func() {
+			config.BindPassword = "bogus-password"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(HaveOccurred())
+			Expect(authenticated).To(BeFalse())
+		})
+	})
+
+	Context("when dialing fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			directory.dialErr = disaster
+		})
+
+		It("returns the error", func() {
+			Expect(authErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when searching for groups", func() {
+		BeforeEach(func() {
+			config.GroupSearchBaseDN = "ou=groups,dc=example,dc=com"
+			config.GroupSearchFilter = "objectClass=groupOfNames"
+		})
+
+		It("includes the groups the user is a member of", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(authenticated).To(BeTrue())
+			Expect(identity.Groups).To(ConsistOf("some-group", "other-group"))
+		})
+
+		It("searches for groups as the service account", func() {
+			Expect(directory.binds).To(HaveLen(3))
+			Expect(directory.binds[2]).To(Equal(config.BindDN))
+		})
+
+		Context("when access is limited to groups", func() {
+			BeforeEach(func() {
+				config.Groups = []string{"some-group"}
+			})
+
+			It("authenticates members", func() {
+				Expect(authErr).NotTo(HaveOccurred())
+				Expect(authenticated).To(BeTrue())
+			})
+
+			Context("when the user is not a member", func() {
+				BeforeEach(func() {
+					username = "other-user"
+					password = "other-password"
+				})
+
+				It("does not authenticate the user", func() {
+					Expect(authErr).NotTo(HaveOccurred())
+					Expect(authenticated).To(BeFalse())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package ldap
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/db"
+)
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type Dialer interface {
+	Dial(config *db.LDAPAuth) (Conn, error)
+}
+
+// Conn is the little of an LDAP connection that authenticating users needs.
+// Bind returns ErrInvalidCredentials when the server rejects the password.
+type Conn interface {
+	Bind(dn string, password string) error
+	Search(baseDN string, filter string, attributes []string) ([]Entry, error)
+	Close()
+}
+
+// Entry is a search result, with the values of the requested attributes.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
@@ -0,0 +1,121 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/concourse/atc/db"
+	goldap "gopkg.in/ldap.v2"
+)
+
+type dialer struct{}
+
+// NewDialer dials LDAP servers over TLS, or in plain text if the team's
+// config says InsecureNoSSL.
+func NewDialer() Dialer {
+	return dialer{}
+}
+
+func (dialer) Dial(config *db.LDAPAuth) (Conn, error) {
+	if config.InsecureNoSSL {
+		conn, err := goldap.Dial("tcp", config.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		return ldapConn{conn: conn}, nil
+	}
+
+	tlsConfig, err := tlsConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := goldap.DialTLS("tcp", config.Host, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return ldapConn{conn: conn}, nil
+}
+
+func tlsConfig(config *db.LDAPAuth) (*tls.Config, error) {
+	serverName := config.Host
+	if host, _, err := net.SplitHostPort(config.Host); err == nil {
+		serverName = host
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return nil, errors.New("invalid LDAP CA certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+type ldapConn struct {
+	conn *goldap.Conn
+}
+
+func (c ldapConn) Bind(dn string, password string) error {
+	err := c.conn.Bind(dn, password)
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+		return ErrInvalidCredentials
+	}
+
+	return err
+}
+
+func (c ldapConn) Search(baseDN string, filter string, attributes []string) ([]Entry, error) {
+	result, err := c.conn.Search(goldap.NewSearchRequest(
+		baseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, entry := range result.Entries {
+		values := map[string][]string{}
+
+		// servers needn't return attribute names as they were requested
+		for _, attribute := range attributes {
+			for _, entryAttribute := range entry.Attributes {
+				if strings.EqualFold(entryAttribute.Name, attribute) {
+					values[attribute] = entryAttribute.Values
+				}
+			}
+		}
+
+		entries = append(entries, Entry{
+			DN:         entry.DN,
+			Attributes: values,
+		})
+	}
+
+	return entries, nil
+}
+
+func (c ldapConn) Close() {
+	c.conn.Close()
+}
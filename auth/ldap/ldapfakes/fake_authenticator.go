@@ -0,0 +1,86 @@
+// This file was generated by counterfeiter
+package ldapfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAuthenticator struct {
+	AuthenticateStub        func(config *db.LDAPAuth, username string, password string) (verifier.Identity, bool, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		config   *db.LDAPAuth
+		username string
+		password string
+	}
+	authenticateReturns struct {
+		result1 verifier.Identity
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthenticator) Authenticate(config *db.LDAPAuth, username string, password string) (verifier.Identity, bool, error) {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		config   *db.LDAPAuth
+		username string
+		password string
+	}{config, username, password})
+	fake.recordInvocation("Authenticate", []interface{}{config, username, password})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(config, username, password)
+	} else {
+		return fake.authenticateReturns.result1, fake.authenticateReturns.result2, fake.authenticateReturns.result3
+	}
+}
+
+func (fake *FakeAuthenticator) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeAuthenticator) AuthenticateArgsForCall(i int) (*db.LDAPAuth, string, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].config, fake.authenticateArgsForCall[i].username, fake.authenticateArgsForCall[i].password
+}
+
+func (fake *FakeAuthenticator) AuthenticateReturns(result1 verifier.Identity, result2 bool, result3 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 verifier.Identity
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuthenticator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuthenticator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ldap.Authenticator = new(FakeAuthenticator)
@@ -0,0 +1,212 @@
+package ldap_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+)
+
+// stubDirectory is an in-process LDAP server, as far as ldap.Conn can tell:
+// it checks binds against entries' passwords, and evaluates the equality,
+// presence, and boolean filters that authenticating uses.
+type stubDirectory struct {
+	entries []stubEntry
+
+	dialed  []*db.LDAPAuth
+	binds   []string
+	closed  int
+	dialErr error
+}
+
+type stubEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+func (d *stubDirectory) Dial(config *db.LDAPAuth) (ldap.Conn, error) {
+	d.dialed = append(d.dialed, config)
+
+	if d.dialErr != nil {
+		return nil, d.dialErr
+	}
+
+	return &stubConn{directory: d}, nil
+}
+
+type stubConn struct {
+	directory *stubDirectory
+}
+
+func (c *stubConn) Bind(dn string, password string) error {
+	c.directory.binds = append(c.directory.binds, dn)
+
+	for _, entry := range c.directory.entries {
+		if entry.dn == dn && entry.password != "" && entry.password == password {
+			return nil
+		}
+	}
+
+	return ldap.ErrInvalidCredentials
+}
+
+func (c *stubConn) Search(baseDN string, filter string, attributes []string) ([]ldap.Entry, error) {
+	matches, rest, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if rest != "" {
+		return nil, fmt.Errorf("trailing characters in filter: %q", rest)
+	}
+
+	found := []ldap.Entry{}
+	for _, entry := range c.directory.entries {
+		if !strings.HasSuffix(entry.dn, baseDN) || !matches(entry) {
+			continue
+		}
+
+		values := map[string][]string{}
+		for _, attribute := range attributes {
+			if value, ok := entry.attributes[attribute]; ok {
+				values[attribute] = value
+			}
+		}
+
+		found = append(found, ldap.Entry{DN: entry.dn, Attributes: values})
+	}
+
+	return found, nil
+}
+
+func (c *stubConn) Close() {
+	c.directory.closed++
+}
+
+type matcher func(stubEntry) bool
+
+func parseFilter(filter string) (matcher, string, error) {
+	if !strings.HasPrefix(filter, "(") {
+		return nil, "", fmt.Errorf("expected '(' in filter: %q", filter)
+	}
+
+	filter = filter[1:]
+
+	switch {
+	case strings.HasPrefix(filter, "&"), strings.HasPrefix(filter, "|"):
+		op := filter[0]
+		filter = filter[1:]
+
+		matchers := []matcher{}
+		for strings.HasPrefix(filter, "(") {
+			m, rest, err := parseFilter(filter)
+			if err != nil {
+				return nil, "", err
+			}
+
+			matchers = append(matchers, m)
+			filter = rest
+		}
+
+		rest, err := closeFilter(filter)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return func(entry stubEntry) bool {
+			for _, m := range matchers {
+				if m(entry) != (op == '&') {
+					return op != '&'
+				}
+			}
+
+			return op == '&'
+		}, rest, nil
+
+	case strings.HasPrefix(filter, "!"):
+		m, rest, err := parseFilter(filter[1:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		rest, err = closeFilter(rest)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return func(entry stubEntry) bool { return !m(entry) }, rest, nil
+
+	default:
+		end := strings.Index(filter, ")")
+		if end == -1 {
+			return nil, "", errors.New("unterminated filter")
+		}
+
+		item := strings.SplitN(filter[:end], "=", 2)
+		if len(item) != 2 {
+			return nil, "", fmt.Errorf("unsupported filter item: %q", filter[:end])
+		}
+
+		attr := item[0]
+		rest := filter[end+1:]
+
+		if item[1] == "*" {
+			return func(entry stubEntry) bool {
+				return len(entry.attributes[attr]) > 0
+			}, rest, nil
+		}
+
+		value, err := unescape(item[1])
+		if err != nil {
+			return nil, "", err
+		}
+
+		return func(entry stubEntry) bool {
+			for _, v := range entry.attributes[attr] {
+				if strings.EqualFold(v, value) {
+					return true
+				}
+			}
+
+			return false
+		}, rest, nil
+	}
+}
+
+func closeFilter(filter string) (string, error) {
+	if !strings.HasPrefix(filter, ")") {
+		return "", fmt.Errorf("expected ')' in filter: %q", filter)
+	}
+
+	return filter[1:], nil
+}
+
+func unescape(value string) (string, error) {
+	unescaped := ""
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if i+3 > len(value) {
+				return "", fmt.Errorf("truncated escape in %q", value)
+			}
+
+			c, err := strconv.ParseUint(value[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", err
+			}
+
+			unescaped += string(rune(c))
+			i += 2
+		case '*', '(', ')':
+			return "", fmt.Errorf("unescaped %q in %q", value[i], value)
+		default:
+			unescaped += string(value[i])
+		}
+	}
+
+	return unescaped, nil
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/auth/ldap"
+	"github.com/concourse/atc/db"
+)
+
+type ldapAuthValidator struct {
+	team          db.SavedTeam
+	authenticator ldap.Authenticator
+}
+
+func NewLDAPAuthValidator(team db.SavedTeam, authenticator ldap.Authenticator) Validator {
+	return ldapAuthValidator{
+		team:          team,
+		authenticator: authenticator,
+	}
+}
+
+func (v ldapAuthValidator) IsAuthenticated(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	username, password, err := extractUsernameAndPassword(auth)
+	if err != nil {
+		return false
+	}
+
+	_, authenticated, err := v.authenticator.Authenticate(v.team.LDAPAuth, username, password)
+	if err != nil {
+		return false
+	}
+
+	return authenticated
+}
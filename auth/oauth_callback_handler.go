@@ -158,9 +158,18 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	identity, err := provider.Identify(hLog.Session("identify"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-identify-user", err)
+		http.Error(w, "failed to identify user", http.StatusInternalServerError)
+		return
+	}
+
+	role := team.Roles.RoleFor(identity.User, identity.Groups)
+
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin, role, identity.User)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
@@ -22,10 +22,12 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/provider/providerfakes"
+	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"regexp"
@@ -279,6 +281,69 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("makes the user an owner of the team", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["role"]).To(Equal("owner"))
+							})
+
+							It("identifies the user using the provider's HTTP client", func() {
+								Expect(fakeProvider.IdentifyCallCount()).To(Equal(1))
+								_, client := fakeProvider.IdentifyArgsForCall(0)
+								Expect(client).To(Equal(httpClient))
+							})
+
+							Context("when the provider identifies the user", func() {
+								BeforeEach(func() {
+									fakeProvider.IdentifyReturns(verifier.Identity{
+										User:   "some-user",
+										Groups: []string{"some-org", "some-org/some-team"},
+									}, nil)
+								})
+
+								It("contains the user", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["user"]).To(Equal("some-user"))
+								})
+							})
+
+							Context("when the team has roles", func() {
+								BeforeEach(func() {
+									team.Roles = atc.TeamRoleBindings{
+										{Role: atc.TeamRoleOperator, Groups: []string{"some-org/some-team"}},
+									}
+									fakeTeamDB.GetTeamReturns(team, true, nil)
+
+									fakeProvider.IdentifyReturns(verifier.Identity{
+										User:   "some-user",
+										Groups: []string{"some-org", "some-org/some-team"},
+									}, nil)
+								})
+
+								It("contains the role bound to the user", func() {
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["role"]).To(Equal("operator"))
+								})
+							})
+						})
+
+						Context("when the user cannot be identified", func() {
+							BeforeEach(func() {
+								fakeProvider.IdentifyReturns(verifier.Identity{}, errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
 						})
 
 						It("does not redirect", func() {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -16,6 +17,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	Identifier
 }
 
 type OAuthClient interface {
@@ -29,3 +31,7 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+type Identifier interface {
+	Identify(lager.Logger, *http.Client) (verifier.Identity, error)
+}
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth/provider"
+	"github.com/concourse/atc/auth/verifier"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
@@ -57,6 +58,16 @@ type FakeProvider struct {
 		result1 bool
 		result2 error
 	}
+	IdentifyStub        func(lager.Logger, *http.Client) (verifier.Identity, error)
+	identifyMutex       sync.RWMutex
+	identifyArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	identifyReturns struct {
+		result1 verifier.Identity
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -225,6 +236,41 @@ func (fake *FakeProvider) VerifyReturns(result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeProvider) Identify(arg1 lager.Logger, arg2 *http.Client) (verifier.Identity, error) {
+	fake.identifyMutex.Lock()
+	fake.identifyArgsForCall = append(fake.identifyArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("Identify", []interface{}{arg1, arg2})
+	fake.identifyMutex.Unlock()
+	if fake.IdentifyStub != nil {
+		return fake.IdentifyStub(arg1, arg2)
+	} else {
+		return fake.identifyReturns.result1, fake.identifyReturns.result2
+	}
+}
+
+func (fake *FakeProvider) IdentifyCallCount() int {
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
+	return len(fake.identifyArgsForCall)
+}
+
+func (fake *FakeProvider) IdentifyArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
+	return fake.identifyArgsForCall[i].arg1, fake.identifyArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) IdentifyReturns(result1 verifier.Identity, result2 error) {
+	fake.IdentifyStub = nil
+	fake.identifyReturns = struct {
+		result1 verifier.Identity
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +284,8 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.clientMutex.RUnlock()
 	fake.verifyMutex.RLock()
 	defer fake.verifyMutex.RUnlock()
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -3,21 +3,25 @@ package auth
 import (
 	"net/http"
 
+	"github.com/concourse/atc/auth/ldap"
 	"github.com/concourse/atc/db"
 )
 
 type teamAuthValidator struct {
-	teamDBFactory db.TeamDBFactory
-	jwtValidator  Validator
+	teamDBFactory     db.TeamDBFactory
+	jwtValidator      Validator
+	ldapAuthenticator ldap.Authenticator
 }
 
 func NewTeamAuthValidator(
 	teamDBFactory db.TeamDBFactory,
 	jwtValidator Validator,
+	ldapAuthenticator ldap.Authenticator,
 ) Validator {
 	return &teamAuthValidator{
-		teamDBFactory: teamDBFactory,
-		jwtValidator:  jwtValidator,
+		teamDBFactory:     teamDBFactory,
+		jwtValidator:      jwtValidator,
+		ldapAuthenticator: ldapAuthenticator,
 	}
 }
 
@@ -37,5 +41,9 @@ func (v teamAuthValidator) IsAuthenticated(r *http.Request) bool {
 		return true
 	}
 
+	if team.LDAPAuth != nil && NewLDAPAuthValidator(team, v.ldapAuthenticator).IsAuthenticated(r) {
+		return true
+	}
+
 	return v.jwtValidator.IsAuthenticated(r)
 }
@@ -1,6 +1,7 @@
 package auth_test
 
 import (
+	"errors"
 	"net/http"
 
 	"golang.org/x/crypto/bcrypt"
@@ -8,6 +9,8 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/auth/ldap/ldapfakes"
+	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 
@@ -22,6 +25,8 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB       *dbfakes.FakeTeamDB
 		jwtValidator *authfakes.FakeValidator
 
+		ldapAuthenticator *ldapfakes.FakeAuthenticator
+
 		request           *http.Request
 		isAuthenticated   bool
 		username          string
@@ -48,7 +53,9 @@ var _ = Describe("TeamAuthValidator", func() {
 		teamDB = new(dbfakes.FakeTeamDB)
 		teamDBFactory.GetTeamDBReturns(teamDB)
 
-		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator)
+		ldapAuthenticator = new(ldapfakes.FakeAuthenticator)
+
+		validator = auth.NewTeamAuthValidator(teamDBFactory, jwtValidator, ldapAuthenticator)
 
 		request, err = http.NewRequest("GET", "http://example.com", nil)
 		Expect(err).ToNot(HaveOccurred())
@@ -99,6 +106,71 @@ var _ = Describe("TeamAuthValidator", func() {
 			})
 		})
 
+		Context("when team has ldap auth configured", func() {
+			BeforeEach(func() {
+				team.LDAPAuth = &db.LDAPAuth{
+					Host: "ldap.example.com:636",
+				}
+				teamDB.GetTeamReturns(team, true, nil)
+			})
+
+			Context("when the request has credentials", func() {
+				BeforeEach(func() {
+					request.Header.Set("Authorization", "Basic "+b64(username+":"+password))
+				})
+
+				It("authenticates them with the team's ldap config", func() {
+					Expect(ldapAuthenticator.AuthenticateCallCount()).To(Equal(1))
+					config, actualUsername, actualPassword := ldapAuthenticator.AuthenticateArgsForCall(0)
+					Expect(config).To(Equal(team.LDAPAuth))
+					Expect(actualUsername).To(Equal(username))
+					Expect(actualPassword).To(Equal(password))
+				})
+
+				Context("when the ldap server authenticates the user", func() {
+					BeforeEach(func() {
+						ldapAuthenticator.AuthenticateReturns(verifier.Identity{User: username}, true, nil)
+					})
+
+					It("returns true", func() {
+						Expect(isAuthenticated).To(BeTrue())
+					})
+				})
+
+				Context("when the ldap server does not authenticate the user", func() {
+					BeforeEach(func() {
+						ldapAuthenticator.AuthenticateReturns(verifier.Identity{}, false, nil)
+					})
+
+					It("returns false", func() {
+						Expect(isAuthenticated).To(BeFalse())
+					})
+				})
+
+				Context("when the ldap server cannot be reached", func() {
+					BeforeEach(func() {
+						ldapAuthenticator.AuthenticateReturns(verifier.Identity{}, true, errors.New("nope"))
+					})
+
+					It("returns false", func() {
+						Expect(isAuthenticated).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when the request has a token instead", func() {
+				BeforeEach(func() {
+					request.Header.Set("Authorization", "Bearer some-token")
+					jwtValidator.IsAuthenticatedReturns(true)
+				})
+
+				It("delegates to jwtValidator", func() {
+					Expect(ldapAuthenticator.AuthenticateCallCount()).To(BeZero())
+					Expect(isAuthenticated).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when team has uaa auth configured", func() {
 			BeforeEach(func() {
 				team.UAAAuth = &db.UAAAuth{
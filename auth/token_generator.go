@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -17,9 +20,17 @@ const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
+const userClaimKey = "user"
+const apiTokenClaimKey = "apiToken"
+const tokenIDClaimKey = "jti"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (TokenType, TokenValue, error)
+
+	// GenerateAPIToken generates a token which does not expire. It is only
+	// accepted for as long as its hash is known to the APITokenDB.
+	GenerateAPIToken(teamName string, teamID int, isAdmin bool, role atc.TeamRole, tokenName string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,12 +43,38 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (TokenType, TokenValue, error) {
 	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
 		expClaimKey:      expiration.Unix(),
 		teamNameClaimKey: teamName,
 		teamIDClaimKey:   teamID,
 		isAdminClaimKey:  isAdmin,
+		roleClaimKey:     string(role),
+		userClaimKey:     user,
+	})
+
+	signed, err := jwtToken.SignedString(generator.privateKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return TokenTypeBearer, TokenValue(signed), err
+}
+
+func (generator *tokenGenerator) GenerateAPIToken(teamName string, teamID int, isAdmin bool, role atc.TeamRole, tokenName string) (TokenType, TokenValue, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
+		tokenIDClaimKey:  hex.EncodeToString(nonce),
+		teamNameClaimKey: teamName,
+		teamIDClaimKey:   teamID,
+		isAdminClaimKey:  isAdmin,
+		roleClaimKey:     string(role),
+		apiTokenClaimKey: tokenName,
 	})
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
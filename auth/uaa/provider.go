@@ -23,6 +23,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	Identifier
 }
 
 type OAuthClient interface {
@@ -35,6 +36,10 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type Identifier interface {
+	Identify(lager.Logger, *http.Client) (verifier.Identity, error)
+}
+
 func NewProvider(
 	uaaAuth *db.UAAAuth,
 	redirectURL string,
@@ -50,6 +55,7 @@ func NewProvider(
 			spaceGUIDs: uaaAuth.CFSpaces,
 			cfAPIURL:   uaaAuth.CFURL,
 		},
+		Identifier: UserIdentifier{},
 		Config: &oauth2.Config{
 			ClientID:     uaaAuth.ClientID,
 			ClientSecret: uaaAuth.ClientSecret,
@@ -69,6 +75,7 @@ type uaaProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+	verifier.Identifier
 	CFCACert string
 }
 
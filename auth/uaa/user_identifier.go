@@ -0,0 +1,62 @@
+package uaa
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+)
+
+// UserIdentifier identifies users by the user name in their UAA access
+// token, and groups them by the token's scopes.
+type UserIdentifier struct{}
+
+type UAAUserToken struct {
+	UserID   string   `json:"user_id"`
+	UserName string   `json:"user_name"`
+	Scopes   []string `json:"scope"`
+}
+
+func (identifier UserIdentifier) Identify(logger lager.Logger, httpClient *http.Client) (verifier.Identity, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return verifier.Identity{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) < 2 {
+		return verifier.Identity{}, errors.New("access token contains an invalid number of segments")
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	var uaaToken UAAUserToken
+	err = json.Unmarshal(decodedClaims, &uaaToken)
+	if err != nil {
+		return verifier.Identity{}, err
+	}
+
+	user := uaaToken.UserName
+	if user == "" {
+		user = uaaToken.UserID
+	}
+
+	return verifier.Identity{
+		User:   user,
+		Groups: uaaToken.Scopes,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package uaa_test
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/uaa"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserIdentifier", func() {
+	var claims jwt.MapClaims
+	var httpClient *http.Client
+	var identity verifier.Identity
+	var identifyErr error
+
+	BeforeEach(func() {
+		claims = jwt.MapClaims{
+			"exp":     time.Now().Add(time.Hour * 72).Unix(),
+			"user_id": "my-user-id",
+			"scope":   []string{"cloud_controller.read", "concourse.admin"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SigningString()
+		Expect(err).NotTo(HaveOccurred())
+
+		c := &oauth2.Config{}
+		httpClient = c.Client(oauth2.NoContext, &oauth2.Token{AccessToken: accessToken})
+
+		identity, identifyErr = UserIdentifier{}.Identify(lagertest.NewTestLogger("test"), httpClient)
+	})
+
+	Context("when the token contains a 'user_name'", func() {
+		BeforeEach(func() {
+			claims["user_name"] = "my-user"
+		})
+
+		It("identifies the user by name and groups them by scope", func() {
+			Expect(identifyErr).NotTo(HaveOccurred())
+			Expect(identity).To(Equal(verifier.Identity{
+				User:   "my-user",
+				Groups: []string{"cloud_controller.read", "concourse.admin"},
+			}))
+		})
+	})
+
+	Context("when the token does not contain a 'user_name'", func() {
+		It("identifies the user by ID", func() {
+			Expect(identifyErr).NotTo(HaveOccurred())
+			Expect(identity.User).To(Equal("my-user-id"))
+		})
+	})
+
+	Context("when the client does not use an oauth2 transport", func() {
+		It("returns an error", func() {
+			_, err := UserIdentifier{}.Identify(lagertest.NewTestLogger("test"), &http.Client{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -1,10 +1,16 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 //go:generate counterfeiter . UserContextReader
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, int, bool, bool)
+	GetRole(r *http.Request) (atc.TeamRole, bool)
+	GetUser(r *http.Request) (string, bool)
 	GetSystem(r *http.Request) (bool, bool)
 }
@@ -0,0 +1,18 @@
+package verifier
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Identity is who an auth provider says the user is, and the groups the
+// provider says they belong to.
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+type Identifier interface {
+	Identify(lager.Logger, *http.Client) (Identity, error)
+}
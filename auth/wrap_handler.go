@@ -9,6 +9,8 @@ var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
+var roleKey = "role"
+var userKey = "user"
 var isSystemKey = "system"
 
 func WrapHandler(
@@ -30,7 +32,17 @@ type authHandler struct {
 }
 
 func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := context.WithValue(r.Context(), authenticated, h.validator.IsAuthenticated(r))
+	isAuthenticated := h.validator.IsAuthenticated(r)
+
+	ctx := context.WithValue(r.Context(), authenticated, isAuthenticated)
+
+	// the user context is only trusted once the request is authenticated, so
+	// that a token which has expired or been revoked carries no team with it
+	if !isAuthenticated {
+		h.handler.ServeHTTP(w, r.WithContext(ctx))
+		return
+	}
+
 	teamName, teamID, isAdmin, found := h.userContextReader.GetTeam(r)
 	if found {
 		ctx = context.WithValue(ctx, teamNameKey, teamName)
@@ -38,9 +50,20 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	role, found := h.userContextReader.GetRole(r)
+	if found {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+
+	user, found := h.userContextReader.GetUser(r)
+	if found {
+		ctx = context.WithValue(ctx, userKey, user)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
 	}
+
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 }
@@ -109,6 +109,7 @@ var _ = Describe("WrapHandler", func() {
 
 		Context("when the userContextReader finds team information", func() {
 			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(true)
 				fakeUserContextReader.GetTeamReturns("some-team", 9, true, true)
 			})
 
@@ -117,6 +118,17 @@ var _ = Describe("WrapHandler", func() {
 				Expect(<-teamNameChan).To(Equal("some-team"))
 				Expect(<-isAdminChan).To(BeTrue())
 			})
+
+			Context("when the validator returns false", func() {
+				BeforeEach(func() {
+					fakeValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("does not pass team information along in the request object", func() {
+					Expect(<-foundChan).To(BeFalse())
+					Expect(fakeUserContextReader.GetTeamCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when the userContextReader does not find team information", func() {
@@ -131,6 +143,7 @@ var _ = Describe("WrapHandler", func() {
 
 		Context("when the userContextReader finds system information", func() {
 			BeforeEach(func() {
+				fakeValidator.IsAuthenticatedReturns(true)
 				fakeUserContextReader.GetSystemReturns(true, true)
 			})
 
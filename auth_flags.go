@@ -150,3 +150,54 @@ func (auth *UAAAuthFlag) Validate() error {
 	}
 	return errs.ErrorOrNil()
 }
+
+type LDAPAuthFlag struct {
+	Host               string   `long:"host"                 description:"LDAP server address, as host:port."`
+	InsecureNoSSL      bool     `long:"insecure-no-ssl"      description:"Connect to the LDAP server without TLS."`
+	InsecureSkipVerify bool     `long:"insecure-skip-verify" description:"Skip verification of the LDAP server's certificate."`
+	CACert             PathFlag `long:"ca-cert"              description:"Path to the LDAP server's PEM-encoded CA certificate file."`
+
+	BindDN       string `long:"bind-dn"       description:"DN of the account to search the directory as. Searches are anonymous if omitted."`
+	BindPassword string `long:"bind-password" description:"Password of the account to search the directory as."`
+
+	UserSearchBaseDN   string `long:"user-search-base-dn"  description:"Base DN to search for users under."`
+	UserSearchFilter   string `long:"user-search-filter"   description:"Filter that users must match, e.g. (objectClass=person)."`
+	UserSearchUsername string `long:"user-search-username" description:"Attribute to match the username against." default:"uid"`
+
+	GroupSearchBaseDN    string   `long:"group-search-base-dn"    description:"Base DN to search for the user's groups under. Groups are not searched for if omitted."`
+	GroupSearchFilter    string   `long:"group-search-filter"     description:"Filter that groups must match, e.g. (objectClass=groupOfNames)."`
+	GroupSearchGroupAttr string   `long:"group-search-group-attr" description:"Attribute of a group listing the DNs of its members." default:"member"`
+	GroupSearchNameAttr  string   `long:"group-search-name-attr"  description:"Attribute of a group holding its name." default:"cn"`
+	Groups               []string `long:"group"                   description:"LDAP group whose members will have access. Everyone found by the user search has access if omitted." value-name:"NAME"`
+}
+
+func (auth *LDAPAuthFlag) IsConfigured() bool {
+	return auth.Host != "" ||
+		auth.BindDN != "" ||
+		auth.UserSearchBaseDN != "" ||
+		auth.GroupSearchBaseDN != "" ||
+		len(auth.Groups) > 0
+}
+
+func (auth *LDAPAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.Host == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-host to use LDAP."),
+		)
+	}
+	if auth.UserSearchBaseDN == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-user-search-base-dn to use LDAP."),
+		)
+	}
+	if len(auth.Groups) > 0 && auth.GroupSearchBaseDN == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --ldap-auth-group-search-base-dn to restrict LDAP access to groups."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
@@ -23,6 +23,7 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+	RerunOf      int    `json:"rerun_of,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -60,4 +61,5 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	QueuePosition       int                               `json:"queue_position,omitempty"`
 }
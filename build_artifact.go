@@ -0,0 +1,8 @@
+package atc
+
+// BuildArtifact is a task output retained by a build, downloadable as a
+// tarball.
+type BuildArtifact struct {
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
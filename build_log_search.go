@@ -0,0 +1,13 @@
+package atc
+
+// BuildLogSearchResult is a build whose logs matched a search, along with
+// excerpts of the matching lines.
+type BuildLogSearchResult struct {
+	BuildID int             `json:"build_id"`
+	Matches []BuildLogMatch `json:"matches"`
+}
+
+type BuildLogMatch struct {
+	OriginID string `json:"origin_id"`
+	Excerpt  string `json:"excerpt"`
+}
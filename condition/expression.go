@@ -0,0 +1,439 @@
+package condition
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expression is a parsed condition, for example:
+//
+//	build.manually_triggered || inputs.repo.version.ref == "abc123"
+//
+// An expression is made up of references to variables, "quoted" strings,
+// bare words (such as numbers), true and false, compared with == and != and
+// combined with !, && and ||. Parentheses can be used for grouping.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Variables are the values that references in an Expression resolve to, keyed
+// by the reference's full dotted name. Values are either strings or bools.
+//
+// A reference to a variable which isn't set is equal only to other unset
+// variables, and is false when used on its own.
+type Variables map[string]interface{}
+
+func Parse(source string) (Expression, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if len(tokens) == 0 {
+		return Expression{}, fmt.Errorf("empty condition")
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if !p.done() {
+		return Expression{}, p.unexpected()
+	}
+
+	return Expression{
+		source: source,
+		root:   root,
+	}, nil
+}
+
+// Evaluate determines whether the expression holds for the given variables.
+// It returns an error if a value which isn't true or false is used where one
+// is expected.
+func (expression Expression) Evaluate(variables Variables) (bool, error) {
+	return evalBool(expression.root, variables)
+}
+
+// References returns the names of the variables referred to by the
+// expression, in the order they appear.
+func (expression Expression) References() []string {
+	var references []string
+	expression.root.collect(&references)
+	return references
+}
+
+func (expression Expression) String() string {
+	return expression.source
+}
+
+type node interface {
+	eval(Variables) (interface{}, error)
+	collect(*[]string)
+	String() string
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n literal) eval(Variables) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n literal) collect(*[]string) {}
+
+func (n literal) String() string {
+	if s, ok := n.value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprintf("%v", n.value)
+}
+
+type reference struct {
+	name string
+}
+
+func (n reference) eval(variables Variables) (interface{}, error) {
+	return variables[n.name], nil
+}
+
+func (n reference) collect(references *[]string) {
+	*references = append(*references, n.name)
+}
+
+func (n reference) String() string {
+	return n.name
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) eval(variables Variables) (interface{}, error) {
+	value, err := evalBool(n.operand, variables)
+	return !value, err
+}
+
+func (n not) collect(references *[]string) {
+	n.operand.collect(references)
+}
+
+func (n not) String() string {
+	return "!" + n.operand.String()
+}
+
+type logical struct {
+	and         bool
+	left, right node
+}
+
+func (n logical) eval(variables Variables) (interface{}, error) {
+	left, err := evalBool(n.left, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if left != n.and {
+		return left, nil
+	}
+
+	return evalBool(n.right, variables)
+}
+
+func (n logical) collect(references *[]string) {
+	n.left.collect(references)
+	n.right.collect(references)
+}
+
+func (n logical) String() string {
+	operator := "||"
+	if n.and {
+		operator = "&&"
+	}
+
+	return fmt.Sprintf("(%s %s %s)", n.left, operator, n.right)
+}
+
+type comparison struct {
+	negate      bool
+	left, right node
+}
+
+func (n comparison) eval(variables Variables) (interface{}, error) {
+	left, err := n.left.eval(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return (left == right) != n.negate, nil
+}
+
+func (n comparison) collect(references *[]string) {
+	n.left.collect(references)
+	n.right.collect(references)
+}
+
+func (n comparison) String() string {
+	operator := "=="
+	if n.negate {
+		operator = "!="
+	}
+
+	return fmt.Sprintf("%s %s %s", n.left, operator, n.right)
+}
+
+func evalBool(n node, variables Variables) (bool, error) {
+	value, err := n.eval(variables)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("%s is '%v', not true or false", n, v)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+var operators = []string{"==", "!=", "&&", "||", "!", "(", ")"}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+
+	i := 0
+
+lexing:
+	for i < len(source) {
+		c := source[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue lexing
+
+		case c == '"':
+			start := i
+			var text []byte
+
+			for i++; i < len(source); i++ {
+				switch source[i] {
+				case '\\':
+					i++
+					if i < len(source) {
+						text = append(text, source[i])
+					}
+				case '"':
+					tokens = append(tokens, token{tokenString, string(text), start})
+					i++
+					continue lexing
+				default:
+					text = append(text, source[i])
+				}
+			}
+
+			return nil, fmt.Errorf("unterminated string at position %d", start)
+
+		case isWordByte(c):
+			start := i
+			for i < len(source) && isWordByte(source[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{tokenWord, source[start:i], start})
+			continue lexing
+		}
+
+		for _, operator := range operators {
+			if strings.HasPrefix(source[i:], operator) {
+				tokens = append(tokens, token{tokenOperator, operator, i})
+				i += len(operator)
+				continue lexing
+			}
+		}
+
+		return nil, fmt.Errorf("unexpected '%c' at position %d", c, i)
+	}
+
+	return tokens, nil
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.'
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *parser) accept(operator string) bool {
+	if p.done() {
+		return false
+	}
+
+	t := p.tokens[p.next]
+	if t.kind != tokenOperator || t.text != operator {
+		return false
+	}
+
+	p.next++
+	return true
+}
+
+func (p *parser) unexpected() error {
+	if p.done() {
+		return fmt.Errorf("unexpected end of condition")
+	}
+
+	t := p.tokens[p.next]
+	return fmt.Errorf("unexpected '%s' at position %d", t.text, t.position)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logical{and: false, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logical{and: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return not{operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	var negate bool
+	switch {
+	case p.accept("=="):
+	case p.accept("!="):
+		negate = true
+	default:
+		return left, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison{negate: negate, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	if p.accept("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(")") {
+			return nil, p.unexpected()
+		}
+
+		return inner, nil
+	}
+
+	if p.done() {
+		return nil, p.unexpected()
+	}
+
+	t := p.tokens[p.next]
+
+	switch t.kind {
+	case tokenString:
+		p.next++
+		return literal{t.text}, nil
+
+	case tokenWord:
+		p.next++
+
+		switch {
+		case t.text == "true":
+			return literal{true}, nil
+		case t.text == "false":
+			return literal{false}, nil
+		case t.text[0] >= '0' && t.text[0] <= '9':
+			return literal{t.text}, nil
+		default:
+			return reference{t.text}, nil
+		}
+	}
+
+	return nil, p.unexpected()
+}
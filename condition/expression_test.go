@@ -0,0 +1,92 @@
+package condition_test
+
+import (
+	"github.com/concourse/atc/condition"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expression", func() {
+	variables := condition.Variables{
+		"build.manually_triggered":    true,
+		"build.job_name":              "some-job",
+		"build.id":                    "42",
+		"inputs.repo.version.ref":     "abc123",
+		"inputs.repo.triggered":       false,
+		"inputs.repo.metadata.author": "some \"author\"",
+	}
+
+	DescribeTable("Evaluate",
+		func(source string, expected bool) {
+			expression, err := condition.Parse(source)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := expression.Evaluate(variables)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("a true reference", "build.manually_triggered", true),
+		Entry("a false reference", "inputs.repo.triggered", false),
+		Entry("an unset reference", "inputs.other.triggered", false),
+		Entry("a literal", "true", true),
+		Entry("an equal string", `inputs.repo.version.ref == "abc123"`, true),
+		Entry("an unequal string", `inputs.repo.version.ref == "def456"`, false),
+		Entry("a number", "build.id == 42", true),
+		Entry("an escaped string", `inputs.repo.metadata.author == "some \"author\""`, true),
+		Entry("not equal", `build.job_name != "some-job"`, false),
+		Entry("an unset reference compared with a string", `inputs.other.version.ref == ""`, false),
+		Entry("two unset references", "inputs.a.triggered == inputs.b.triggered", true),
+		Entry("a bool compared with a string", `build.manually_triggered == "true"`, false),
+		Entry("negation", "!inputs.repo.triggered", true),
+		Entry("and", `build.manually_triggered && build.job_name == "other-job"`, false),
+		Entry("or", `inputs.repo.triggered || build.job_name == "some-job"`, true),
+		Entry("and binding tighter than or", "true || false && false", true),
+		Entry("parentheses", "(true || false) && false", false),
+		Entry("negated parentheses", `!(build.id == 42 || false)`, false),
+	)
+
+	DescribeTable("Parse errors",
+		func(source string, message string) {
+			_, err := condition.Parse(source)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("nothing", "  ", "empty condition"),
+		Entry("a dangling operator", "build.id ==", "unexpected end of condition"),
+		Entry("an unclosed parenthesis", "(true", "unexpected end of condition"),
+		Entry("an extra parenthesis", "true)", "unexpected ')' at position 4"),
+		Entry("an unknown character", "build.id = 42", "unexpected '=' at position 9"),
+		Entry("an unterminated string", `build.name == "42`, "unterminated string at position 14"),
+		Entry("two operands", "true false", "unexpected 'false' at position 5"),
+	)
+
+	It("returns an error if a value which isn't true or false is used on its own", func() {
+		expression, err := condition.Parse("build.manually_triggered && build.job_name")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = expression.Evaluate(variables)
+		Expect(err).To(MatchError("build.job_name is 'some-job', not true or false"))
+	})
+
+	It("does not evaluate the right side of && or || if it doesn't need to", func() {
+		expression, err := condition.Parse("false && build.job_name")
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := expression.Evaluate(variables)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeFalse())
+	})
+
+	It("returns its references in order", func() {
+		expression, err := condition.Parse(`!inputs.repo.triggered || (build.id == 42 && params.platform != build.name)`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(expression.References()).To(Equal([]string{
+			"inputs.repo.triggered",
+			"build.id",
+			"params.platform",
+			"build.name",
+		}))
+	})
+})
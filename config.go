@@ -56,8 +56,14 @@ type ResourceConfig struct {
 	Type       string `yaml:"type" json:"type" mapstructure:"type"`
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token" mapstructure:"webhook_token"`
 }
 
+// CheckEveryNever disables periodic checking of a resource, for resources
+// whose checks are triggered via their webhook.
+const CheckEveryNever = "never"
+
 type ResourceType struct {
 	Name   string `yaml:"name" json:"name" mapstructure:"name"`
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
@@ -91,6 +97,8 @@ type Hooks struct {
 	Failure *PlanConfig
 	Ensure  *PlanConfig
 	Success *PlanConfig
+	Error   *PlanConfig
+	Abort   *PlanConfig
 }
 
 type JobConfig struct {
@@ -102,16 +110,28 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             int      `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
+
+	Triggers []TriggerConfig `yaml:"triggers,omitempty" json:"triggers,omitempty" mapstructure:"triggers"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
 	Ensure  *PlanConfig `yaml:"ensure,omitempty" json:"ensure,omitempty" mapstructure:"ensure"`
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
+	Error   *PlanConfig `yaml:"on_error,omitempty" json:"on_error,omitempty" mapstructure:"on_error"`
+	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+}
+
+// A TriggerConfig schedules builds of a job with a five-field cron
+// expression, evaluated in the given timezone (UTC by default).
+type TriggerConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" mapstructure:"timezone"`
 }
 
 func (config JobConfig) Hooks() Hooks {
-	return Hooks{config.Failure, config.Ensure, config.Success}
+	return Hooks{config.Failure, config.Ensure, config.Success, config.Error, config.Abort}
 }
 
 func (config JobConfig) MaxInFlight() int {
@@ -240,6 +260,85 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+// A RetryCondition is an outcome of a step that causes it to be retried.
+type RetryCondition string
+
+const (
+	RetryOnFailed  RetryCondition = "failed"
+	RetryOnErrored RetryCondition = "errored"
+)
+
+// An AttemptsConfig represents how many times a step is attempted, and
+// optionally how long to wait between attempts and which outcomes to retry.
+// A bare number is shorthand for just the count.
+type AttemptsConfig struct {
+	Count int `yaml:"count" json:"count" mapstructure:"count"`
+
+	// the wait before the second attempt, doubling for each attempt after
+	// that, up to the max backoff
+	Backoff    string `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+	MaxBackoff string `yaml:"max_backoff,omitempty" json:"max_backoff,omitempty" mapstructure:"max_backoff"`
+
+	// the outcomes that are retried; both failures and errors if empty
+	On []RetryCondition `yaml:"on,omitempty" json:"on,omitempty" mapstructure:"on"`
+}
+
+type attemptsConfig AttemptsConfig
+
+func (c AttemptsConfig) isCountOnly() bool {
+	return c.Backoff == "" && c.MaxBackoff == "" && len(c.On) == 0
+}
+
+func (c *AttemptsConfig) UnmarshalJSON(attempts []byte) error {
+	var count int
+	if err := json.Unmarshal(attempts, &count); err == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	if err := json.Unmarshal(attempts, &config); err != nil {
+		return errors.New("unknown type for attempts")
+	}
+
+	*c = AttemptsConfig(config)
+
+	return nil
+}
+
+func (c *AttemptsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var count int
+	if err := unmarshal(&count); err == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	if err := unmarshal(&config); err != nil {
+		return errors.New("unknown type for attempts")
+	}
+
+	*c = AttemptsConfig(config)
+
+	return nil
+}
+
+func (c AttemptsConfig) MarshalJSON() ([]byte, error) {
+	if c.isCountOnly() {
+		return json.Marshal(c.Count)
+	}
+
+	return json.Marshal(attemptsConfig(c))
+}
+
+func (c AttemptsConfig) MarshalYAML() (interface{}, error) {
+	if c.isCountOnly() {
+		return c.Count, nil
+	}
+
+	return attemptsConfig(c), nil
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -256,6 +355,10 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, running its steps with bounded
+	// concurrency
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -287,15 +390,27 @@ type PlanConfig struct {
 	InputMapping  map[string]string `yaml:"input_mapping,omitempty" json:"input_mapping,omitempty" mapstructure:"input_mapping"`
 	OutputMapping map[string]string `yaml:"output_mapping,omitempty" json:"output_mapping,omitempty" mapstructure:"output_mapping"`
 
+	// used by Task to run it once for every combination of the vars' values,
+	// each passed as a param and interpolated as ((var)) in the mappings
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+
 	// used to specify an image artifact from a previous build to be used as the image for a subsequent task container
 	ImageArtifactName string `yaml:"image,omitempty" json:"image,omitempty" mapstructure:"image"`
 
+	// used by Task to retain some of its outputs after the build, by the names
+	// they're registered under in the build
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty" mapstructure:"artifacts"`
+
 	// used by Put to specify params for the subsequent Get
 	GetParams Params `yaml:"get_params,omitempty" json:"get_params,omitempty" mapstructure:"get_params"`
 
 	// used by any step to specify which workers are eligible to run the step
 	Tags Tags `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
 
+	// used by Get and Put to limit the resources available to their container;
+	// tasks specify their limits in their config
+	ContainerLimits *ContainerLimits `yaml:"container_limits,omitempty" json:"container_limits,omitempty" mapstructure:"container_limits"`
+
 	// used by any step to run something when the step reports a failure
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
 
@@ -305,21 +420,48 @@ type PlanConfig struct {
 	// used on any step to execute on successful completion of the step
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 
+	// used on any step to run something when the step errors, rather than fails
+	Error *PlanConfig `yaml:"on_error,omitempty" json:"on_error,omitempty" mapstructure:"on_error"`
+
+	// used on any step to run something when the build is aborted while the
+	// step is running; a step timing out does not count as an abort
+	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+
 	// used on any step to swallow failures and errors
 	Try *PlanConfig `yaml:"try,omitempty" json:"try,omitempty" mapstructure:"try"`
 
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to skip it unless the condition holds when it's reached
+	If string `yaml:"if,omitempty" json:"if,omitempty" mapstructure:"if"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
 	// repeat the step up to N times, until it works
-	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+	Attempts *AttemptsConfig `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+// An AcrossVarConfig is a var that a task is run across, and its values.
+type AcrossVarConfig struct {
+	Var    string   `yaml:"var" json:"var" mapstructure:"var"`
+	Values []string `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
+}
+
+// An InParallelConfig configures a set of steps to run in parallel.
+type InParallelConfig struct {
+	Steps PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+
+	// the maximum number of steps to run at once; 0 means no limit
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+
+	// interrupt the remaining steps as soon as one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -360,7 +502,7 @@ func (config PlanConfig) ResourceName() string {
 }
 
 func (config PlanConfig) Hooks() Hooks {
-	return Hooks{config.Failure, config.Ensure, config.Success}
+	return Hooks{config.Failure, config.Ensure, config.Success, config.Error, config.Abort}
 }
 
 type ResourceConfigs []ResourceConfig
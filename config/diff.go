@@ -0,0 +1,213 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/concourse/atc"
+)
+
+// RedactedValue replaces each value of a source shown in a config diff, so
+// that previewing a config never reveals its credentials.
+const RedactedValue = "((redacted))"
+
+// Diff compares a pipeline's saved config with one about to be saved. Entries
+// are compared in full, but presented with their sources and webhook tokens
+// redacted.
+func Diff(before atc.Config, after atc.Config) atc.ConfigDiff {
+	diff := atc.ConfigDiff{
+		Groups:        []atc.ConfigChange{},
+		Resources:     []atc.ConfigChange{},
+		ResourceTypes: []atc.ConfigChange{},
+		Jobs:          []atc.ConfigChange{},
+	}
+
+	for _, group := range after.Groups {
+		old, found := before.Groups.Lookup(group.Name)
+		if !found {
+			diff.Groups = append(diff.Groups, added(group.Name, group))
+		} else if !reflect.DeepEqual(old, group) {
+			diff.Groups = append(diff.Groups, changed(group.Name, old, group))
+		}
+	}
+
+	for _, group := range before.Groups {
+		if _, found := after.Groups.Lookup(group.Name); !found {
+			diff.Groups = append(diff.Groups, removed(group.Name, group))
+		}
+	}
+
+	for _, resource := range after.Resources {
+		old, found := before.Resources.Lookup(resource.Name)
+		if !found {
+			diff.Resources = append(diff.Resources, added(resource.Name, redactResource(resource)))
+		} else if !reflect.DeepEqual(old, resource) {
+			diff.Resources = append(diff.Resources, changed(resource.Name, redactResource(old), redactResource(resource)))
+		}
+	}
+
+	for _, resource := range before.Resources {
+		if _, found := after.Resources.Lookup(resource.Name); !found {
+			diff.Resources = append(diff.Resources, removed(resource.Name, redactResource(resource)))
+		}
+	}
+
+	for _, resourceType := range after.ResourceTypes {
+		old, found := before.ResourceTypes.Lookup(resourceType.Name)
+		if !found {
+			diff.ResourceTypes = append(diff.ResourceTypes, added(resourceType.Name, redactResourceType(resourceType)))
+		} else if !reflect.DeepEqual(old, resourceType) {
+			diff.ResourceTypes = append(diff.ResourceTypes, changed(resourceType.Name, redactResourceType(old), redactResourceType(resourceType)))
+		}
+	}
+
+	for _, resourceType := range before.ResourceTypes {
+		if _, found := after.ResourceTypes.Lookup(resourceType.Name); !found {
+			diff.ResourceTypes = append(diff.ResourceTypes, removed(resourceType.Name, redactResourceType(resourceType)))
+		}
+	}
+
+	for _, job := range after.Jobs {
+		old, found := before.Jobs.Lookup(job.Name)
+		if !found {
+			diff.Jobs = append(diff.Jobs, added(job.Name, redactJob(job)))
+		} else if !reflect.DeepEqual(old, job) {
+			diff.Jobs = append(diff.Jobs, changed(job.Name, redactJob(old), redactJob(job)))
+		}
+	}
+
+	for _, job := range before.Jobs {
+		if _, found := after.Jobs.Lookup(job.Name); !found {
+			diff.Jobs = append(diff.Jobs, removed(job.Name, redactJob(job)))
+		}
+	}
+
+	return diff
+}
+
+func added(name string, after interface{}) atc.ConfigChange {
+	return atc.ConfigChange{
+		Name:   name,
+		Change: atc.ConfigChangeAdded,
+		After:  after,
+	}
+}
+
+func changed(name string, before interface{}, after interface{}) atc.ConfigChange {
+	return atc.ConfigChange{
+		Name:   name,
+		Change: atc.ConfigChangeChanged,
+		Before: before,
+		After:  after,
+	}
+}
+
+func removed(name string, before interface{}) atc.ConfigChange {
+	return atc.ConfigChange{
+		Name:   name,
+		Change: atc.ConfigChangeRemoved,
+		Before: before,
+	}
+}
+
+func redactSource(source atc.Source) atc.Source {
+	if source == nil {
+		return nil
+	}
+
+	redacted := atc.Source{}
+	for key := range source {
+		redacted[key] = RedactedValue
+	}
+
+	return redacted
+}
+
+func redactResource(resource atc.ResourceConfig) atc.ResourceConfig {
+	resource.Source = redactSource(resource.Source)
+
+	if resource.WebhookToken != "" {
+		resource.WebhookToken = RedactedValue
+	}
+
+	return resource
+}
+
+func redactResourceType(resourceType atc.ResourceType) atc.ResourceType {
+	resourceType.Source = redactSource(resourceType.Source)
+	return resourceType
+}
+
+func redactJob(job atc.JobConfig) atc.JobConfig {
+	if job.Plan != nil {
+		plan := make(atc.PlanSequence, len(job.Plan))
+		for i, step := range job.Plan {
+			plan[i] = redactPlan(step)
+		}
+
+		job.Plan = plan
+	}
+
+	job.Failure = redactPlanPointer(job.Failure)
+	job.Ensure = redactPlanPointer(job.Ensure)
+	job.Success = redactPlanPointer(job.Success)
+	job.Error = redactPlanPointer(job.Error)
+	job.Abort = redactPlanPointer(job.Abort)
+
+	return job
+}
+
+// redactPlan copies the step, redacting the image resource source of any
+// inline task config within it.
+func redactPlan(plan atc.PlanConfig) atc.PlanConfig {
+	plan.Do = redactPlanSequence(plan.Do)
+	plan.Aggregate = redactPlanSequence(plan.Aggregate)
+
+	if plan.InParallel != nil {
+		inParallel := *plan.InParallel
+		inParallel.Steps = *redactPlanSequence(&inParallel.Steps)
+		plan.InParallel = &inParallel
+	}
+
+	if plan.TaskConfig != nil {
+		taskConfig := *plan.TaskConfig
+
+		if taskConfig.ImageResource != nil {
+			imageResource := *taskConfig.ImageResource
+			imageResource.Source = redactSource(imageResource.Source)
+			taskConfig.ImageResource = &imageResource
+		}
+
+		plan.TaskConfig = &taskConfig
+	}
+
+	plan.Failure = redactPlanPointer(plan.Failure)
+	plan.Ensure = redactPlanPointer(plan.Ensure)
+	plan.Success = redactPlanPointer(plan.Success)
+	plan.Error = redactPlanPointer(plan.Error)
+	plan.Abort = redactPlanPointer(plan.Abort)
+	plan.Try = redactPlanPointer(plan.Try)
+
+	return plan
+}
+
+func redactPlanSequence(plans *atc.PlanSequence) *atc.PlanSequence {
+	if plans == nil {
+		return nil
+	}
+
+	redacted := make(atc.PlanSequence, len(*plans))
+	for i, plan := range *plans {
+		redacted[i] = redactPlan(plan)
+	}
+
+	return &redacted
+}
+
+func redactPlanPointer(plan *atc.PlanConfig) *atc.PlanConfig {
+	if plan == nil {
+		return nil
+	}
+
+	redacted := redactPlan(*plan)
+	return &redacted
+}
@@ -0,0 +1,161 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var before atc.Config
+
+	BeforeEach(func() {
+		before = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+				{Name: "old-group", Jobs: []string{"some-job"}},
+			},
+			Resources: atc.ResourceConfigs{
+				{
+					Name:         "some-resource",
+					Type:         "git",
+					Source:       atc.Source{"uri": "some-uri", "private_key": "some-key"},
+					WebhookToken: "some-token",
+				},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image", Source: atc.Source{"repository": "some-repo"}},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource"},
+					},
+				},
+			},
+		}
+	})
+
+	It("is empty when nothing changed", func() {
+		Expect(Diff(before, before)).To(Equal(atc.ConfigDiff{
+			Groups:        []atc.ConfigChange{},
+			Resources:     []atc.ConfigChange{},
+			ResourceTypes: []atc.ConfigChange{},
+			Jobs:          []atc.ConfigChange{},
+		}))
+	})
+
+	It("reports added, changed, and removed groups", func() {
+		after := before
+		after.Groups = atc.GroupConfigs{
+			{Name: "some-group", Jobs: []string{"some-job", "other-job"}},
+			{Name: "new-group", Jobs: []string{"other-job"}},
+		}
+
+		Expect(Diff(before, after).Groups).To(Equal([]atc.ConfigChange{
+			{
+				Name:   "some-group",
+				Change: atc.ConfigChangeChanged,
+				Before: atc.GroupConfig{Name: "some-group", Jobs: []string{"some-job"}},
+				After:  atc.GroupConfig{Name: "some-group", Jobs: []string{"some-job", "other-job"}},
+			},
+			{
+				Name:   "new-group",
+				Change: atc.ConfigChangeAdded,
+				After:  atc.GroupConfig{Name: "new-group", Jobs: []string{"other-job"}},
+			},
+			{
+				Name:   "old-group",
+				Change: atc.ConfigChangeRemoved,
+				Before: atc.GroupConfig{Name: "old-group", Jobs: []string{"some-job"}},
+			},
+		}))
+	})
+
+	It("reports a change to only a resource's source, with the source redacted", func() {
+		after := before
+		after.Resources = atc.ResourceConfigs{
+			{
+				Name:         "some-resource",
+				Type:         "git",
+				Source:       atc.Source{"uri": "some-uri", "private_key": "other-key"},
+				WebhookToken: "some-token",
+			},
+		}
+
+		redacted := atc.ResourceConfig{
+			Name:         "some-resource",
+			Type:         "git",
+			Source:       atc.Source{"uri": RedactedValue, "private_key": RedactedValue},
+			WebhookToken: RedactedValue,
+		}
+
+		Expect(Diff(before, after).Resources).To(Equal([]atc.ConfigChange{
+			{
+				Name:   "some-resource",
+				Change: atc.ConfigChangeChanged,
+				Before: redacted,
+				After:  redacted,
+			},
+		}))
+	})
+
+	It("redacts the sources of added and removed resource types", func() {
+		after := before
+		after.ResourceTypes = atc.ResourceTypes{
+			{Name: "other-type", Type: "docker-image", Source: atc.Source{"repository": "other-repo"}},
+		}
+
+		Expect(Diff(before, after).ResourceTypes).To(Equal([]atc.ConfigChange{
+			{
+				Name:   "other-type",
+				Change: atc.ConfigChangeAdded,
+				After:  atc.ResourceType{Name: "other-type", Type: "docker-image", Source: atc.Source{"repository": RedactedValue}},
+			},
+			{
+				Name:   "some-type",
+				Change: atc.ConfigChangeRemoved,
+				Before: atc.ResourceType{Name: "some-type", Type: "docker-image", Source: atc.Source{"repository": RedactedValue}},
+			},
+		}))
+	})
+
+	It("redacts the image resources of inline task configs in jobs, without modifying the given config", func() {
+		taskConfig := &atc.TaskConfig{
+			Platform: "linux",
+			ImageResource: &atc.ImageResource{
+				Type:   "docker-image",
+				Source: atc.Source{"password": "some-password"},
+			},
+		}
+
+		after := before
+		after.Jobs = atc.JobConfigs{
+			{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource"},
+					{
+						Do: &atc.PlanSequence{
+							{Task: "some-task", TaskConfig: taskConfig},
+						},
+					},
+				},
+			},
+		}
+
+		diff := Diff(before, after)
+		Expect(diff.Jobs).To(HaveLen(1))
+		Expect(diff.Jobs[0].Change).To(Equal(atc.ConfigChangeChanged))
+		Expect(diff.Jobs[0].Before).To(Equal(before.Jobs[0]))
+
+		changedJob := diff.Jobs[0].After.(atc.JobConfig)
+		redactedTask := (*changedJob.Plan[1].Do)[0].TaskConfig
+		Expect(redactedTask.ImageResource.Source).To(Equal(atc.Source{"password": RedactedValue}))
+
+		Expect(taskConfig.ImageResource.Source).To(Equal(atc.Source{"password": "some-password"}))
+	})
+})
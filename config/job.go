@@ -1,6 +1,11 @@
 package config
 
-import "github.com/concourse/atc"
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/concourse/atc"
+)
 
 // these are expressly tucked away so as to avoid accidental use in public API
 // endpoints as that could leak credentials
@@ -26,6 +31,8 @@ func JobInputs(config atc.JobConfig) []JobInput {
 		Ensure:  config.Ensure,
 		Failure: config.Failure,
 		Success: config.Success,
+		Error:   config.Error,
+		Abort:   config.Abort,
 	})
 }
 
@@ -35,9 +42,89 @@ func JobOutputs(config atc.JobConfig) []JobOutput {
 		Ensure:  config.Ensure,
 		Failure: config.Failure,
 		Success: config.Success,
+		Error:   config.Error,
+		Abort:   config.Abort,
 	})
 }
 
+// ApplyResourcePins pins each input of a pinned resource to the resource's
+// pinned version, overriding any version configured on the get step.
+func ApplyResourcePins(inputs []JobInput, pinnedVersions map[string]atc.Version) []JobInput {
+	pinnedInputs := make([]JobInput, len(inputs))
+
+	for i, input := range inputs {
+		if version, found := pinnedVersions[input.Resource]; found {
+			input.Version = &atc.VersionConfig{Pinned: version}
+		}
+
+		pinnedInputs[i] = input
+	}
+
+	return pinnedInputs
+}
+
+// CrossPipelinePassed splits a passed constraint of the form
+// "other-pipeline/job" into the names of the pipeline and the job. It returns
+// false for constraints that don't name another pipeline; a job in the same
+// pipeline whose name contains a slash still takes precedence.
+func CrossPipelinePassed(passed string) (string, string, bool) {
+	segments := strings.SplitN(passed, "/", 2)
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", false
+	}
+
+	return segments[0], segments[1], true
+}
+
+// CrossPipelineResources returns the names of the resources which the job in
+// another pipeline gets or puts and which are configured the same as the given
+// resource, i.e. whose versions are the given resource's versions.
+func CrossPipelineResources(resource atc.ResourceConfig, otherConfig atc.Config, otherJob atc.JobConfig) []string {
+	resourceNames := []string{}
+
+	for _, input := range JobInputs(otherJob) {
+		resourceNames = append(resourceNames, input.Resource)
+	}
+
+	for _, output := range JobOutputs(otherJob) {
+		resourceNames = append(resourceNames, output.Resource)
+	}
+
+	matching := []string{}
+	seen := map[string]bool{}
+
+	for _, name := range resourceNames {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		otherResource, found := otherConfig.Resources.Lookup(name)
+		if found && otherResource.Type == resource.Type && sameSource(otherResource.Source, resource.Source) {
+			matching = append(matching, name)
+		}
+	}
+
+	return matching
+}
+
+// sources are compared by their JSON encoding, as configs decoded from YAML
+// and from JSON may hold the same values as different types
+func sameSource(a atc.Source, b atc.Source) bool {
+	aPayload, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bPayload, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return string(aPayload) == string(bPayload)
+}
+
 func collectInputs(plan atc.PlanConfig) []JobInput {
 	var inputs []JobInput
 
@@ -49,6 +136,14 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		inputs = append(inputs, collectInputs(*plan.Failure)...)
 	}
 
+	if plan.Error != nil {
+		inputs = append(inputs, collectInputs(*plan.Error)...)
+	}
+
+	if plan.Abort != nil {
+		inputs = append(inputs, collectInputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		inputs = append(inputs, collectInputs(*plan.Ensure)...)
 	}
@@ -69,6 +164,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -102,6 +203,14 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		outputs = append(outputs, collectOutputs(*plan.Failure)...)
 	}
 
+	if plan.Error != nil {
+		outputs = append(outputs, collectOutputs(*plan.Error)...)
+	}
+
+	if plan.Abort != nil {
+		outputs = append(outputs, collectOutputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		outputs = append(outputs, collectOutputs(*plan.Ensure)...)
 	}
@@ -122,6 +231,12 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+	}
+
 	if plan.Put != "" {
 		put := plan.Put
 
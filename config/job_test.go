@@ -176,6 +176,40 @@ var _ = Describe("Job config", func() {
 				})
 			})
 
+			Context("when a job has error and abort hooks", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							Get: "a",
+							Abort: &atc.PlanConfig{
+								Get: "c",
+							},
+						},
+					}
+
+					jobConfig.Error = &atc.PlanConfig{
+						Get: "b",
+					}
+				})
+
+				It("returns an input config for all get plans", func() {
+					Expect(inputs).To(ConsistOf(
+						config.JobInput{
+							Name:     "a",
+							Resource: "a",
+						},
+						config.JobInput{
+							Name:     "b",
+							Resource: "b",
+						},
+						config.JobInput{
+							Name:     "c",
+							Resource: "c",
+						},
+					))
+				})
+			})
+
 			Context("when a plan has an ensure hook on a get", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
@@ -617,4 +651,38 @@ var _ = Describe("Job config", func() {
 			})
 		})
 	})
+
+	Describe("ApplyResourcePins", func() {
+		It("pins inputs of pinned resources to the resource's version", func() {
+			inputs := []config.JobInput{
+				{
+					Name:     "a",
+					Resource: "some-resource",
+					Version:  &atc.VersionConfig{Every: true},
+				},
+				{
+					Name:     "b",
+					Resource: "other-resource",
+				},
+			}
+
+			pinnedInputs := config.ApplyResourcePins(inputs, map[string]atc.Version{
+				"some-resource": {"ref": "abc"},
+			})
+
+			Expect(pinnedInputs).To(Equal([]config.JobInput{
+				{
+					Name:     "a",
+					Resource: "some-resource",
+					Version:  &atc.VersionConfig{Pinned: atc.Version{"ref": "abc"}},
+				},
+				{
+					Name:     "b",
+					Resource: "other-resource",
+				},
+			}))
+
+			Expect(inputs[0].Version).To(Equal(&atc.VersionConfig{Every: true}))
+		})
+	})
 })
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/condition"
+	"github.com/concourse/atc/cron"
 )
 
 func formatErr(groupName string, err error) string {
@@ -61,6 +63,68 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 	return warnings, errorMessages
 }
 
+// ValidateCrossPipelinePassed validates the passed constraints of the
+// pipeline's jobs that refer to jobs in other pipelines of the team, given the
+// configs of the team's pipelines by name.
+func ValidateCrossPipelinePassed(c atc.Config, pipelineName string, teamPipelines map[string]atc.Config) []string {
+	errorMessages := []string{}
+
+	for _, job := range c.Jobs {
+		for _, input := range JobInputs(job) {
+			identifier := fmt.Sprintf("jobs.%s.get.%s.passed", job.Name, input.Name)
+
+			resource, _ := c.Resources.Lookup(input.Resource)
+
+			for _, passed := range input.Passed {
+				if _, found := c.Jobs.Lookup(passed); found {
+					continue
+				}
+
+				otherPipelineName, otherJobName, isCrossPipeline := CrossPipelinePassed(passed)
+				if !isCrossPipeline {
+					continue
+				}
+
+				if otherPipelineName == pipelineName {
+					errorMessages = append(errorMessages, fmt.Sprintf("%s references a job in its own pipeline ('%s')", identifier, passed))
+					continue
+				}
+
+				otherConfig, found := teamPipelines[otherPipelineName]
+				if !found {
+					errorMessages = append(errorMessages, fmt.Sprintf("%s references an unknown pipeline ('%s')", identifier, otherPipelineName))
+					continue
+				}
+
+				otherJob, found := otherConfig.Jobs.Lookup(otherJobName)
+				if !found {
+					errorMessages = append(errorMessages, fmt.Sprintf("%s references an unknown job ('%s')", identifier, passed))
+					continue
+				}
+
+				if len(CrossPipelineResources(resource, otherConfig, otherJob)) == 0 {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s references a job ('%s') which doesn't interact with a resource configured the same as '%s'",
+							identifier,
+							passed,
+							input.Resource,
+						),
+					)
+				}
+			}
+		}
+	}
+
+	err := compositeErr(errorMessages)
+	if err != nil {
+		return []string{formatErr("jobs", err)}
+	}
+
+	return nil
+}
+
 func validateGroups(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -114,6 +178,13 @@ func validateResources(c atc.Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckEvery != "" && resource.CheckEvery != atc.CheckEveryNever {
+			_, err := time.ParseDuration(resource.CheckEvery)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+" has invalid check_every: "+err.Error())
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -218,6 +289,22 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
+		for j, trigger := range job.Triggers {
+			triggerIdentifier := fmt.Sprintf("%s.triggers[%d]", identifier, j)
+
+			_, err := cron.Parse(trigger.Cron)
+			if err != nil {
+				errorMessages = append(errorMessages, triggerIdentifier+" has an invalid cron expression: "+err.Error())
+			}
+
+			if trigger.Timezone != "" {
+				_, err := time.LoadLocation(trigger.Timezone)
+				if err != nil {
+					errorMessages = append(errorMessages, triggerIdentifier+" has an unknown timezone: "+trigger.Timezone)
+				}
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -281,6 +368,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -309,14 +400,29 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if plan.InParallel.Limit < 0 {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.limit", identifier)
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid limit (%d)", plan.InParallel.Limit))
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "artifacts"},
 			plan, identifier)...,
 		)
 
+		errorMessages = append(errorMessages, validateContainerLimits(identifier, plan.ContainerLimits)...)
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -345,6 +451,12 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
+				if _, _, isCrossPipeline := CrossPipelinePassed(job); isCrossPipeline {
+					// validated against the team's other pipelines by
+					// ValidateCrossPipelinePassed
+					continue
+				}
+
 				errorMessages = append(
 					errorMessages,
 					fmt.Sprintf(
@@ -388,10 +500,12 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "artifacts"},
 			plan, identifier)...,
 		)
 
+		errorMessages = append(errorMessages, validateContainerLimits(identifier, plan.ContainerLimits)...)
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -436,10 +550,16 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "container_limits"},
 			plan, identifier)...,
 		)
 
+		if plan.TaskConfig != nil {
+			errorMessages = append(errorMessages, validateContainerLimits(identifier+".config", plan.TaskConfig.ContainerLimits)...)
+		}
+
+		errorMessages = append(errorMessages, validateArtifacts(identifier, plan)...)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -468,6 +588,20 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, planErrMessages...)
 	}
 
+	if plan.Error != nil {
+		subIdentifier := fmt.Sprintf("%s.error", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Error)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Abort)
+		warnings = append(warnings, planWarnings...)
+		errorMessages = append(errorMessages, planErrMessages...)
+	}
+
 	if plan.Timeout != "" {
 		_, err := time.ParseDuration(plan.Timeout)
 		if err != nil {
@@ -476,11 +610,14 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		}
 	}
 
-	if plan.Attempts < 0 {
-		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
-		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
+	if plan.Attempts != nil {
+		errorMessages = append(errorMessages, validateAttempts(identifier, *plan.Attempts)...)
 	}
 
+	errorMessages = append(errorMessages, validateAcross(identifier, plan)...)
+
+	errorMessages = append(errorMessages, validateCondition(identifier, plan)...)
+
 	return warnings, errorMessages
 }
 
@@ -514,6 +651,14 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "container_limits":
+			if plan.ContainerLimits != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "artifacts":
+			if len(plan.Artifacts) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -531,6 +676,119 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 	return errorMessages
 }
 
+func validateContainerLimits(identifier string, limits *atc.ContainerLimits) []string {
+	if limits == nil {
+		return nil
+	}
+
+	errorMessages := []string{}
+
+	for _, message := range limits.Validate() {
+		errorMessages = append(errorMessages, identifier+"."+message)
+	}
+
+	return errorMessages
+}
+
+func validateAttempts(identifier string, attempts atc.AttemptsConfig) []string {
+	errorMessages := []string{}
+
+	subIdentifier := fmt.Sprintf("%s.attempts", identifier)
+
+	if attempts.Count < 0 {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", attempts.Count))
+	}
+
+	if attempts.Backoff != "" {
+		_, err := time.ParseDuration(attempts.Backoff)
+		if err != nil {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".backoff refers to a duration that could not be parsed ('%s')", attempts.Backoff))
+		}
+	}
+
+	if attempts.MaxBackoff != "" {
+		_, err := time.ParseDuration(attempts.MaxBackoff)
+		if err != nil {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".max_backoff refers to a duration that could not be parsed ('%s')", attempts.MaxBackoff))
+		}
+	}
+
+	for _, condition := range attempts.On {
+		if condition != atc.RetryOnFailed && condition != atc.RetryOnErrored {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".on has an unknown outcome ('%s')", condition))
+		}
+	}
+
+	return errorMessages
+}
+
+func validateAcross(identifier string, plan atc.PlanConfig) []string {
+	if len(plan.Across) == 0 {
+		return nil
+	}
+
+	if plan.Task == "" {
+		return []string{identifier + ".across can only be used with a task"}
+	}
+
+	errorMessages := []string{}
+
+	vars := map[string]bool{}
+	for i, v := range plan.Across {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if v.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var")
+		} else if vars[v.Var] {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has a duplicate var ('%s')", v.Var))
+		}
+
+		vars[v.Var] = true
+
+		if len(v.Values) == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" has no values")
+		}
+	}
+
+	return errorMessages
+}
+
+func validateArtifacts(identifier string, plan atc.PlanConfig) []string {
+	errorMessages := []string{}
+
+	// the task's outputs are only known up front when its config is inlined
+	var outputNames map[string]bool
+	if plan.TaskConfig != nil && plan.TaskConfigPath == "" {
+		outputNames = map[string]bool{}
+
+		for _, output := range plan.TaskConfig.Outputs {
+			name := output.Name
+			if mapped, ok := plan.OutputMapping[name]; ok {
+				name = mapped
+			}
+
+			outputNames[name] = true
+		}
+	}
+
+	seen := map[string]bool{}
+
+	for _, name := range plan.Artifacts {
+		switch {
+		case name == "" || strings.Contains(name, "/"):
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.artifacts has an invalid name ('%s')", identifier, name))
+		case seen[name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.artifacts lists '%s' more than once", identifier, name))
+		case outputNames != nil && !outputNames[name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.artifacts references an unknown output ('%s')", identifier, name))
+		}
+
+		seen[name] = true
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -538,3 +796,57 @@ func compositeErr(errorMessages []string) error {
 
 	return errors.New(strings.Join(errorMessages, "\n"))
 }
+
+var conditionBuildVariables = map[string]bool{
+	"build.id":                 true,
+	"build.name":               true,
+	"build.job_name":           true,
+	"build.pipeline_name":      true,
+	"build.team_name":          true,
+	"build.manually_triggered": true,
+}
+
+func validateCondition(identifier string, plan atc.PlanConfig) []string {
+	if plan.If == "" {
+		return nil
+	}
+
+	subIdentifier := fmt.Sprintf("%s.if", identifier)
+
+	expression, err := condition.Parse(plan.If)
+	if err != nil {
+		return []string{subIdentifier + fmt.Sprintf(" could not be parsed (%s)", err)}
+	}
+
+	errorMessages := []string{}
+
+	for _, reference := range expression.References() {
+		if !validConditionReference(reference) {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" refers to an unknown variable ('%s')", reference))
+		}
+	}
+
+	return errorMessages
+}
+
+func validConditionReference(reference string) bool {
+	fields := strings.Split(reference, ".")
+
+	switch fields[0] {
+	case "build":
+		return conditionBuildVariables[reference]
+
+	case "params":
+		return len(fields) == 2 && fields[1] != ""
+
+	case "inputs":
+		switch {
+		case len(fields) == 3:
+			return fields[1] != "" && fields[2] == "triggered"
+		case len(fields) == 4:
+			return fields[1] != "" && (fields[2] == "version" || fields[2] == "metadata") && fields[3] != ""
+		}
+	}
+
+	return false
+}
@@ -167,6 +167,28 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has invalid check_every: time: invalid duration bogus"))
+			})
+		})
+
+		Context("when a resource is never checked periodically", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "never"
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -411,6 +433,46 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has valid triggers", func() {
+			BeforeEach(func() {
+				job.Triggers = []atc.TriggerConfig{
+					{Cron: "0 2 * * *"},
+					{Cron: "*/15 9-17 * * 1-5", Timezone: "Europe/London"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has a trigger with an invalid cron expression", func() {
+			BeforeEach(func() {
+				job.Triggers = []atc.TriggerConfig{{Cron: "0 2 * *"}}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.triggers[0] has an invalid cron expression"))
+			})
+		})
+
+		Context("when a job has a trigger with an unknown timezone", func() {
+			BeforeEach(func() {
+				job.Triggers = []atc.TriggerConfig{{Cron: "0 2 * * *", Timezone: "Nowhere/Special"}}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.triggers[0] has an unknown timezone: Nowhere/Special"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -518,6 +580,110 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has container limits specified outside its config", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:            "lol",
+						TaskConfigPath:  "task.yml",
+						ContainerLimits: &atc.ContainerLimits{CPU: 512},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has invalid fields specified (container_limits)"))
+				})
+			})
+
+			Context("when a task plan's config has invalid container limits", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task: "lol",
+						TaskConfig: &atc.TaskConfig{
+							ContainerLimits: &atc.ContainerLimits{CPU: 1},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.config.container_limits.cpu must be at least 2 shares"))
+				})
+			})
+
+			Context("when a get plan has invalid container limits", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:             "some-resource",
+						ContainerLimits: &atc.ContainerLimits{Memory: 1024},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.container_limits.memory must be at least 4194304 bytes"))
+				})
+			})
+
+			Context("when a task plan retains artifacts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:          "lol",
+						OutputMapping: map[string]string{"out": "mapped-out"},
+						TaskConfig: &atc.TaskConfig{
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "out"},
+								{Name: "other-out"},
+							},
+						},
+						Artifacts: []string{"mapped-out", "other-out"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+
+				Context("when an artifact is not one of the task's outputs", func() {
+					BeforeEach(func() {
+						config.Jobs[len(config.Jobs)-1].Plan[0].Artifacts = []string{"out", "other-out", "other-out"}
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.artifacts references an unknown output ('out')"))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.artifacts lists 'other-out' more than once"))
+					})
+				})
+			})
+
+			Context("when a get plan retains artifacts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:       "some-resource",
+						Artifacts: []string{"some-resource"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (artifacts)"))
+				})
+			})
+
 			Context("when a task plan has neither a config or a path set", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -859,6 +1025,44 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an error", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Error: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.error.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when a plan has an invalid step within an abort", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Abort: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.abort.put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
 			Context("when a plan has an invalid timeout in a step", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -897,7 +1101,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
 						Put:      "some-resource",
-						Attempts: -1,
+						Attempts: &atc.AttemptsConfig{Count: -1},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -909,6 +1113,178 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has an invalid policy", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Attempts: &atc.AttemptsConfig{
+							Count:      5,
+							Backoff:    "nope",
+							MaxBackoff: "2m",
+							On:         []atc.RetryCondition{"errored", "bored"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.backoff refers to a duration that could not be parsed ('nope')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.on has an unknown outcome ('bored')"))
+					Expect(errorMessages[0]).NotTo(ContainSubstring("max_backoff"))
+				})
+			})
+
+			Context("when a task is run across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "go_version", Values: []string{"1.7", "1.8"}},
+							{Var: "platform", Values: []string{"linux"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when a task is run across vars with no name or values, or repeated vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some/config/path.yml",
+						Across: []atc.AcrossVarConfig{
+							{Var: "", Values: []string{"1.7"}},
+							{Var: "platform"},
+							{Var: "platform", Values: []string{"linux"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[0] has no var"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[1] has no values"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.across[2] has a duplicate var ('platform')"))
+				})
+			})
+
+			Context("when a step other than a task is run across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						Across: []atc.AcrossVarConfig{
+							{Var: "platform", Values: []string{"linux"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across can only be used with a task"))
+				})
+			})
+
+			Context("when a step has a condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						If:  `!build.manually_triggered && (inputs.some-input.triggered || inputs.some-input.version.ref == "abc" || params.platform != "linux")`,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
+			Context("when a step has a condition that cannot be parsed", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						If:  "build.manually_triggered &&",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if could not be parsed (unexpected end of condition)"))
+				})
+			})
+
+			Context("when a step has a condition that refers to unknown variables", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put: "some-resource",
+						If:  "build.bogus || inputs.some-input.bogus.ref == 42 || params || commit",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if refers to an unknown variable ('build.bogus')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if refers to an unknown variable ('inputs.some-input.bogus.ref')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if refers to an unknown variable ('params')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if refers to an unknown variable ('commit')"))
+				})
+			})
+
+			Context("when an in_parallel plan has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{Put: "some-resource"},
+							},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit has an invalid limit (-1)"))
+				})
+			})
+
+			Context("when an in_parallel plan has a step that refers to a resource that does not exist", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{Get: "some-missing-resource"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0].get.some-missing-resource refers to a resource that does not exist"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -941,6 +1317,22 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:      "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"other-pipeline/other-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("leaves it to be validated against the team's pipelines", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
@@ -1021,3 +1413,138 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidateCrossPipelinePassed", func() {
+	var (
+		config        atc.Config
+		teamPipelines map[string]atc.Config
+
+		errorMessages []string
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git"},
+			},
+
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:      "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"other-pipeline/other-job"},
+						},
+					},
+				},
+			},
+		}
+
+		teamPipelines = map[string]atc.Config{
+			"other-pipeline": {
+				Resources: atc.ResourceConfigs{
+					{Name: "other-resource", Type: "git"},
+					{Name: "some-time", Type: "time"},
+				},
+
+				Jobs: atc.JobConfigs{
+					{
+						Name: "other-job",
+						Plan: atc.PlanSequence{
+							{Get: "other-resource"},
+						},
+					},
+					{
+						Name: "timed-job",
+						Plan: atc.PlanSequence{
+							{Get: "some-time"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		errorMessages = ValidateCrossPipelinePassed(config, "some-pipeline", teamPipelines)
+	})
+
+	Context("when the job interacts with a resource configured the same", func() {
+		It("returns no errors", func() {
+			Expect(errorMessages).To(BeEmpty())
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			delete(teamPipelines, "other-pipeline")
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.get.some-input.passed references an unknown pipeline ('other-pipeline')"))
+		})
+	})
+
+	Context("when the pipeline is the pipeline itself", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"some-pipeline/other-job"}
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.get.some-input.passed references a job in its own pipeline ('some-pipeline/other-job')"))
+		})
+	})
+
+	Context("when the job does not exist", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"other-pipeline/bogus-job"}
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.get.some-input.passed references an unknown job ('other-pipeline/bogus-job')"))
+		})
+	})
+
+	Context("when the job does not interact with a resource of the same type", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"other-pipeline/timed-job"}
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.get.some-input.passed references a job ('other-pipeline/timed-job') which doesn't interact with a resource configured the same as 'some-resource'"))
+		})
+	})
+
+	Context("when the job only interacts with a resource of the same type with a different source", func() {
+		BeforeEach(func() {
+			config.Resources[0].Source = atc.Source{"uri": "some-uri"}
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.get.some-input.passed references a job ('other-pipeline/other-job') which doesn't interact with a resource configured the same as 'some-resource'"))
+		})
+	})
+
+	Context("when a job in the same pipeline has a name containing a slash", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"other-pipeline/bogus-job"}
+			config.Jobs = append(config.Jobs, atc.JobConfig{
+				Name: "other-pipeline/bogus-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource"},
+				},
+			})
+		})
+
+		It("refers to that job", func() {
+			Expect(errorMessages).To(BeEmpty())
+		})
+	})
+})
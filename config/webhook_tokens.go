@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+// RedactWebhookTokens replaces the webhook tokens of the config's resources,
+// so that showing the config to whoever can read it doesn't let them trigger
+// checks of its resources.
+func RedactWebhookTokens(c atc.Config) atc.Config {
+	if c.Resources == nil {
+		return c
+	}
+
+	resources := make(atc.ResourceConfigs, len(c.Resources))
+	for i, resource := range c.Resources {
+		if resource.WebhookToken != "" {
+			resource.WebhookToken = RedactedValue
+		}
+
+		resources[i] = resource
+	}
+
+	c.Resources = resources
+
+	return c
+}
+
+// RedactRawWebhookTokens replaces the webhook tokens of a config as it was
+// saved, which may not decode as a config. If it has no webhook tokens, or is
+// not even a JSON object, it is returned as it is.
+func RedactRawWebhookTokens(raw atc.RawConfig) atc.RawConfig {
+	var payload map[string]interface{}
+	err := json.Unmarshal([]byte(raw), &payload)
+	if err != nil {
+		return raw
+	}
+
+	resources, ok := payload["resources"].([]interface{})
+	if !ok {
+		return raw
+	}
+
+	redactedAny := false
+	for _, resource := range resources {
+		resourceMap, ok := resource.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if token, ok := resourceMap["webhook_token"].(string); ok && token != "" {
+			resourceMap["webhook_token"] = RedactedValue
+			redactedAny = true
+		}
+	}
+
+	if !redactedAny {
+		return raw
+	}
+
+	redacted, err := json.Marshal(payload)
+	if err != nil {
+		return raw
+	}
+
+	return atc.RawConfig(redacted)
+}
+
+// HasRedactedWebhookTokens reports whether any of the config's resources has
+// a webhook token that was redacted, e.g. in a config that was fetched,
+// edited, and is now being saved again.
+func HasRedactedWebhookTokens(c atc.Config) bool {
+	for _, resource := range c.Resources {
+		if resource.WebhookToken == RedactedValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RestoreWebhookTokens gives each resource whose webhook token was redacted
+// the token of the saved resource of the same name. The tokens of resources
+// which are new are left redacted.
+func RestoreWebhookTokens(c atc.Config, saved atc.Config) atc.Config {
+	if !HasRedactedWebhookTokens(c) {
+		return c
+	}
+
+	resources := make(atc.ResourceConfigs, len(c.Resources))
+	for i, resource := range c.Resources {
+		if resource.WebhookToken == RedactedValue {
+			if savedResource, found := saved.Resources.Lookup(resource.Name); found {
+				resource.WebhookToken = savedResource.WebhookToken
+			}
+		}
+
+		resources[i] = resource
+	}
+
+	c.Resources = resources
+
+	return c
+}
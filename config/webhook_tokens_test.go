@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook tokens", func() {
+	var pipelineConfig atc.Config
+
+	BeforeEach(func() {
+		pipelineConfig = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git", WebhookToken: "some-token"},
+				{Name: "other-resource", Type: "git"},
+			},
+		}
+	})
+
+	Describe("RedactWebhookTokens", func() {
+		It("redacts the tokens that are set", func() {
+			redacted := RedactWebhookTokens(pipelineConfig)
+			Expect(redacted.Resources[0].WebhookToken).To(Equal(RedactedValue))
+			Expect(redacted.Resources[1].WebhookToken).To(BeEmpty())
+		})
+
+		It("does not modify the given config", func() {
+			RedactWebhookTokens(pipelineConfig)
+			Expect(pipelineConfig.Resources[0].WebhookToken).To(Equal("some-token"))
+		})
+	})
+
+	Describe("RedactRawWebhookTokens", func() {
+		It("redacts the tokens that are set", func() {
+			raw := atc.RawConfig(`{"resources":[{"name":"some-resource","webhook_token":"some-token"},{"name":"other-resource"}]}`)
+			Expect(RedactRawWebhookTokens(raw)).To(MatchJSON(`{"resources":[{"name":"some-resource","webhook_token":"((redacted))"},{"name":"other-resource"}]}`))
+		})
+
+		It("returns a config without tokens as it is", func() {
+			raw := atc.RawConfig(`{"resources": [{"name": "some-resource"}]}`)
+			Expect(RedactRawWebhookTokens(raw)).To(Equal(raw))
+		})
+
+		It("returns a config that is not JSON as it is", func() {
+			raw := atc.RawConfig("raw-config")
+			Expect(RedactRawWebhookTokens(raw)).To(Equal(raw))
+		})
+	})
+
+	Describe("RestoreWebhookTokens", func() {
+		var newConfig atc.Config
+
+		BeforeEach(func() {
+			newConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git", WebhookToken: RedactedValue},
+					{Name: "other-resource", Type: "git", WebhookToken: "new-token"},
+					{Name: "new-resource", Type: "git", WebhookToken: RedactedValue},
+				},
+			}
+		})
+
+		It("restores the redacted tokens of saved resources", func() {
+			restored := RestoreWebhookTokens(newConfig, pipelineConfig)
+			Expect(restored.Resources[0].WebhookToken).To(Equal("some-token"))
+		})
+
+		It("keeps tokens which were changed", func() {
+			restored := RestoreWebhookTokens(newConfig, pipelineConfig)
+			Expect(restored.Resources[1].WebhookToken).To(Equal("new-token"))
+		})
+
+		It("leaves the tokens of new resources redacted", func() {
+			restored := RestoreWebhookTokens(newConfig, pipelineConfig)
+			Expect(restored.Resources[2].WebhookToken).To(Equal(RedactedValue))
+		})
+	})
+})
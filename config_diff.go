@@ -0,0 +1,27 @@
+package atc
+
+type ConfigChangeType string
+
+const (
+	ConfigChangeAdded   ConfigChangeType = "added"
+	ConfigChangeRemoved ConfigChangeType = "removed"
+	ConfigChangeChanged ConfigChangeType = "changed"
+)
+
+// ConfigDiff is what saving a config would change about a pipeline, keyed by
+// the names of its groups, resources, resource types, and jobs.
+type ConfigDiff struct {
+	Groups        []ConfigChange `json:"groups"`
+	Resources     []ConfigChange `json:"resources"`
+	ResourceTypes []ConfigChange `json:"resource_types"`
+	Jobs          []ConfigChange `json:"jobs"`
+}
+
+// ConfigChange is a single added, removed, or changed entry of a config.
+// Before and After hold the entry's config with any source redacted.
+type ConfigChange struct {
+	Name   string           `json:"name"`
+	Change ConfigChangeType `json:"change"`
+	Before interface{}      `json:"before,omitempty"`
+	After  interface{}      `json:"after,omitempty"`
+}
@@ -1,7 +1,10 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	. "github.com/concourse/atc"
+	"gopkg.in/yaml.v2"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -100,4 +103,57 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("AttemptsConfig", func() {
+		Context("when given a number", func() {
+			It("is the count, from JSON", func() {
+				var config PlanConfig
+				err := json.Unmarshal([]byte(`{"task":"some-task","attempts":3}`), &config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Attempts).To(Equal(&AttemptsConfig{Count: 3}))
+			})
+
+			It("is the count, from YAML", func() {
+				var config PlanConfig
+				err := yaml.Unmarshal([]byte("task: some-task\nattempts: 3\n"), &config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Attempts).To(Equal(&AttemptsConfig{Count: 3}))
+			})
+
+			It("marshals back to a number", func() {
+				payload, err := json.Marshal(PlanConfig{Task: "some-task", Attempts: &AttemptsConfig{Count: 3}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{"task":"some-task","attempts":3}`))
+			})
+		})
+
+		Context("when given a policy", func() {
+			expected := &AttemptsConfig{
+				Count:      5,
+				Backoff:    "10s",
+				MaxBackoff: "2m",
+				On:         []RetryCondition{RetryOnErrored},
+			}
+
+			It("is the policy, from JSON", func() {
+				var config PlanConfig
+				err := json.Unmarshal([]byte(`{"put":"some-resource","attempts":{"count":5,"backoff":"10s","max_backoff":"2m","on":["errored"]}}`), &config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Attempts).To(Equal(expected))
+			})
+
+			It("is the policy, from YAML", func() {
+				var config PlanConfig
+				err := yaml.Unmarshal([]byte("put: some-resource\nattempts: {count: 5, backoff: 10s, max_backoff: 2m, on: [errored]}\n"), &config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Attempts).To(Equal(expected))
+			})
+
+			It("marshals back to the policy", func() {
+				payload, err := json.Marshal(PlanConfig{Put: "some-resource", Attempts: expected})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{"put":"some-resource","attempts":{"count":5,"backoff":"10s","max_backoff":"2m","on":["errored"]}}`))
+			})
+		})
+	})
 })
@@ -0,0 +1,17 @@
+package atc
+
+// PipelineConfigVersion is a config that was saved for a pipeline, and who
+// saved it.
+type PipelineConfigVersion struct {
+	Version   int    `json:"version"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type PipelineConfigVersionResponse struct {
+	PipelineConfigVersion
+
+	Config    *Config   `json:"config"`
+	Errors    []string  `json:"errors,omitempty"`
+	RawConfig RawConfig `json:"raw_config"`
+}
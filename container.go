@@ -16,4 +16,6 @@ type Container struct {
 	EnvironmentVariables []string `json:"env_variables,omitempty"`
 	Attempts             []int    `json:"attempt,omitempty"`
 	User                 string   `json:"user,omitempty"`
+
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
 }
@@ -0,0 +1,36 @@
+package atc
+
+import "fmt"
+
+// the smallest limits that a container can sensibly be started with
+const (
+	MinContainerCPUShares   = 2
+	MinContainerMemoryBytes = 4 * 1024 * 1024
+)
+
+// ContainerLimits constrains the resources available to a step's container.
+// Zero values leave the worker's defaults in place.
+type ContainerLimits struct {
+	// CPU shares, weighted against the other containers on the worker.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory limit, in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+
+	// Disk limit, in bytes.
+	Disk uint64 `json:"disk,omitempty" yaml:"disk,omitempty" mapstructure:"disk"`
+}
+
+func (limits ContainerLimits) Validate() []string {
+	messages := []string{}
+
+	if limits.CPU != 0 && limits.CPU < MinContainerCPUShares {
+		messages = append(messages, fmt.Sprintf("container_limits.cpu must be at least %d shares", MinContainerCPUShares))
+	}
+
+	if limits.Memory != 0 && limits.Memory < MinContainerMemoryBytes {
+		messages = append(messages, fmt.Sprintf("container_limits.memory must be at least %d bytes", MinContainerMemoryBytes))
+	}
+
+	return messages
+}
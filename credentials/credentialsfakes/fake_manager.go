@@ -0,0 +1,78 @@
+// This file was generated by counterfeiter
+package credentialsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/credentials"
+)
+
+type FakeManager struct {
+	VariablesStub        func(teamName string, pipelineName string) credentials.Variables
+	variablesMutex       sync.RWMutex
+	variablesArgsForCall []struct {
+		teamName     string
+		pipelineName string
+	}
+	variablesReturns struct {
+		result1 credentials.Variables
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeManager) Variables(teamName string, pipelineName string) credentials.Variables {
+	fake.variablesMutex.Lock()
+	fake.variablesArgsForCall = append(fake.variablesArgsForCall, struct {
+		teamName     string
+		pipelineName string
+	}{teamName, pipelineName})
+	fake.recordInvocation("Variables", []interface{}{teamName, pipelineName})
+	fake.variablesMutex.Unlock()
+	if fake.VariablesStub != nil {
+		return fake.VariablesStub(teamName, pipelineName)
+	} else {
+		return fake.variablesReturns.result1
+	}
+}
+
+func (fake *FakeManager) VariablesCallCount() int {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return len(fake.variablesArgsForCall)
+}
+
+func (fake *FakeManager) VariablesArgsForCall(i int) (string, string) {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return fake.variablesArgsForCall[i].teamName, fake.variablesArgsForCall[i].pipelineName
+}
+
+func (fake *FakeManager) VariablesReturns(result1 credentials.Variables) {
+	fake.VariablesStub = nil
+	fake.variablesReturns = struct {
+		result1 credentials.Variables
+	}{result1}
+}
+
+func (fake *FakeManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ credentials.Manager = new(FakeManager)
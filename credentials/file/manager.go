@@ -0,0 +1,95 @@
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/atc/credentials"
+	"gopkg.in/yaml.v2"
+)
+
+type credentialsFile struct {
+	Teams map[string]teamCredentials `yaml:"teams"`
+}
+
+type teamCredentials struct {
+	Vars      map[string]interface{}            `yaml:"vars"`
+	Pipelines map[string]map[string]interface{} `yaml:"pipelines"`
+}
+
+// Manager serves variables from a YAML file of the form:
+//
+//	teams:
+//	  some-team:
+//	    vars: {some-var: some-value}
+//	    pipelines:
+//	      some-pipeline: {some-var: pipeline-specific-value}
+//
+// Pipeline-scoped variables take precedence over team-scoped ones.
+type Manager struct {
+	teams map[string]teamCredentials
+}
+
+func NewManager(path string) (*Manager, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %s", err)
+	}
+
+	var file credentialsFile
+	err = yaml.Unmarshal(payload, &file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %s", err)
+	}
+
+	return &Manager{teams: file.Teams}, nil
+}
+
+func (manager *Manager) Variables(teamName string, pipelineName string) credentials.Variables {
+	return variables{
+		team:         manager.teams[teamName],
+		pipelineName: pipelineName,
+	}
+}
+
+type variables struct {
+	team         teamCredentials
+	pipelineName string
+}
+
+func (vars variables) Get(name string) (interface{}, bool, error) {
+	if val, found := vars.team.Pipelines[vars.pipelineName][name]; found {
+		return sanitize(val), true, nil
+	}
+
+	if val, found := vars.team.Vars[name]; found {
+		return sanitize(val), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// sanitize converts the map[interface{}]interface{} values produced by the
+// YAML parser into map[string]interface{} so that they can be marshaled as
+// JSON when given to resources.
+func sanitize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		sanitized := map[string]interface{}{}
+		for key, subVal := range v {
+			sanitized[fmt.Sprintf("%v", key)] = sanitize(subVal)
+		}
+
+		return sanitized
+
+	case []interface{}:
+		sanitized := []interface{}{}
+		for _, subVal := range v {
+			sanitized = append(sanitized, sanitize(subVal))
+		}
+
+		return sanitized
+	}
+
+	return val
+}
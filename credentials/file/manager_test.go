@@ -0,0 +1,85 @@
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/credentials/file"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		credsFile *os.File
+		manager   *file.Manager
+	)
+
+	BeforeEach(func() {
+		var err error
+		credsFile, err = ioutil.TempFile("", "credentials")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = credsFile.WriteString(`---
+teams:
+  some-team:
+    vars:
+      some-var: team-value
+      other-var: other-value
+      some-map:
+        nested: value
+    pipelines:
+      some-pipeline:
+        some-var: pipeline-value
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credsFile.Close()).To(Succeed())
+
+		manager, err = file.NewManager(credsFile.Name())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(credsFile.Name())).To(Succeed())
+	})
+
+	It("prefers pipeline-scoped variables", func() {
+		val, found, err := manager.Variables("some-team", "some-pipeline").Get("some-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("pipeline-value"))
+	})
+
+	It("falls back to team-scoped variables", func() {
+		val, found, err := manager.Variables("some-team", "some-pipeline").Get("other-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("other-value"))
+
+		val, found, err = manager.Variables("some-team", "other-pipeline").Get("some-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("team-value"))
+	})
+
+	It("returns maps with string keys", func() {
+		val, found, err := manager.Variables("some-team", "some-pipeline").Get("some-map")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal(map[string]interface{}{"nested": "value"}))
+	})
+
+	It("does not expose variables to other teams", func() {
+		_, found, err := manager.Variables("other-team", "some-pipeline").Get("some-var")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the file does not exist", func() {
+		It("returns an error", func() {
+			_, err := file.NewManager("/does/not/exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,244 @@
+package credentials
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . Manager
+
+// A Manager provides the variables visible to a given pipeline.
+type Manager interface {
+	Variables(teamName string, pipelineName string) Variables
+}
+
+//go:generate counterfeiter . Variables
+
+type Variables interface {
+	Get(name string) (interface{}, bool, error)
+}
+
+// VariablesFor returns nil Variables when no manager is configured, in which
+// case evaluation leaves all values untouched.
+func VariablesFor(manager Manager, teamName string, pipelineName string) Variables {
+	if manager == nil {
+		return nil
+	}
+
+	return manager.Variables(teamName, pipelineName)
+}
+
+type UndefinedVariablesError struct {
+	Names []string
+}
+
+func (err UndefinedVariablesError) Error() string {
+	return fmt.Sprintf("undefined variables: %s", strings.Join(err.Names, ", "))
+}
+
+type NonStringVariableError struct {
+	Name string
+}
+
+func (err NonStringVariableError) Error() string {
+	return fmt.Sprintf("variable '%s' must be a string to be interpolated into a larger string", err.Name)
+}
+
+var variablePattern = regexp.MustCompile(`\(\(([-/\.\w]+)\)\)`)
+
+func EvaluateSource(variables Variables, source atc.Source) (atc.Source, error) {
+	if variables == nil || source == nil {
+		return source, nil
+	}
+
+	evaluated, err := evaluate(variables, map[string]interface{}(source))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated.(map[string]interface{})), nil
+}
+
+func EvaluateParams(variables Variables, params atc.Params) (atc.Params, error) {
+	if variables == nil || params == nil {
+		return params, nil
+	}
+
+	evaluated, err := evaluate(variables, map[string]interface{}(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated.(map[string]interface{})), nil
+}
+
+// EvaluateString evaluates a lone configured string, such as a resource's
+// webhook token, which must remain a string once evaluated.
+func EvaluateString(variables Variables, str string) (string, error) {
+	if variables == nil {
+		return str, nil
+	}
+
+	evaluated, err := evaluate(variables, str)
+	if err != nil {
+		return "", err
+	}
+
+	evaluatedStr, ok := evaluated.(string)
+	if !ok {
+		return "", NonStringVariableError{Name: variablePattern.FindStringSubmatch(str)[1]}
+	}
+
+	return evaluatedStr, nil
+}
+
+func EvaluateResourceTypes(variables Variables, resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	if variables == nil || resourceTypes == nil {
+		return resourceTypes, nil
+	}
+
+	evaluated := atc.ResourceTypes{}
+	for _, resourceType := range resourceTypes {
+		source, err := EvaluateSource(variables, resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		evaluated = append(evaluated, resourceType)
+	}
+
+	return evaluated, nil
+}
+
+func evaluate(variables Variables, value interface{}) (interface{}, error) {
+	undefined := map[string]bool{}
+
+	evaluated, err := evaluateValue(variables, value, undefined)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(undefined) > 0 {
+		names := []string{}
+		for name := range undefined {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, UndefinedVariablesError{Names: names}
+	}
+
+	return evaluated, nil
+}
+
+func evaluateValue(variables Variables, value interface{}, undefined map[string]bool) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		evaluated := map[string]interface{}{}
+		for key, val := range v {
+			evaluatedVal, err := evaluateValue(variables, val, undefined)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = evaluatedVal
+		}
+
+		return evaluated, nil
+
+	case map[interface{}]interface{}:
+		evaluated := map[interface{}]interface{}{}
+		for key, val := range v {
+			evaluatedVal, err := evaluateValue(variables, val, undefined)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = evaluatedVal
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := []interface{}{}
+		for _, val := range v {
+			evaluatedVal, err := evaluateValue(variables, val, undefined)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated = append(evaluated, evaluatedVal)
+		}
+
+		return evaluated, nil
+
+	case string:
+		return evaluateString(variables, v, undefined)
+	}
+
+	return value, nil
+}
+
+func evaluateString(variables Variables, str string, undefined map[string]bool) (interface{}, error) {
+	matches := variablePattern.FindAllStringSubmatchIndex(str, -1)
+	if len(matches) == 0 {
+		return str, nil
+	}
+
+	// a string consisting solely of a variable takes on the variable's value,
+	// preserving its type
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(str) {
+		name := str[matches[0][2]:matches[0][3]]
+
+		val, found, err := variables.Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			undefined[name] = true
+			return str, nil
+		}
+
+		return val, nil
+	}
+
+	var evaluated string
+	var lastIndex int
+
+	for _, match := range matches {
+		name := str[match[2]:match[3]]
+
+		evaluated += str[lastIndex:match[0]]
+		lastIndex = match[1]
+
+		val, found, err := variables.Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			undefined[name] = true
+			continue
+		}
+
+		switch v := val.(type) {
+		case string:
+			evaluated += v
+		case int, int64, float64, bool:
+			evaluated += fmt.Sprintf("%v", v)
+		default:
+			return nil, NonStringVariableError{Name: name}
+		}
+	}
+
+	evaluated += str[lastIndex:]
+
+	return evaluated, nil
+}
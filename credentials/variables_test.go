@@ -0,0 +1,131 @@
+package credentials_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/credentialsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluating variables", func() {
+	var fakeVariables *credentialsfakes.FakeVariables
+
+	BeforeEach(func() {
+		fakeVariables = new(credentialsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			switch name {
+			case "private-key":
+				return "some-private-key", true, nil
+			case "port":
+				return 8080, true, nil
+			case "tags":
+				return []interface{}{"a", "b"}, true, nil
+			}
+
+			return nil, false, nil
+		}
+	})
+
+	Describe("EvaluateSource", func() {
+		It("replaces variables nested within the source", func() {
+			source, err := EvaluateSource(fakeVariables, atc.Source{
+				"uri":         "https://example.com:((port))/repo",
+				"private_key": "((private-key))",
+				"nested": map[string]interface{}{
+					"tags": "((tags))",
+					"list": []interface{}{"((private-key))", "literal"},
+				},
+				"count": 3,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(source).To(Equal(atc.Source{
+				"uri":         "https://example.com:8080/repo",
+				"private_key": "some-private-key",
+				"nested": map[string]interface{}{
+					"tags": []interface{}{"a", "b"},
+					"list": []interface{}{"some-private-key", "literal"},
+				},
+				"count": 3,
+			}))
+		})
+
+		It("reports every undefined variable", func() {
+			_, err := EvaluateSource(fakeVariables, atc.Source{
+				"a": "((missing-b))",
+				"b": "prefix-((missing-a))",
+			})
+			Expect(err).To(Equal(UndefinedVariablesError{Names: []string{"missing-a", "missing-b"}}))
+		})
+
+		It("refuses to interpolate non-string values into a larger string", func() {
+			_, err := EvaluateSource(fakeVariables, atc.Source{
+				"a": "prefix-((tags))",
+			})
+			Expect(err).To(Equal(NonStringVariableError{Name: "tags"}))
+		})
+
+		It("returns the error if looking up a variable fails", func() {
+			disaster := errors.New("nope")
+			fakeVariables.GetReturns(nil, false, disaster)
+			fakeVariables.GetStub = nil
+
+			_, err := EvaluateSource(fakeVariables, atc.Source{"a": "((private-key))"})
+			Expect(err).To(Equal(disaster))
+		})
+
+		It("leaves the source alone when no variables are configured", func() {
+			source, err := EvaluateSource(nil, atc.Source{"a": "((private-key))"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(Equal(atc.Source{"a": "((private-key))"}))
+		})
+	})
+
+	Describe("EvaluateParams", func() {
+		It("replaces variables in the params", func() {
+			params, err := EvaluateParams(fakeVariables, atc.Params{"key": "((private-key))"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(atc.Params{"key": "some-private-key"}))
+		})
+	})
+
+	Describe("EvaluateString", func() {
+		It("replaces variables in the string", func() {
+			str, err := EvaluateString(fakeVariables, "((private-key))")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(str).To(Equal("some-private-key"))
+		})
+
+		It("refuses variables that are not strings", func() {
+			_, err := EvaluateString(fakeVariables, "((port))")
+			Expect(err).To(Equal(NonStringVariableError{Name: "port"}))
+		})
+
+		It("reports undefined variables", func() {
+			_, err := EvaluateString(fakeVariables, "((missing))")
+			Expect(err).To(Equal(UndefinedVariablesError{Names: []string{"missing"}}))
+		})
+
+		It("leaves the string alone when no variables are configured", func() {
+			str, err := EvaluateString(nil, "((private-key))")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(str).To(Equal("((private-key))"))
+		})
+	})
+
+	Describe("EvaluateResourceTypes", func() {
+		It("replaces variables in each resource type's source", func() {
+			resourceTypes, err := EvaluateResourceTypes(fakeVariables, atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image", Source: atc.Source{"password": "((private-key))"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceTypes).To(Equal(atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image", Source: atc.Source{"password": "some-private-key"}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,162 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc/credentials"
+)
+
+// Manager looks up variables from a Vault-compatible HTTP API. Variables are
+// first looked up under PathPrefix/TEAM/PIPELINE/NAME, falling back to
+// PathPrefix/TEAM/NAME. A variable's name may not lead outside of its team's
+// path.
+type Manager struct {
+	URL        string
+	Token      string
+	PathPrefix string
+
+	Client *http.Client
+}
+
+// DefaultTimeout bounds each request made to Vault, so that an unresponsive
+// server fails the lookup rather than holding up whatever needed the variable.
+const DefaultTimeout = 30 * time.Second
+
+func NewManager(vaultURL string, token string, pathPrefix string) *Manager {
+	return &Manager{
+		URL:        vaultURL,
+		Token:      token,
+		PathPrefix: pathPrefix,
+
+		Client: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (manager *Manager) Variables(teamName string, pipelineName string) credentials.Variables {
+	return variables{
+		manager:      manager,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+type variables struct {
+	manager      *Manager
+	teamName     string
+	pipelineName string
+}
+
+func (vars variables) Get(name string) (interface{}, bool, error) {
+	if strings.HasPrefix(name, "/") {
+		return nil, false, InvalidVariableNameError{Name: name}
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return nil, false, InvalidVariableNameError{Name: name}
+		}
+	}
+
+	pipelinePath, err := vars.secretPath(vars.pipelineName, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	val, found, err := vars.manager.read(pipelinePath)
+	if err != nil || found {
+		return val, found, err
+	}
+
+	teamPath, err := vars.secretPath(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return vars.manager.read(teamPath)
+}
+
+func (vars variables) secretPath(segments ...string) (string, error) {
+	teamPath := path.Join(vars.manager.PathPrefix, vars.teamName)
+
+	secretPath := path.Join(append([]string{teamPath}, segments...)...)
+	if !strings.HasPrefix(secretPath, teamPath+"/") {
+		return "", InvalidVariableNameError{Name: segments[len(segments)-1]}
+	}
+
+	return secretPath, nil
+}
+
+type InvalidVariableNameError struct {
+	Name string
+}
+
+func (err InvalidVariableNameError) Error() string {
+	return fmt.Sprintf("variable '%s' does not refer to a path within the team's secrets", err.Name)
+}
+
+type secretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+type UnexpectedResponseError struct {
+	Path       string
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("unexpected response reading '%s' from vault: %d", err.Path, err.StatusCode)
+}
+
+func (manager *Manager) read(secretPath string) (interface{}, bool, error) {
+	secretURL, err := url.Parse(manager.URL)
+	if err != nil {
+		return nil, false, err
+	}
+
+	secretURL.Path = path.Join(secretURL.Path, "v1", secretPath)
+
+	request, err := http.NewRequest("GET", secretURL.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	request.Header.Set("X-Vault-Token", manager.Token)
+
+	response, err := manager.Client.Do(request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, false, UnexpectedResponseError{
+			Path:       secretPath,
+			StatusCode: response.StatusCode,
+		}
+	}
+
+	var secret secretResponse
+	err = json.NewDecoder(response.Body).Decode(&secret)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// secrets written as {value: ...} are unwrapped, otherwise the whole
+	// secret is given as a map
+	if val, found := secret.Data["value"]; found && len(secret.Data) == 1 {
+		return val, true, nil
+	}
+
+	return secret.Data, true, nil
+}
@@ -0,0 +1,158 @@
+package vault_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/vault"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		vaultServer *ghttp.Server
+		variables   credentials.Variables
+
+		name string
+
+		val   interface{}
+		found bool
+		err   error
+	)
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+
+		manager := vault.NewManager(vaultServer.URL(), "some-token", "/concourse")
+		variables = manager.Variables("some-team", "some-pipeline")
+
+		name = "some-var"
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		val, found, err = variables.Get(name)
+	})
+
+	Context("when the variable is scoped to the pipeline", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/some-var"),
+					ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{"value": "pipeline-value"},
+					}),
+				),
+			)
+		})
+
+		It("returns the unwrapped value", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+		})
+	})
+
+	Context("when the variable is only scoped to the team", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/some-var"),
+					ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-var"),
+					ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{"username": "admin", "password": "secret"},
+					}),
+				),
+			)
+		})
+
+		It("returns the whole secret", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{"username": "admin", "password": "secret"}))
+		})
+	})
+
+	Context("when the variable does not exist", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+				ghttp.RespondWith(http.StatusNotFound, `{"errors":[]}`),
+			)
+		})
+
+		It("is not found", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when vault responds with an unexpected status", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(Equal(vault.UnexpectedResponseError{
+				Path:       "/concourse/some-team/some-pipeline/some-var",
+				StatusCode: http.StatusForbidden,
+			}))
+		})
+	})
+
+	Context("when the variable's name leads into another team's secrets", func() {
+		BeforeEach(func() {
+			name = "../../other-team/secret"
+		})
+
+		It("returns an error without reading anything from vault", func() {
+			Expect(err).To(Equal(vault.InvalidVariableNameError{Name: "../../other-team/secret"}))
+			Expect(found).To(BeFalse())
+			Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when the variable's name is an absolute path", func() {
+		BeforeEach(func() {
+			name = "/other-team/secret"
+		})
+
+		It("returns an error without reading anything from vault", func() {
+			Expect(err).To(Equal(vault.InvalidVariableNameError{Name: "/other-team/secret"}))
+			Expect(vaultServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Context("when the variable's name is nested", func() {
+		BeforeEach(func() {
+			name = "some-dir/some-var"
+
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/concourse/some-team/some-pipeline/some-dir/some-var"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"data": map[string]interface{}{"value": "nested-value"},
+					}),
+				),
+			)
+		})
+
+		It("looks it up within the team's secrets", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("nested-value"))
+		})
+	})
+})
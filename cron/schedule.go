@@ -0,0 +1,209 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week.
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// as with cron, when both day fields are restricted a day matches if
+	// either of them does
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField     = field{"minute", 0, 59}
+	hourField       = field{"hour", 0, 23}
+	dayOfMonthField = field{"day of month", 1, 31}
+	monthField      = field{"month", 1, 12}
+	dayOfWeekField  = field{"day of week", 0, 7}
+)
+
+// searchLimit bounds how far ahead Next looks, so that expressions which can
+// never fire (e.g. "0 0 31 2 *") do not loop forever.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+func Parse(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var schedule Schedule
+	var err error
+
+	schedule.minutes, err = parseField(fields[0], minuteField)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.hours, err = parseField(fields[1], hourField)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.daysOfMonth, err = parseField(fields[2], dayOfMonthField)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.months, err = parseField(fields[3], monthField)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.daysOfWeek, err = parseField(fields[4], dayOfWeekField)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	// both 0 and 7 are sunday
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+
+	// a day field starting with * (e.g. */2) restricts the days only along
+	// with the other day field, rather than as an alternative to it
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// Next returns the first time after the given time at which the schedule
+// fires, in the given time's location. It returns the zero time if the
+// schedule never fires.
+func (s Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(searchLimit)
+
+	for t.Before(limit) {
+		if !s.matches(s.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matches(s.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matches(s.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) matches(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.matches(s.daysOfMonth, t.Day())
+	dayOfWeek := s.matches(s.daysOfWeek, int(t.Weekday()))
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+func parseField(spec string, f field) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec := part
+		step := 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			rangeSpec = part[:i]
+
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, part)
+			}
+		}
+
+		var start, end int
+
+		switch {
+		case rangeSpec == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rangeSpec, "-"):
+			bounds := strings.SplitN(rangeSpec, "-", 2)
+
+			var err error
+			start, err = parseValue(bounds[0], f)
+			if err != nil {
+				return 0, err
+			}
+
+			end, err = parseValue(bounds[1], f)
+			if err != nil {
+				return 0, err
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("invalid range in %s field: %s", f.name, part)
+			}
+		default:
+			var err error
+			start, err = parseValue(rangeSpec, f)
+			if err != nil {
+				return 0, err
+			}
+
+			end = start
+			if step > 1 {
+				end = f.max
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(spec string, f field) (int, error) {
+	value, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s field: %s", f.name, spec)
+	}
+
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d: %d", f.name, f.min, f.max, value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,81 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/cron"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	// a wednesday
+	now := time.Date(2017, time.March, 15, 10, 30, 45, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(spec string, expected time.Time) {
+			schedule, err := cron.Parse(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(now)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2017, time.March, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("later the same day", "0 14 * * *", time.Date(2017, time.March, 15, 14, 0, 0, 0, time.UTC)),
+		Entry("the next day", "0 2 * * *", time.Date(2017, time.March, 16, 2, 0, 0, 0, time.UTC)),
+		Entry("with a step", "*/20 * * * *", time.Date(2017, time.March, 15, 10, 40, 0, 0, time.UTC)),
+		Entry("with a list", "15,45 * * * *", time.Date(2017, time.March, 15, 10, 45, 0, 0, time.UTC)),
+		Entry("with a range", "0 9-17 * * 1-5", time.Date(2017, time.March, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("on a day of the week", "0 0 * * 0", time.Date(2017, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("with 7 as sunday", "0 0 * * 7", time.Date(2017, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("in another month", "0 0 1 6 *", time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("on either restricted day", "0 0 1 * 5", time.Date(2017, time.March, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("on a stepped day of the month that is also the day of the week", "0 0 */2 * 1", time.Date(2017, time.March, 27, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 31 2 *", time.Time{}),
+	)
+
+	DescribeTable("Parse errors",
+		func(spec string) {
+			_, err := cron.Parse(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("not a number", "a * * * *"),
+		Entry("backwards range", "* 5-1 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+	)
+
+	Describe("NextTrigger", func() {
+		It("returns the earliest fire time across the triggers, in their timezones", func() {
+			next, found, err := cron.NextTrigger([]atc.TriggerConfig{
+				{Cron: "0 14 * * *"},
+				{Cron: "0 12 * * *", Timezone: "America/New_York"},
+			}, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(next.UTC()).To(Equal(time.Date(2017, time.March, 15, 14, 0, 0, 0, time.UTC)))
+
+			next, found, err = cron.NextTrigger([]atc.TriggerConfig{
+				{Cron: "0 14 * * *"},
+				{Cron: "0 8 * * *", Timezone: "America/New_York"},
+			}, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(next.UTC()).To(Equal(time.Date(2017, time.March, 15, 12, 0, 0, 0, time.UTC)))
+		})
+
+		It("returns false when no trigger fires", func() {
+			_, found, err := cron.NextTrigger([]atc.TriggerConfig{{Cron: "0 0 31 2 *"}}, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns an error for an unknown timezone", func() {
+			_, _, err := cron.NextTrigger([]atc.TriggerConfig{{Cron: "* * * * *", Timezone: "Nowhere/Special"}}, now)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
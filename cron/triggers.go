@@ -0,0 +1,39 @@
+package cron
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// NextTrigger returns the earliest time after the given time at which any of
+// the triggers fires, and false if none of them ever fire.
+func NextTrigger(triggers []atc.TriggerConfig, after time.Time) (time.Time, bool, error) {
+	var next time.Time
+
+	for _, trigger := range triggers {
+		schedule, err := Parse(trigger.Cron)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		location := time.UTC
+		if trigger.Timezone != "" {
+			location, err = time.LoadLocation(trigger.Timezone)
+			if err != nil {
+				return time.Time{}, false, err
+			}
+		}
+
+		fireTime := schedule.Next(after.In(location))
+		if fireTime.IsZero() {
+			continue
+		}
+
+		if next.IsZero() || fireTime.Before(next) {
+			next = fireTime
+		}
+	}
+
+	return next, !next.IsZero(), nil
+}
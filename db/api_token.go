@@ -0,0 +1,73 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// APIToken is a long-lived token for a team. Only a hash of the token itself
+// is stored; the token is shown once, when it is created.
+type APIToken struct {
+	Name      string
+	Role      atc.TeamRole
+	TokenHash string
+}
+
+type SavedAPIToken struct {
+	ID     int
+	TeamID int
+
+	APIToken
+
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+const apiTokenColumns = "a.id, a.team_id, a.name, a.role, a.token_hash, a.created_at, a.last_used_at"
+
+func scanAPIToken(row scannable) (SavedAPIToken, error) {
+	var token SavedAPIToken
+	var role string
+	var lastUsedAt pq.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.TeamID,
+		&token.Name,
+		&role,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	token.Role = atc.TeamRole(role)
+
+	if lastUsedAt.Valid {
+		token.LastUsedAt = lastUsedAt.Time
+	}
+
+	return token, nil
+}
+
+func scanAPITokens(rows *sql.Rows) ([]SavedAPIToken, error) {
+	defer rows.Close()
+
+	tokens := []SavedAPIToken{}
+
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
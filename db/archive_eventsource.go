@@ -0,0 +1,76 @@
+package db
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/event"
+)
+
+func newArchiveBuildEventSource(blob io.ReadCloser, from uint) (*archiveBuildEventSource, error) {
+	reader, err := archive.NewEventReader(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveBuildEventSource{
+		reader: reader,
+		skip:   from,
+	}, nil
+}
+
+// archiveBuildEventSource replays the events of a build whose event stream
+// has been moved out of the database. The stream is complete, so it simply
+// ends rather than waiting for more events.
+type archiveBuildEventSource struct {
+	reader *archive.EventReader
+	skip   uint
+
+	closed bool
+	lock   sync.Mutex
+}
+
+func (source *archiveBuildEventSource) Next() (event.Envelope, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.closed {
+		return event.Envelope{}, ErrBuildEventStreamClosed
+	}
+
+	for ; source.skip > 0; source.skip-- {
+		_, err := source.reader.Read()
+		if err != nil {
+			return event.Envelope{}, source.translateErr(err)
+		}
+	}
+
+	ev, err := source.reader.Read()
+	if err != nil {
+		return event.Envelope{}, source.translateErr(err)
+	}
+
+	return ev, nil
+}
+
+func (source *archiveBuildEventSource) Close() error {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.closed {
+		return nil
+	}
+
+	source.closed = true
+
+	return source.reader.Close()
+}
+
+func (source *archiveBuildEventSource) translateErr(err error) error {
+	if err == io.EOF {
+		return ErrEndOfBuildEventStream
+	}
+
+	return err
+}
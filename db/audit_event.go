@@ -0,0 +1,22 @@
+package db
+
+import "time"
+
+// AuditEvent records a call to an API route that changes something.
+type AuditEvent struct {
+	ID        int
+	Route     string
+	User      string
+	Team      string
+	Target    string
+	Status    int
+	CreatedAt time.Time
+}
+
+// AuditEventFilter narrows down audit events to those matching all of its
+// non-empty fields.
+type AuditEventFilter struct {
+	Route string
+	User  string
+	Team  string
+}
@@ -4,12 +4,17 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/archive"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+	"github.com/tedsuo/rata"
 )
 
 type Status string
@@ -23,8 +28,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time, rerun_of"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.rerun_of, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -45,6 +50,7 @@ type Build interface {
 	IsScheduled() bool
 	IsRunning() bool
 	IsManuallyTriggered() bool
+	RerunOf() int
 
 	Reload() (bool, error)
 
@@ -72,6 +78,12 @@ type Build interface {
 	SaveImageResourceVersion(planID atc.PlanID, identifier ResourceCacheIdentifier) error
 	GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, error)
 
+	SaveTestResults(planID atc.PlanID, results []atc.TestResult) error
+	GetTestResults() ([]atc.TestResult, error)
+
+	SaveArtifact(name string) error
+	GetArtifacts() ([]BuildArtifact, error)
+
 	GetConfig() (atc.Config, ConfigVersion, error)
 
 	GetPipeline() (SavedPipeline, error)
@@ -91,6 +103,8 @@ type build struct {
 
 	isManuallyTriggered bool
 
+	rerunOf int
+
 	engine         string
 	engineMetadata string
 
@@ -101,7 +115,8 @@ type build struct {
 	conn Conn
 	bus  *notificationsBus
 
-	lockFactory LockFactory
+	lockFactory  LockFactory
+	eventArchive archive.Store
 }
 
 func (b *build) ID() int {
@@ -132,6 +147,12 @@ func (b *build) IsManuallyTriggered() bool {
 	return b.isManuallyTriggered
 }
 
+// RerunOf returns the ID of the build that this build re-runs with the same
+// inputs, or 0 if it is not a re-run.
+func (b *build) RerunOf() int {
+	return b.rerunOf
+}
+
 func (b *build) Engine() string {
 	return b.engine
 }
@@ -174,7 +195,7 @@ func (b *build) IsRunning() bool {
 }
 
 func (b *build) Reload() (bool, error) {
-	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory)
+	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory, b.eventArchive)
 	newBuild, found, err := buildFactory.ScanBuild(b.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
@@ -209,6 +230,27 @@ func (b *build) Reload() (bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.eventArchive != nil {
+		var archived bool
+		err := b.conn.QueryRow(`
+			SELECT events_archived
+			FROM builds
+			WHERE id = $1
+		`, b.id).Scan(&archived)
+		if err != nil {
+			return nil, err
+		}
+
+		if archived {
+			blob, err := b.eventArchive.Get(archive.BuildEventsKey(b.id))
+			if err != nil {
+				return nil, err
+			}
+
+			return newArchiveBuildEventSource(blob, from)
+		}
+	}
+
 	notifier, err := newConditionNotifier(b.bus, buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -263,6 +305,11 @@ func (b *build) Start(engine, metadata string) (bool, error) {
 		return false, err
 	}
 
+	err = b.queueWebhookDeliveries(tx, StatusStarted)
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -277,7 +324,14 @@ func (b *build) Start(engine, metadata string) (bool, error) {
 }
 
 func (b *build) Abort() error {
-	_, err := b.conn.Exec(`
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
    UPDATE builds
    SET status = 'aborted'
    WHERE id = $1
@@ -286,6 +340,16 @@ func (b *build) Abort() error {
 		return err
 	}
 
+	err = b.queueWebhookDeliveries(tx, StatusAborted)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
 	err = b.bus.Notify(buildAbortChannel(b.id))
 	if err != nil {
 		return err
@@ -335,6 +399,11 @@ func (b *build) Finish(status Status) error {
 		return err
 	}
 
+	err = b.queueWebhookDeliveries(tx, status)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -366,6 +435,68 @@ func (b *build) MarkAsFailed(cause error) error {
 	return b.Finish(StatusErrored)
 }
 
+// queueWebhookDeliveries records a pending delivery of the build's current
+// state to each of the team's webhooks. A webhook is only notified of a given
+// status once, so that an abort followed by the engine finishing the build as
+// aborted doesn't result in two deliveries.
+func (b *build) queueWebhookDeliveries(tx Tx, status Status) error {
+	var startTime, endTime pq.NullTime
+	err := tx.QueryRow(`
+		SELECT start_time, end_time
+		FROM builds
+		WHERE id = $1
+	`, b.id).Scan(&startTime, &endTime)
+	if err != nil {
+		return err
+	}
+
+	apiURL, err := atc.Routes.CreatePathForRoute(atc.GetBuild, rata.Params{
+		"build_id": strconv.Itoa(b.id),
+	})
+	if err != nil {
+		return err
+	}
+
+	payload := atc.Build{
+		ID:           b.id,
+		TeamName:     b.teamName,
+		Name:         b.name,
+		Status:       string(status),
+		JobName:      b.jobName,
+		PipelineName: b.pipelineName,
+		APIURL:       apiURL,
+	}
+
+	if startTime.Valid {
+		payload.StartTime = startTime.Time.Unix()
+	}
+
+	if endTime.Valid {
+		payload.EndTime = endTime.Time.Unix()
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, build_id, build_status, payload)
+		SELECT w.id, $1, $2, $3
+		FROM webhooks w
+		WHERE w.team_id = $4
+		AND NOT EXISTS (
+			SELECT 1
+			FROM webhook_deliveries d
+			WHERE d.webhook_id = w.id
+			AND d.build_id = $1
+			AND d.build_status = $2
+		)
+	`, b.id, string(status), string(payloadJSON), b.teamID)
+
+	return err
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -613,7 +744,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory)
+	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory, b.eventArchive)
 	tdb := tdbf.GetTeamDB(b.teamName)
 	savedPipeline, found, err := tdb.GetPipelineByName(b.pipelineName)
 	if err != nil {
@@ -624,7 +755,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.eventArchive)
 	pdb := pdbf.Build(savedPipeline)
 	if err != nil {
 		return BuildPreparation{}, false, err
@@ -635,9 +766,25 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	configInputs := config.JobInputs(jobConfig)
+	pinnedVersions, err := pdb.GetPinnedResourceVersions()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	configInputs := config.ApplyResourcePins(config.JobInputs(jobConfig), pinnedVersions)
+
+	var nextBuildInputs []BuildInput
+	if b.rerunOf != 0 {
+		// a re-run always uses the inputs of the build it re-runs
+		nextBuildInputs, _, err = b.GetResources()
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
 
-	nextBuildInputs, found, err := pdb.GetNextBuildInputs(jobName)
+		found = true
+	} else {
+		nextBuildInputs, found, err = pdb.GetNextBuildInputs(jobName)
+	}
 
 	inputsSatisfiedStatus := BuildPreparationStatusBlocking
 	inputs := map[string]BuildPreparationStatus{}
@@ -702,6 +849,17 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		}
 	}
 
+	queuePosition := 0
+
+	position, queued, err := getBuildQueuePosition(b.conn, b.id)
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	if queued {
+		queuePosition = position.Ahead + 1
+	}
+
 	buildPreparation := BuildPreparation{
 		BuildID:             b.id,
 		PausedPipeline:      pausedPipelineStatus,
@@ -710,6 +868,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+		QueuePosition:       queuePosition,
 	}
 
 	return buildPreparation, true, nil
@@ -728,7 +887,7 @@ func (b *build) SaveInput(input BuildInput) (SavedVersionedResource, error) {
 		return SavedVersionedResource{}, err
 	}
 
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.eventArchive)
 
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
@@ -747,7 +906,7 @@ func (b *build) SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedR
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
-	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
+	pipelineDBFactory := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory, b.eventArchive)
 	pipelineDB := pipelineDBFactory.Build(savedPipeline)
 
 	return pipelineDB.SaveOutput(b.id, vr, explicit)
@@ -833,6 +992,117 @@ func (b *build) GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, e
 	return identifiers, nil
 }
 
+// the number of rows inserted per statement, keeping well clear of postgres'
+// limit on bind parameters
+const testResultsBatchSize = 1000
+
+func (b *build) SaveTestResults(planID atc.PlanID, results []atc.TestResult) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for len(results) > 0 {
+		batch := results
+		if len(batch) > testResultsBatchSize {
+			batch = batch[:testResultsBatchSize]
+		}
+
+		results = results[len(batch):]
+
+		params := []interface{}{b.id, string(planID)}
+		values := []string{}
+
+		for _, result := range batch {
+			params = append(params, result.Suite, result.Name, string(result.Status), result.Duration)
+			n := len(params)
+			values = append(values, fmt.Sprintf("($1, $2, $%d, $%d, $%d, $%d)", n-3, n-2, n-1, n))
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO build_test_results (build_id, plan_id, suite, name, status, duration)
+			VALUES `+strings.Join(values, ", "), params...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) GetTestResults() ([]atc.TestResult, error) {
+	rows, err := b.conn.Query(`
+		SELECT plan_id, suite, name, status, duration
+		FROM build_test_results
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []atc.TestResult{}
+
+	for rows.Next() {
+		var result atc.TestResult
+		var planID, status string
+		err := rows.Scan(&planID, &result.Suite, &result.Name, &status, &result.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		result.PlanID = atc.PlanID(planID)
+		result.Status = atc.TestStatus(status)
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+func (b *build) SaveArtifact(name string) error {
+	_, err := b.conn.Exec(`
+		INSERT INTO build_artifacts (build_id, name)
+		SELECT $1, $2
+		WHERE NOT EXISTS (
+			SELECT 1 FROM build_artifacts WHERE build_id = $1 AND name = $2
+		)
+	`, b.id, name)
+	return err
+}
+
+func (b *build) GetArtifacts() ([]BuildArtifact, error) {
+	rows, err := b.conn.Query(`
+		SELECT name, created_at
+		FROM build_artifacts
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	artifacts := []BuildArtifact{}
+
+	for rows.Next() {
+		var artifact BuildArtifact
+		err := rows.Scan(&artifact.Name, &artifact.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, rows.Err()
+}
+
 func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (Lock, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -963,7 +1233,7 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	return nil
+	return indexBuildLog(tx, b.id, event)
 }
 
 func buildAbortChannel(buildID int) string {
@@ -0,0 +1,10 @@
+package db
+
+import "time"
+
+// BuildArtifact is an output of a build's task retained in the artifact
+// store after the build finished.
+type BuildArtifact struct {
+	Name      string
+	CreatedAt time.Time
+}
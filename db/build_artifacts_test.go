@@ -0,0 +1,82 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build artifacts", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		pipelineDB db.PipelineDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		pipeline, _, err := teamDBFactory.GetTeamDB(atc.DefaultTeamName).SaveConfig("some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDB = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil).Build(pipeline)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("records each retained artifact against the build once", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		otherBuild, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveArtifact("some-output")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveArtifact("other-output")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveArtifact("some-output")
+		Expect(err).NotTo(HaveOccurred())
+
+		artifacts, err := build.GetArtifacts()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(artifacts).To(HaveLen(2))
+		Expect(artifacts[0].Name).To(Equal("some-output"))
+		Expect(artifacts[0].CreatedAt).NotTo(BeZero())
+		Expect(artifacts[1].Name).To(Equal("other-output"))
+
+		Expect(otherBuild.GetArtifacts()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,177 @@
+package db_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/archive/local"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archived build events", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		archiveDir string
+		store      archive.Store
+
+		database *db.SQLDB
+		teamDB   db.TeamDB
+		build    db.Build
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		var err error
+		archiveDir, err = ioutil.TempDir("", "event-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = local.NewStore(archiveDir)
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		database = db.NewSQL(dbConn, bus, lockFactory, store)
+		_, err = database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDB = db.NewTeamDBFactory(dbConn, bus, lockFactory, store).GetTeamDB("some-team")
+
+		build, err = teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{Payload: "log 1"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{Payload: "log 2"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.Finish(db.StatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		os.RemoveAll(archiveDir)
+	})
+
+	archiveBuild := func() {
+		events, err := build.Events(0)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer events.Close()
+
+		buf := new(bytes.Buffer)
+		writer := archive.NewEventWriter(buf)
+
+		for {
+			ev, err := events.Next()
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Write(ev)).To(Succeed())
+		}
+
+		Expect(writer.Close()).To(Succeed())
+
+		err = store.Put(archive.BuildEventsKey(build.ID()), buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.ArchiveBuildEventsByBuildIDs([]int{build.ID()})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("deletes the build's events from the database without marking it as reaped", func() {
+		archiveBuild()
+
+		var count int
+		err := dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, build.ID()).Scan(&count)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+
+		found, err := build.Reload()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(build.ReapTime()).To(BeZero())
+	})
+
+	It("replays the build's events from the archive", func() {
+		archiveBuild()
+
+		reloaded, found, err := database.GetBuildByID(build.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		events, err := reloaded.Events(0)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer events.Close()
+
+		ev, err := events.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).To(Equal(envelope(event.Log{Payload: "log 1"})))
+
+		ev, err = events.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).To(Equal(envelope(event.Log{Payload: "log 2"})))
+
+		ev, err = events.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev.Event).To(Equal(event.Status{}.EventType()))
+
+		_, err = events.Next()
+		Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+	})
+
+	It("replays from the given event", func() {
+		archiveBuild()
+
+		events, err := build.Events(1)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer events.Close()
+
+		ev, err := events.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).To(Equal(envelope(event.Log{Payload: "log 2"})))
+	})
+
+	It("stops replaying once closed", func() {
+		archiveBuild()
+
+		events, err := build.Events(0)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = events.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = events.Next()
+		Expect(err).To(Equal(db.ErrBuildEventStreamClosed))
+	})
+})
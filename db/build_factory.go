@@ -3,14 +3,16 @@ package db
 import (
 	"database/sql"
 
+	"github.com/concourse/atc/archive"
 	"github.com/lib/pq"
 )
 
-func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory) *buildFactory {
+func newBuildFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory, eventArchive archive.Store) *buildFactory {
 	return &buildFactory{
-		conn:        conn,
-		lockFactory: lockFactory,
-		bus:         bus,
+		conn:         conn,
+		lockFactory:  lockFactory,
+		bus:          bus,
+		eventArchive: eventArchive,
 	}
 }
 
@@ -18,7 +20,8 @@ type buildFactory struct {
 	conn Conn
 	bus  *notificationsBus
 
-	lockFactory LockFactory
+	lockFactory  LockFactory
+	eventArchive archive.Store
 }
 
 func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
@@ -31,10 +34,11 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
+	var rerunOf sql.NullInt64
 	var teamName string
 	var isManuallyTriggered bool
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &rerunOf, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -44,9 +48,10 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	}
 
 	build := &build{
-		conn:        f.conn,
-		bus:         f.bus,
-		lockFactory: f.lockFactory,
+		conn:         f.conn,
+		bus:          f.bus,
+		lockFactory:  f.lockFactory,
+		eventArchive: f.eventArchive,
 
 		id:                  id,
 		name:                name,
@@ -61,6 +66,8 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		endTime:   endTime.Time,
 		reapTime:  reapTime.Time,
 
+		rerunOf: int(rerunOf.Int64),
+
 		teamName: teamName,
 	}
 
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+var ansiEscapePattern = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+// indexBuildLog records the lines of log events for searching. Lines are
+// indexed as they were chunked by the build, so a line which was split
+// across two events is indexed as two lines.
+func indexBuildLog(tx Tx, buildID int, ev atc.Event) error {
+	log, ok := ev.(event.Log)
+	if !ok {
+		return nil
+	}
+
+	params := []interface{}{buildID, string(log.Origin.ID)}
+	values := []string{}
+
+	for _, line := range strings.Split(log.Payload, "\n") {
+		line = strings.TrimRight(ansiEscapePattern.ReplaceAllString(line, ""), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		params = append(params, line)
+		values = append(values, "($1, $2, $"+strconv.Itoa(len(params))+")")
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO build_log_lines (build_id, origin_id, line)
+		VALUES `+strings.Join(values, ", "), params...)
+	return err
+}
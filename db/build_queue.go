@@ -0,0 +1,66 @@
+package db
+
+import "database/sql"
+
+// BuildQueuePosition describes where a pending build stands among the builds
+// waiting to start across every pipeline, which are ordered by the priority
+// of their job and then by age.
+type BuildQueuePosition struct {
+	Ahead     int
+	TeamAhead int
+
+	Running     int
+	TeamRunning int
+}
+
+func (db *SQLDB) GetBuildQueuePosition(buildID int) (BuildQueuePosition, bool, error) {
+	return getBuildQueuePosition(db.conn, buildID)
+}
+
+// a build is only queued once it could otherwise start: its inputs have been
+// determined, or are those of the build it re-runs, and neither its job nor
+// its pipeline is paused or held back by max_in_flight
+func getBuildQueuePosition(conn Conn, buildID int) (BuildQueuePosition, bool, error) {
+	var position BuildQueuePosition
+
+	err := conn.QueryRow(`
+		WITH queue AS (
+			SELECT b.id, b.team_id,
+				row_number() OVER (
+					ORDER BY COALESCE((j.config->>'priority')::int, 0) DESC, b.id ASC
+				) AS position,
+				row_number() OVER (
+					PARTITION BY b.team_id
+					ORDER BY COALESCE((j.config->>'priority')::int, 0) DESC, b.id ASC
+				) AS team_position
+			FROM builds b
+			INNER JOIN jobs j ON b.job_id = j.id
+			INNER JOIN pipelines p ON j.pipeline_id = p.id
+			WHERE b.status = 'pending'
+				AND b.scheduled = false
+				AND (j.inputs_determined = true OR b.rerun_of IS NOT NULL)
+				AND j.max_in_flight_reached = false
+				AND j.paused = false
+				AND p.paused = false
+		), running AS (
+			SELECT team_id
+			FROM builds
+			WHERE status = 'started'
+				OR (scheduled = true AND status = 'pending')
+		)
+		SELECT q.position - 1, q.team_position - 1,
+			(SELECT COUNT(*) FROM running),
+			(SELECT COUNT(*) FROM running r WHERE r.team_id = q.team_id)
+		FROM queue q
+		WHERE q.id = $1
+	`, buildID).Scan(&position.Ahead, &position.TeamAhead, &position.Running, &position.TeamRunning)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildQueuePosition{}, false, nil
+		}
+
+		return BuildQueuePosition{}, false, err
+	}
+
+	return position, true, nil
+}
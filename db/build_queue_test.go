@@ -0,0 +1,157 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build queue", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB               *db.SQLDB
+		pipelineDB          db.PipelineDB
+		otherPipelineDB     db.PipelineDB
+		otherTeamPipelineDB db.PipelineDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
+
+		savePipeline := func(teamName string, pipelineName string, config atc.Config) db.PipelineDB {
+			pipeline, _, err := teamDBFactory.GetTeamDB(teamName).SaveConfig(pipelineName, config, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			return pipelineDBFactory.Build(pipeline)
+		}
+
+		pipelineDB = savePipeline(atc.DefaultTeamName, "some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "urgent-job", Priority: 10},
+			},
+		})
+
+		otherPipelineDB = savePipeline(atc.DefaultTeamName, "other-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		})
+
+		otherTeamPipelineDB = savePipeline("other-team", "some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	createQueuedBuild := func(pipelineDB db.PipelineDB, jobName string) db.Build {
+		build, err := pipelineDB.CreateJobBuild(jobName)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = pipelineDB.SaveNextInputMapping(nil, jobName)
+		Expect(err).NotTo(HaveOccurred())
+
+		return build
+	}
+
+	It("orders pending builds across pipelines by priority and then age", func() {
+		oldest := createQueuedBuild(otherPipelineDB, "some-job")
+		otherTeams := createQueuedBuild(otherTeamPipelineDB, "some-job")
+		newest := createQueuedBuild(pipelineDB, "some-job")
+		urgent := createQueuedBuild(pipelineDB, "urgent-job")
+
+		position, found, err := sqlDB.GetBuildQueuePosition(urgent.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(position).To(Equal(db.BuildQueuePosition{Ahead: 0, TeamAhead: 0}))
+
+		position, found, err = sqlDB.GetBuildQueuePosition(oldest.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(position).To(Equal(db.BuildQueuePosition{Ahead: 1, TeamAhead: 1}))
+
+		position, found, err = sqlDB.GetBuildQueuePosition(otherTeams.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(position).To(Equal(db.BuildQueuePosition{Ahead: 2, TeamAhead: 0}))
+
+		position, found, err = sqlDB.GetBuildQueuePosition(newest.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(position).To(Equal(db.BuildQueuePosition{Ahead: 3, TeamAhead: 2}))
+	})
+
+	It("counts running builds globally and by team", func() {
+		running := createQueuedBuild(pipelineDB, "some-job")
+		_, err := running.Start("some-engine", "some-metadata")
+		Expect(err).NotTo(HaveOccurred())
+
+		otherTeamsRunning := createQueuedBuild(otherTeamPipelineDB, "some-job")
+		_, err = otherTeamsRunning.Start("some-engine", "some-metadata")
+		Expect(err).NotTo(HaveOccurred())
+
+		queued := createQueuedBuild(otherPipelineDB, "some-job")
+
+		position, found, err := sqlDB.GetBuildQueuePosition(queued.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(position.Running).To(Equal(2))
+		Expect(position.TeamRunning).To(Equal(1))
+	})
+
+	It("does not queue builds whose inputs have not been determined", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, found, err := sqlDB.GetBuildQueuePosition(build.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("does not queue builds of paused pipelines", func() {
+		build := createQueuedBuild(pipelineDB, "some-job")
+
+		err := pipelineDB.Pause()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, found, err := sqlDB.GetBuildQueuePosition(build.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
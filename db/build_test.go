@@ -41,7 +41,7 @@ var _ = Describe("Build", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
 		pipelineConfig = atc.Config{
@@ -73,7 +73,7 @@ var _ = Describe("Build", func() {
 		pipeline, _, err = teamDB.SaveConfig("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 		pipelineDB = pipelineDBFactory.Build(pipeline)
 	})
 
@@ -625,9 +625,11 @@ var _ = Describe("Build", func() {
 
 						err = pipelineDB.SetMaxInFlightReached("some-job", false)
 						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.QueuePosition = 1
 					})
 
-					It("returns build preparation with max in flight not reached", func() {
+					It("returns build preparation with max in flight not reached and the build's queue position", func() {
 						buildPrep, found, err := build.GetPreparation()
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
@@ -0,0 +1,144 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build test results", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		pipelineDB db.PipelineDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		pipeline, _, err := teamDBFactory.GetTeamDB(atc.DefaultTeamName).SaveConfig("some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "other-job"},
+			},
+		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDB = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil).Build(pipeline)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("saves the results of each step against the build", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveTestResults("unit-plan", []atc.TestResult{
+			{Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 0.5},
+			{Suite: "unit", Name: "breaks", Status: atc.TestStatusFailed, Duration: 1.25},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveTestResults("integration-plan", []atc.TestResult{
+			{Suite: "integration", Name: "talks to things", Status: atc.TestStatusSkipped},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(build.GetTestResults()).To(Equal([]atc.TestResult{
+			{PlanID: "unit-plan", Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 0.5},
+			{PlanID: "unit-plan", Suite: "unit", Name: "breaks", Status: atc.TestStatusFailed, Duration: 1.25},
+			{PlanID: "integration-plan", Suite: "integration", Name: "talks to things", Status: atc.TestStatusSkipped},
+		}))
+	})
+
+	It("saves reports larger than a single statement allows", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		results := make([]atc.TestResult, 12000)
+		for i := range results {
+			results[i] = atc.TestResult{Suite: "big", Name: "test", Status: atc.TestStatusPassed}
+		}
+
+		err = build.SaveTestResults("some-plan", results)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(build.GetTestResults()).To(HaveLen(12000))
+	})
+
+	It("returns the results of the job's latest builds with results, newest first", func() {
+		oldestBuild, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = oldestBuild.SaveTestResults("some-plan", []atc.TestResult{
+			{Suite: "unit", Name: "works", Status: atc.TestStatusFailed, Duration: 3},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		middleBuild, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = middleBuild.SaveTestResults("some-plan", []atc.TestResult{
+			{Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 2},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		otherJobBuild, err := pipelineDB.CreateJobBuild("other-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = otherJobBuild.SaveTestResults("some-plan", []atc.TestResult{
+			{Suite: "unit", Name: "elsewhere", Status: atc.TestStatusPassed},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(pipelineDB.GetJobTestRuns("some-job", 1)).To(Equal([]db.JobTestRun{
+			{
+				BuildID:   middleBuild.ID(),
+				BuildName: middleBuild.Name(),
+				Result:    atc.TestResult{PlanID: "some-plan", Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 2},
+			},
+		}))
+
+		Expect(pipelineDB.GetJobTestRuns("some-job", 10)).To(Equal([]db.JobTestRun{
+			{
+				BuildID:   middleBuild.ID(),
+				BuildName: middleBuild.Name(),
+				Result:    atc.TestResult{PlanID: "some-plan", Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 2},
+			},
+			{
+				BuildID:   oldestBuild.ID(),
+				BuildName: oldestBuild.Name(),
+				Result:    atc.TestResult{PlanID: "some-plan", Suite: "unit", Name: "works", Status: atc.TestStatusFailed, Duration: 3},
+			},
+		}))
+	})
+})
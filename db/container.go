@@ -51,6 +51,7 @@ type ContainerMetadata struct {
 	EnvironmentVariables []string
 	Attempts             []int
 	User                 string
+	ContainerLimits      atc.ContainerLimits
 }
 
 type Container struct {
@@ -86,6 +86,7 @@ type DB interface {
 	GetTaskLock(logger lager.Logger, taskName string) (Lock, bool, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	ArchiveBuildEventsByBuildIDs(buildIDs []int) error
 
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
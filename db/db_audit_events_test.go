@@ -0,0 +1,135 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("Audit events", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+	var sqlDB *db.SQLDB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	saveEvents := func(events ...db.AuditEvent) {
+		for _, event := range events {
+			err := sqlDB.SaveAuditEvent(event)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	withoutIDsAndTimes := func(events []db.AuditEvent) []db.AuditEvent {
+		stripped := []db.AuditEvent{}
+		for _, event := range events {
+			Expect(event.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			event.ID = 0
+			event.CreatedAt = time.Time{}
+			stripped = append(stripped, event)
+		}
+
+		return stripped
+	}
+
+	pausePipeline := db.AuditEvent{
+		Route:  atc.PausePipeline,
+		User:   "some-user",
+		Team:   "some-team",
+		Target: "/api/v1/teams/some-team/pipelines/some-pipeline/pause",
+		Status: 200,
+	}
+
+	hijackContainer := db.AuditEvent{
+		Route:  atc.HijackContainer,
+		User:   "other-user",
+		Team:   "some-team",
+		Target: "/api/v1/containers/some-handle/hijack",
+		Status: 101,
+	}
+
+	saveConfig := db.AuditEvent{
+		Route:  atc.SaveConfig,
+		User:   "some-user",
+		Team:   "other-team",
+		Target: "/api/v1/teams/other-team/pipelines/some-pipeline/config",
+		Status: 400,
+	}
+
+	It("returns saved events newest first", func() {
+		saveEvents(pausePipeline, hijackContainer, saveConfig)
+
+		events, pagination, err := sqlDB.GetAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{saveConfig, hijackContainer, pausePipeline}))
+		Expect(pagination).To(Equal(db.Pagination{}))
+	})
+
+	It("returns no events when there are none", func() {
+		events, _, err := sqlDB.GetAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+	})
+
+	It("filters events", func() {
+		saveEvents(pausePipeline, hijackContainer, saveConfig)
+
+		events, _, err := sqlDB.GetAuditEvents(db.AuditEventFilter{User: "some-user"}, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{saveConfig, pausePipeline}))
+
+		events, _, err = sqlDB.GetAuditEvents(db.AuditEventFilter{Team: "some-team", Route: atc.HijackContainer}, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{hijackContainer}))
+	})
+
+	It("paginates events", func() {
+		saveEvents(pausePipeline, hijackContainer, saveConfig)
+
+		events, pagination, err := sqlDB.GetAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{saveConfig, hijackContainer}))
+		Expect(pagination.Previous).To(BeNil())
+		Expect(pagination.Next).To(Equal(&db.Page{Since: events[1].ID, Limit: 2}))
+
+		events, pagination, err = sqlDB.GetAuditEvents(db.AuditEventFilter{}, *pagination.Next)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{pausePipeline}))
+		Expect(pagination.Previous).To(Equal(&db.Page{Until: events[0].ID, Limit: 2}))
+		Expect(pagination.Next).To(BeNil())
+
+		events, _, err = sqlDB.GetAuditEvents(db.AuditEventFilter{}, *pagination.Previous)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withoutIDsAndTimes(events)).To(Equal([]db.AuditEvent{saveConfig, hijackContainer}))
+	})
+})
@@ -42,7 +42,7 @@ var _ = Describe("Keeping track of containers", func() {
 
 		lockFactory := db.NewLockFactory(retryableConn)
 
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		config := atc.Config{
 			Jobs: atc.JobConfigs{
@@ -78,7 +78,7 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(err).NotTo(HaveOccurred())
 		teamID = savedTeam.ID
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB("team-name")
 
 		savedPipeline, _, err = teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
@@ -87,7 +87,7 @@ var _ = Describe("Keeping track of containers", func() {
 		_, _, err = teamDB.SaveConfig("some-other-pipeline", config, 0, db.PipelineUnpaused)
 		Expect(err).NotTo(HaveOccurred())
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
 
 		workerInfo := db.WorkerInfo{
@@ -33,7 +33,7 @@ var _ = Describe("Pipes", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		savedTeam, err = database.CreateTeam(db.Team{Name: "team-name"})
 		Expect(err).NotTo(HaveOccurred())
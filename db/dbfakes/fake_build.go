@@ -262,6 +262,43 @@ type FakeBuild struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	SaveTestResultsStub        func(planID atc.PlanID, results []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		planID  atc.PlanID
+		results []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	GetTestResultsStub        func() ([]atc.TestResult, error)
+	getTestResultsMutex       sync.RWMutex
+	getTestResultsArgsForCall []struct{}
+	getTestResultsReturns     struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	SaveArtifactStub        func(name string) error
+	saveArtifactMutex       sync.RWMutex
+	saveArtifactArgsForCall []struct {
+		name string
+	}
+	saveArtifactReturns struct {
+		result1 error
+	}
+	GetArtifactsStub        func() ([]db.BuildArtifact, error)
+	getArtifactsMutex       sync.RWMutex
+	getArtifactsArgsForCall []struct{}
+	getArtifactsReturns     struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1242,6 +1279,155 @@ func (fake *FakeBuild) GetPipelineReturns(result1 db.SavedPipeline, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	} else {
+		return fake.rerunOfReturns.result1
+	}
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestResults(planID atc.PlanID, results []atc.TestResult) error {
+	var resultsCopy []atc.TestResult
+	if results != nil {
+		resultsCopy = make([]atc.TestResult, len(results))
+		copy(resultsCopy, results)
+	}
+	fake.saveTestResultsMutex.Lock()
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		planID  atc.PlanID
+		results []atc.TestResult
+	}{planID, resultsCopy})
+	fake.recordInvocation("SaveTestResults", []interface{}{planID, resultsCopy})
+	fake.saveTestResultsMutex.Unlock()
+	if fake.SaveTestResultsStub != nil {
+		return fake.SaveTestResultsStub(planID, results)
+	} else {
+		return fake.saveTestResultsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestResultsArgsForCall(i int) (atc.PlanID, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return fake.saveTestResultsArgsForCall[i].planID, fake.saveTestResultsArgsForCall[i].results
+}
+
+func (fake *FakeBuild) SaveTestResultsReturns(result1 error) {
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetTestResults() ([]atc.TestResult, error) {
+	fake.getTestResultsMutex.Lock()
+	fake.getTestResultsArgsForCall = append(fake.getTestResultsArgsForCall, struct{}{})
+	fake.recordInvocation("GetTestResults", []interface{}{})
+	fake.getTestResultsMutex.Unlock()
+	if fake.GetTestResultsStub != nil {
+		return fake.GetTestResultsStub()
+	} else {
+		return fake.getTestResultsReturns.result1, fake.getTestResultsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetTestResultsCallCount() int {
+	fake.getTestResultsMutex.RLock()
+	defer fake.getTestResultsMutex.RUnlock()
+	return len(fake.getTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) GetTestResultsReturns(result1 []atc.TestResult, result2 error) {
+	fake.GetTestResultsStub = nil
+	fake.getTestResultsReturns = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveArtifact(name string) error {
+	fake.saveArtifactMutex.Lock()
+	fake.saveArtifactArgsForCall = append(fake.saveArtifactArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("SaveArtifact", []interface{}{name})
+	fake.saveArtifactMutex.Unlock()
+	if fake.SaveArtifactStub != nil {
+		return fake.SaveArtifactStub(name)
+	} else {
+		return fake.saveArtifactReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveArtifactCallCount() int {
+	fake.saveArtifactMutex.RLock()
+	defer fake.saveArtifactMutex.RUnlock()
+	return len(fake.saveArtifactArgsForCall)
+}
+
+func (fake *FakeBuild) SaveArtifactArgsForCall(i int) string {
+	fake.saveArtifactMutex.RLock()
+	defer fake.saveArtifactMutex.RUnlock()
+	return fake.saveArtifactArgsForCall[i].name
+}
+
+func (fake *FakeBuild) SaveArtifactReturns(result1 error) {
+	fake.SaveArtifactStub = nil
+	fake.saveArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetArtifacts() ([]db.BuildArtifact, error) {
+	fake.getArtifactsMutex.Lock()
+	fake.getArtifactsArgsForCall = append(fake.getArtifactsArgsForCall, struct{}{})
+	fake.recordInvocation("GetArtifacts", []interface{}{})
+	fake.getArtifactsMutex.Unlock()
+	if fake.GetArtifactsStub != nil {
+		return fake.GetArtifactsStub()
+	} else {
+		return fake.getArtifactsReturns.result1, fake.getArtifactsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetArtifactsCallCount() int {
+	fake.getArtifactsMutex.RLock()
+	defer fake.getArtifactsMutex.RUnlock()
+	return len(fake.getArtifactsArgsForCall)
+}
+
+func (fake *FakeBuild) GetArtifactsReturns(result1 []db.BuildArtifact, result2 error) {
+	fake.GetArtifactsStub = nil
+	fake.getArtifactsReturns = struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1315,6 +1501,16 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.getPipelineMutex.RLock()
 	defer fake.getPipelineMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	fake.getTestResultsMutex.RLock()
+	defer fake.getTestResultsMutex.RUnlock()
+	fake.saveArtifactMutex.RLock()
+	defer fake.saveArtifactMutex.RUnlock()
+	fake.getArtifactsMutex.RLock()
+	defer fake.getArtifactsMutex.RUnlock()
 	return fake.invocations
 }
 
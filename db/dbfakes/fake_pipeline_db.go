@@ -544,6 +544,75 @@ type FakePipelineDB struct {
 	hideReturns     struct {
 		result1 error
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	PinResourceVersionStub        func(resourceName string, versionedResourceID int) (bool, error)
+	pinResourceVersionMutex       sync.RWMutex
+	pinResourceVersionArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	pinResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	UnpinResourceStub        func(resourceName string) error
+	unpinResourceMutex       sync.RWMutex
+	unpinResourceArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceReturns struct {
+		result1 error
+	}
+	GetPinnedResourceVersionsStub        func() (map[string]atc.Version, error)
+	getPinnedResourceVersionsMutex       sync.RWMutex
+	getPinnedResourceVersionsArgsForCall []struct{}
+	getPinnedResourceVersionsReturns     struct {
+		result1 map[string]atc.Version
+		result2 error
+	}
+	GetJobTriggersEvaluatedAtStub        func(jobName string) (time.Time, bool, error)
+	getJobTriggersEvaluatedAtMutex       sync.RWMutex
+	getJobTriggersEvaluatedAtArgsForCall []struct {
+		jobName string
+	}
+	getJobTriggersEvaluatedAtReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	SetJobTriggersEvaluatedAtStub        func(jobName string, evaluatedAt time.Time) error
+	setJobTriggersEvaluatedAtMutex       sync.RWMutex
+	setJobTriggersEvaluatedAtArgsForCall []struct {
+		jobName     string
+		evaluatedAt time.Time
+	}
+	setJobTriggersEvaluatedAtReturns struct {
+		result1 error
+	}
+	RerunJobBuildStub        func(originalBuild db.Build) (db.Build, error)
+	rerunJobBuildMutex       sync.RWMutex
+	rerunJobBuildArgsForCall []struct {
+		originalBuild db.Build
+	}
+	rerunJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	GetJobTestRunsStub        func(job string, builds int) ([]db.JobTestRun, error)
+	getJobTestRunsMutex       sync.RWMutex
+	getJobTestRunsArgsForCall []struct {
+		job    string
+		builds int
+	}
+	getJobTestRunsReturns struct {
+		result1 []db.JobTestRun
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2536,6 +2605,263 @@ func (fake *FakePipelineDB) HideReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipelineDB) PinResourceVersion(resourceName string, versionedResourceID int) (bool, error) {
+	fake.pinResourceVersionMutex.Lock()
+	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.recordInvocation("PinResourceVersion", []interface{}{resourceName, versionedResourceID})
+	fake.pinResourceVersionMutex.Unlock()
+	if fake.PinResourceVersionStub != nil {
+		return fake.PinResourceVersionStub(resourceName, versionedResourceID)
+	} else {
+		return fake.pinResourceVersionReturns.result1, fake.pinResourceVersionReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceVersionCallCount() int {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return len(fake.pinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceVersionArgsForCall(i int) (string, int) {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return fake.pinResourceVersionArgsForCall[i].resourceName, fake.pinResourceVersionArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) PinResourceVersionReturns(result1 bool, result2 error) {
+	fake.PinResourceVersionStub = nil
+	fake.pinResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) UnpinResource(resourceName string) error {
+	fake.unpinResourceMutex.Lock()
+	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.recordInvocation("UnpinResource", []interface{}{resourceName})
+	fake.unpinResourceMutex.Unlock()
+	if fake.UnpinResourceStub != nil {
+		return fake.UnpinResourceStub(resourceName)
+	} else {
+		return fake.unpinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceCallCount() int {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return len(fake.unpinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceArgsForCall(i int) string {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return fake.unpinResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceReturns(result1 error) {
+	fake.UnpinResourceStub = nil
+	fake.unpinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetPinnedResourceVersions() (map[string]atc.Version, error) {
+	fake.getPinnedResourceVersionsMutex.Lock()
+	fake.getPinnedResourceVersionsArgsForCall = append(fake.getPinnedResourceVersionsArgsForCall, struct{}{})
+	fake.recordInvocation("GetPinnedResourceVersions", []interface{}{})
+	fake.getPinnedResourceVersionsMutex.Unlock()
+	if fake.GetPinnedResourceVersionsStub != nil {
+		return fake.GetPinnedResourceVersionsStub()
+	} else {
+		return fake.getPinnedResourceVersionsReturns.result1, fake.getPinnedResourceVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetPinnedResourceVersionsCallCount() int {
+	fake.getPinnedResourceVersionsMutex.RLock()
+	defer fake.getPinnedResourceVersionsMutex.RUnlock()
+	return len(fake.getPinnedResourceVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetPinnedResourceVersionsReturns(result1 map[string]atc.Version, result2 error) {
+	fake.GetPinnedResourceVersionsStub = nil
+	fake.getPinnedResourceVersionsReturns = struct {
+		result1 map[string]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetJobTriggersEvaluatedAt(jobName string) (time.Time, bool, error) {
+	fake.getJobTriggersEvaluatedAtMutex.Lock()
+	fake.getJobTriggersEvaluatedAtArgsForCall = append(fake.getJobTriggersEvaluatedAtArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetJobTriggersEvaluatedAt", []interface{}{jobName})
+	fake.getJobTriggersEvaluatedAtMutex.Unlock()
+	if fake.GetJobTriggersEvaluatedAtStub != nil {
+		return fake.GetJobTriggersEvaluatedAtStub(jobName)
+	} else {
+		return fake.getJobTriggersEvaluatedAtReturns.result1, fake.getJobTriggersEvaluatedAtReturns.result2, fake.getJobTriggersEvaluatedAtReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetJobTriggersEvaluatedAtCallCount() int {
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	return len(fake.getJobTriggersEvaluatedAtArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobTriggersEvaluatedAtArgsForCall(i int) string {
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	return fake.getJobTriggersEvaluatedAtArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) GetJobTriggersEvaluatedAtReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobTriggersEvaluatedAtStub = nil
+	fake.getJobTriggersEvaluatedAtReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) SetJobTriggersEvaluatedAt(jobName string, evaluatedAt time.Time) error {
+	fake.setJobTriggersEvaluatedAtMutex.Lock()
+	fake.setJobTriggersEvaluatedAtArgsForCall = append(fake.setJobTriggersEvaluatedAtArgsForCall, struct {
+		jobName     string
+		evaluatedAt time.Time
+	}{jobName, evaluatedAt})
+	fake.recordInvocation("SetJobTriggersEvaluatedAt", []interface{}{jobName, evaluatedAt})
+	fake.setJobTriggersEvaluatedAtMutex.Unlock()
+	if fake.SetJobTriggersEvaluatedAtStub != nil {
+		return fake.SetJobTriggersEvaluatedAtStub(jobName, evaluatedAt)
+	} else {
+		return fake.setJobTriggersEvaluatedAtReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetJobTriggersEvaluatedAtCallCount() int {
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	return len(fake.setJobTriggersEvaluatedAtArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetJobTriggersEvaluatedAtArgsForCall(i int) (string, time.Time) {
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	return fake.setJobTriggersEvaluatedAtArgsForCall[i].jobName, fake.setJobTriggersEvaluatedAtArgsForCall[i].evaluatedAt
+}
+
+func (fake *FakePipelineDB) SetJobTriggersEvaluatedAtReturns(result1 error) {
+	fake.SetJobTriggersEvaluatedAtStub = nil
+	fake.setJobTriggersEvaluatedAtReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) RerunJobBuild(originalBuild db.Build) (db.Build, error) {
+	fake.rerunJobBuildMutex.Lock()
+	fake.rerunJobBuildArgsForCall = append(fake.rerunJobBuildArgsForCall, struct {
+		originalBuild db.Build
+	}{originalBuild})
+	fake.recordInvocation("RerunJobBuild", []interface{}{originalBuild})
+	fake.rerunJobBuildMutex.Unlock()
+	if fake.RerunJobBuildStub != nil {
+		return fake.RerunJobBuildStub(originalBuild)
+	} else {
+		return fake.rerunJobBuildReturns.result1, fake.rerunJobBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) RerunJobBuildCallCount() int {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	return len(fake.rerunJobBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) RerunJobBuildArgsForCall(i int) db.Build {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	return fake.rerunJobBuildArgsForCall[i].originalBuild
+}
+
+func (fake *FakePipelineDB) RerunJobBuildReturns(result1 db.Build, result2 error) {
+	fake.RerunJobBuildStub = nil
+	fake.rerunJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetJobTestRuns(job string, builds int) ([]db.JobTestRun, error) {
+	fake.getJobTestRunsMutex.Lock()
+	fake.getJobTestRunsArgsForCall = append(fake.getJobTestRunsArgsForCall, struct {
+		job    string
+		builds int
+	}{job, builds})
+	fake.recordInvocation("GetJobTestRuns", []interface{}{job, builds})
+	fake.getJobTestRunsMutex.Unlock()
+	if fake.GetJobTestRunsStub != nil {
+		return fake.GetJobTestRunsStub(job, builds)
+	} else {
+		return fake.getJobTestRunsReturns.result1, fake.getJobTestRunsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetJobTestRunsCallCount() int {
+	fake.getJobTestRunsMutex.RLock()
+	defer fake.getJobTestRunsMutex.RUnlock()
+	return len(fake.getJobTestRunsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobTestRunsArgsForCall(i int) (string, int) {
+	fake.getJobTestRunsMutex.RLock()
+	defer fake.getJobTestRunsMutex.RUnlock()
+	return fake.getJobTestRunsArgsForCall[i].job, fake.getJobTestRunsArgsForCall[i].builds
+}
+
+func (fake *FakePipelineDB) GetJobTestRunsReturns(result1 []db.JobTestRun, result2 error) {
+	fake.GetJobTestRunsStub = nil
+	fake.getJobTestRunsReturns = struct {
+		result1 []db.JobTestRun
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2663,6 +2989,22 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	fake.getPinnedResourceVersionsMutex.RLock()
+	defer fake.getPinnedResourceVersionsMutex.RUnlock()
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	fake.getJobTestRunsMutex.RLock()
+	defer fake.getJobTestRunsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -166,6 +166,151 @@ type FakeTeamDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	GetWebhooksStub        func() ([]db.SavedWebhook, error)
+	getWebhooksMutex       sync.RWMutex
+	getWebhooksArgsForCall []struct{}
+	getWebhooksReturns     struct {
+		result1 []db.SavedWebhook
+		result2 error
+	}
+	GetWebhookStub        func(webhookName string) (db.SavedWebhook, bool, error)
+	getWebhookMutex       sync.RWMutex
+	getWebhookArgsForCall []struct {
+		webhookName string
+	}
+	getWebhookReturns struct {
+		result1 db.SavedWebhook
+		result2 bool
+		result3 error
+	}
+	SaveWebhookStub        func(webhook db.Webhook) (db.SavedWebhook, bool, error)
+	saveWebhookMutex       sync.RWMutex
+	saveWebhookArgsForCall []struct {
+		webhook db.Webhook
+	}
+	saveWebhookReturns struct {
+		result1 db.SavedWebhook
+		result2 bool
+		result3 error
+	}
+	DeleteWebhookStub        func(webhookName string) (bool, error)
+	deleteWebhookMutex       sync.RWMutex
+	deleteWebhookArgsForCall []struct {
+		webhookName string
+	}
+	deleteWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetWebhookDeliveriesStub        func(webhookName string) ([]db.WebhookDelivery, bool, error)
+	getWebhookDeliveriesMutex       sync.RWMutex
+	getWebhookDeliveriesArgsForCall []struct {
+		webhookName string
+	}
+	getWebhookDeliveriesReturns struct {
+		result1 []db.WebhookDelivery
+		result2 bool
+		result3 error
+	}
+	RedeliverWebhookDeliveryStub        func(webhookName string, deliveryID int) (db.WebhookDelivery, bool, error)
+	redeliverWebhookDeliveryMutex       sync.RWMutex
+	redeliverWebhookDeliveryArgsForCall []struct {
+		webhookName string
+		deliveryID  int
+	}
+	redeliverWebhookDeliveryReturns struct {
+		result1 db.WebhookDelivery
+		result2 bool
+		result3 error
+	}
+	SearchBuildLogsStub        func(search db.BuildLogSearch) ([]db.BuildLogMatches, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		search db.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatches
+		result2 error
+	}
+	SaveConfigAsStub        func(author string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveConfigAsMutex       sync.RWMutex
+	saveConfigAsArgsForCall []struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}
+	saveConfigAsReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetConfigVersionsStub        func(pipelineName string) ([]db.PipelineConfigVersion, bool, error)
+	getConfigVersionsMutex       sync.RWMutex
+	getConfigVersionsArgsForCall []struct {
+		pipelineName string
+	}
+	getConfigVersionsReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	GetConfigVersionStub        func(pipelineName string, version db.ConfigVersion) (db.PipelineConfigVersion, bool, error)
+	getConfigVersionMutex       sync.RWMutex
+	getConfigVersionArgsForCall []struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}
+	getConfigVersionReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	UpdateRolesStub        func(roles atc.TeamRoleBindings) (db.SavedTeam, error)
+	updateRolesMutex       sync.RWMutex
+	updateRolesArgsForCall []struct {
+		roles atc.TeamRoleBindings
+	}
+	updateRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	GetAPITokensStub        func() ([]db.SavedAPIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct{}
+	getAPITokensReturns     struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	CreateAPITokenStub        func(token db.APIToken) (db.SavedAPIToken, bool, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		token db.APIToken
+	}
+	createAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}
+	DeleteAPITokenStub        func(tokenName string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		tokenName string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	UpdateLDAPAuthStub        func(ldapAuth *db.LDAPAuth) (db.SavedTeam, error)
+	updateLDAPAuthMutex       sync.RWMutex
+	updateLDAPAuthArgsForCall []struct {
+		ldapAuth *db.LDAPAuth
+	}
+	updateLDAPAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -740,6 +885,514 @@ func (fake *FakeTeamDB) GetVolumesReturns(result1 []db.SavedVolume, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) GetWebhooks() ([]db.SavedWebhook, error) {
+	fake.getWebhooksMutex.Lock()
+	fake.getWebhooksArgsForCall = append(fake.getWebhooksArgsForCall, struct{}{})
+	fake.recordInvocation("GetWebhooks", []interface{}{})
+	fake.getWebhooksMutex.Unlock()
+	if fake.GetWebhooksStub != nil {
+		return fake.GetWebhooksStub()
+	} else {
+		return fake.getWebhooksReturns.result1, fake.getWebhooksReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetWebhooksCallCount() int {
+	fake.getWebhooksMutex.RLock()
+	defer fake.getWebhooksMutex.RUnlock()
+	return len(fake.getWebhooksArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetWebhooksReturns(result1 []db.SavedWebhook, result2 error) {
+	fake.GetWebhooksStub = nil
+	fake.getWebhooksReturns = struct {
+		result1 []db.SavedWebhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetWebhook(webhookName string) (db.SavedWebhook, bool, error) {
+	fake.getWebhookMutex.Lock()
+	fake.getWebhookArgsForCall = append(fake.getWebhookArgsForCall, struct {
+		webhookName string
+	}{webhookName})
+	fake.recordInvocation("GetWebhook", []interface{}{webhookName})
+	fake.getWebhookMutex.Unlock()
+	if fake.GetWebhookStub != nil {
+		return fake.GetWebhookStub(webhookName)
+	} else {
+		return fake.getWebhookReturns.result1, fake.getWebhookReturns.result2, fake.getWebhookReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetWebhookCallCount() int {
+	fake.getWebhookMutex.RLock()
+	defer fake.getWebhookMutex.RUnlock()
+	return len(fake.getWebhookArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetWebhookArgsForCall(i int) string {
+	fake.getWebhookMutex.RLock()
+	defer fake.getWebhookMutex.RUnlock()
+	return fake.getWebhookArgsForCall[i].webhookName
+}
+
+func (fake *FakeTeamDB) GetWebhookReturns(result1 db.SavedWebhook, result2 bool, result3 error) {
+	fake.GetWebhookStub = nil
+	fake.getWebhookReturns = struct {
+		result1 db.SavedWebhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SaveWebhook(webhook db.Webhook) (db.SavedWebhook, bool, error) {
+	fake.saveWebhookMutex.Lock()
+	fake.saveWebhookArgsForCall = append(fake.saveWebhookArgsForCall, struct {
+		webhook db.Webhook
+	}{webhook})
+	fake.recordInvocation("SaveWebhook", []interface{}{webhook})
+	fake.saveWebhookMutex.Unlock()
+	if fake.SaveWebhookStub != nil {
+		return fake.SaveWebhookStub(webhook)
+	} else {
+		return fake.saveWebhookReturns.result1, fake.saveWebhookReturns.result2, fake.saveWebhookReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveWebhookCallCount() int {
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	return len(fake.saveWebhookArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveWebhookArgsForCall(i int) db.Webhook {
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	return fake.saveWebhookArgsForCall[i].webhook
+}
+
+func (fake *FakeTeamDB) SaveWebhookReturns(result1 db.SavedWebhook, result2 bool, result3 error) {
+	fake.SaveWebhookStub = nil
+	fake.saveWebhookReturns = struct {
+		result1 db.SavedWebhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) DeleteWebhook(webhookName string) (bool, error) {
+	fake.deleteWebhookMutex.Lock()
+	fake.deleteWebhookArgsForCall = append(fake.deleteWebhookArgsForCall, struct {
+		webhookName string
+	}{webhookName})
+	fake.recordInvocation("DeleteWebhook", []interface{}{webhookName})
+	fake.deleteWebhookMutex.Unlock()
+	if fake.DeleteWebhookStub != nil {
+		return fake.DeleteWebhookStub(webhookName)
+	} else {
+		return fake.deleteWebhookReturns.result1, fake.deleteWebhookReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) DeleteWebhookCallCount() int {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return len(fake.deleteWebhookArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteWebhookArgsForCall(i int) string {
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	return fake.deleteWebhookArgsForCall[i].webhookName
+}
+
+func (fake *FakeTeamDB) DeleteWebhookReturns(result1 bool, result2 error) {
+	fake.DeleteWebhookStub = nil
+	fake.deleteWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetWebhookDeliveries(webhookName string) ([]db.WebhookDelivery, bool, error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	fake.getWebhookDeliveriesArgsForCall = append(fake.getWebhookDeliveriesArgsForCall, struct {
+		webhookName string
+	}{webhookName})
+	fake.recordInvocation("GetWebhookDeliveries", []interface{}{webhookName})
+	fake.getWebhookDeliveriesMutex.Unlock()
+	if fake.GetWebhookDeliveriesStub != nil {
+		return fake.GetWebhookDeliveriesStub(webhookName)
+	} else {
+		return fake.getWebhookDeliveriesReturns.result1, fake.getWebhookDeliveriesReturns.result2, fake.getWebhookDeliveriesReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetWebhookDeliveriesCallCount() int {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetWebhookDeliveriesArgsForCall(i int) string {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	return fake.getWebhookDeliveriesArgsForCall[i].webhookName
+}
+
+func (fake *FakeTeamDB) GetWebhookDeliveriesReturns(result1 []db.WebhookDelivery, result2 bool, result3 error) {
+	fake.GetWebhookDeliveriesStub = nil
+	fake.getWebhookDeliveriesReturns = struct {
+		result1 []db.WebhookDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) RedeliverWebhookDelivery(webhookName string, deliveryID int) (db.WebhookDelivery, bool, error) {
+	fake.redeliverWebhookDeliveryMutex.Lock()
+	fake.redeliverWebhookDeliveryArgsForCall = append(fake.redeliverWebhookDeliveryArgsForCall, struct {
+		webhookName string
+		deliveryID  int
+	}{webhookName, deliveryID})
+	fake.recordInvocation("RedeliverWebhookDelivery", []interface{}{webhookName, deliveryID})
+	fake.redeliverWebhookDeliveryMutex.Unlock()
+	if fake.RedeliverWebhookDeliveryStub != nil {
+		return fake.RedeliverWebhookDeliveryStub(webhookName, deliveryID)
+	} else {
+		return fake.redeliverWebhookDeliveryReturns.result1, fake.redeliverWebhookDeliveryReturns.result2, fake.redeliverWebhookDeliveryReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) RedeliverWebhookDeliveryCallCount() int {
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	return len(fake.redeliverWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeTeamDB) RedeliverWebhookDeliveryArgsForCall(i int) (string, int) {
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	return fake.redeliverWebhookDeliveryArgsForCall[i].webhookName, fake.redeliverWebhookDeliveryArgsForCall[i].deliveryID
+}
+
+func (fake *FakeTeamDB) RedeliverWebhookDeliveryReturns(result1 db.WebhookDelivery, result2 bool, result3 error) {
+	fake.RedeliverWebhookDeliveryStub = nil
+	fake.redeliverWebhookDeliveryReturns = struct {
+		result1 db.WebhookDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SearchBuildLogs(search db.BuildLogSearch) ([]db.BuildLogMatches, error) {
+	fake.searchBuildLogsMutex.Lock()
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		search db.BuildLogSearch
+	}{search})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{search})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(search)
+	} else {
+		return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeamDB) SearchBuildLogsArgsForCall(i int) db.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].search
+}
+
+func (fake *FakeTeamDB) SearchBuildLogsReturns(result1 []db.BuildLogMatches, result2 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatches
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) SaveConfigAs(author string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveConfigAsMutex.Lock()
+	fake.saveConfigAsArgsForCall = append(fake.saveConfigAsArgsForCall, struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}{author, pipelineName, config, from, pausedState})
+	fake.recordInvocation("SaveConfigAs", []interface{}{author, pipelineName, config, from, pausedState})
+	fake.saveConfigAsMutex.Unlock()
+	if fake.SaveConfigAsStub != nil {
+		return fake.SaveConfigAsStub(author, pipelineName, config, from, pausedState)
+	} else {
+		return fake.saveConfigAsReturns.result1, fake.saveConfigAsReturns.result2, fake.saveConfigAsReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveConfigAsCallCount() int {
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	return len(fake.saveConfigAsArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveConfigAsArgsForCall(i int) (string, string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	return fake.saveConfigAsArgsForCall[i].author, fake.saveConfigAsArgsForCall[i].pipelineName, fake.saveConfigAsArgsForCall[i].config, fake.saveConfigAsArgsForCall[i].from, fake.saveConfigAsArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeamDB) SaveConfigAsReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveConfigAsStub = nil
+	fake.saveConfigAsReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigVersions(pipelineName string) ([]db.PipelineConfigVersion, bool, error) {
+	fake.getConfigVersionsMutex.Lock()
+	fake.getConfigVersionsArgsForCall = append(fake.getConfigVersionsArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetConfigVersions", []interface{}{pipelineName})
+	fake.getConfigVersionsMutex.Unlock()
+	if fake.GetConfigVersionsStub != nil {
+		return fake.GetConfigVersionsStub(pipelineName)
+	} else {
+		return fake.getConfigVersionsReturns.result1, fake.getConfigVersionsReturns.result2, fake.getConfigVersionsReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsCallCount() int {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return len(fake.getConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsArgsForCall(i int) string {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return fake.getConfigVersionsArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsReturns(result1 []db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.GetConfigVersionsStub = nil
+	fake.getConfigVersionsReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigVersion(pipelineName string, version db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+	fake.getConfigVersionMutex.Lock()
+	fake.getConfigVersionArgsForCall = append(fake.getConfigVersionArgsForCall, struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}{pipelineName, version})
+	fake.recordInvocation("GetConfigVersion", []interface{}{pipelineName, version})
+	fake.getConfigVersionMutex.Unlock()
+	if fake.GetConfigVersionStub != nil {
+		return fake.GetConfigVersionStub(pipelineName, version)
+	} else {
+		return fake.getConfigVersionReturns.result1, fake.getConfigVersionReturns.result2, fake.getConfigVersionReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionCallCount() int {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return len(fake.getConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionArgsForCall(i int) (string, db.ConfigVersion) {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return fake.getConfigVersionArgsForCall[i].pipelineName, fake.getConfigVersionArgsForCall[i].version
+}
+
+func (fake *FakeTeamDB) GetConfigVersionReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.GetConfigVersionStub = nil
+	fake.getConfigVersionReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) UpdateRoles(roles atc.TeamRoleBindings) (db.SavedTeam, error) {
+	fake.updateRolesMutex.Lock()
+	fake.updateRolesArgsForCall = append(fake.updateRolesArgsForCall, struct {
+		roles atc.TeamRoleBindings
+	}{roles})
+	fake.recordInvocation("UpdateRoles", []interface{}{roles})
+	fake.updateRolesMutex.Unlock()
+	if fake.UpdateRolesStub != nil {
+		return fake.UpdateRolesStub(roles)
+	} else {
+		return fake.updateRolesReturns.result1, fake.updateRolesReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateRolesCallCount() int {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return len(fake.updateRolesArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateRolesArgsForCall(i int) atc.TeamRoleBindings {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return fake.updateRolesArgsForCall[i].roles
+}
+
+func (fake *FakeTeamDB) UpdateRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateRolesStub = nil
+	fake.updateRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokens() ([]db.SavedAPIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct{}{})
+	fake.recordInvocation("GetAPITokens", []interface{}{})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub()
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturns(result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) CreateAPIToken(token db.APIToken) (db.SavedAPIToken, bool, error) {
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		token db.APIToken
+	}{token})
+	fake.recordInvocation("CreateAPIToken", []interface{}{token})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(token)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2, fake.createAPITokenReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateAPITokenArgsForCall(i int) db.APIToken {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].token
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturns(result1 db.SavedAPIToken, result2 bool, result3 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) DeleteAPIToken(tokenName string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		tokenName string
+	}{tokenName})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{tokenName})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(tokenName)
+	} else {
+		return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenArgsForCall(i int) string {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].tokenName
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuth(ldapAuth *db.LDAPAuth) (db.SavedTeam, error) {
+	fake.updateLDAPAuthMutex.Lock()
+	fake.updateLDAPAuthArgsForCall = append(fake.updateLDAPAuthArgsForCall, struct {
+		ldapAuth *db.LDAPAuth
+	}{ldapAuth})
+	fake.recordInvocation("UpdateLDAPAuth", []interface{}{ldapAuth})
+	fake.updateLDAPAuthMutex.Unlock()
+	if fake.UpdateLDAPAuthStub != nil {
+		return fake.UpdateLDAPAuthStub(ldapAuth)
+	} else {
+		return fake.updateLDAPAuthReturns.result1, fake.updateLDAPAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthCallCount() int {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return len(fake.updateLDAPAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthArgsForCall(i int) *db.LDAPAuth {
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
+	return fake.updateLDAPAuthArgsForCall[i].ldapAuth
+}
+
+func (fake *FakeTeamDB) UpdateLDAPAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateLDAPAuthStub = nil
+	fake.updateLDAPAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -779,6 +1432,36 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByDescriptorsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.getWebhooksMutex.RLock()
+	defer fake.getWebhooksMutex.RUnlock()
+	fake.getWebhookMutex.RLock()
+	defer fake.getWebhookMutex.RUnlock()
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	fake.deleteWebhookMutex.RLock()
+	defer fake.deleteWebhookMutex.RUnlock()
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	fake.updateLDAPAuthMutex.RLock()
+	defer fake.updateLDAPAuthMutex.RUnlock()
 	return fake.invocations
 }
 
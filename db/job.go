@@ -24,3 +24,11 @@ type DashboardJob struct {
 }
 
 type Dashboard []DashboardJob
+
+// JobTestRun is the result of a test in one of a job's builds.
+type JobTestRun struct {
+	BuildID   int
+	BuildName string
+
+	Result atc.TestResult
+}
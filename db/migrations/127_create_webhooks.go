@@ -0,0 +1,44 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateWebhooks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE webhooks (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			url text NOT NULL,
+			secret text NOT NULL DEFAULT '',
+			UNIQUE (team_id, name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE webhook_deliveries (
+			id serial PRIMARY KEY,
+			webhook_id integer NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			build_status text NOT NULL,
+			payload text NOT NULL,
+			status text NOT NULL DEFAULT 'pending',
+			attempts integer NOT NULL DEFAULT 0,
+			response_status integer NOT NULL DEFAULT 0,
+			error text NOT NULL DEFAULT '',
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+			delivered_at timestamp with time zone
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending'
+	`)
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddContainerLimitsToContainers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE containers
+		ADD COLUMN container_limits text
+	`)
+	return err
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildLogLines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_log_lines (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			origin_id text NOT NULL,
+			line text NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_log_lines_build_id_idx ON build_log_lines (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// substring searches can only use an index through pg_trgm, and installing
+	// an extension needs more privileges than the ATC's role usually has; the
+	// operator installs it, and without it searches scan the lines instead
+	var hasTrigrams bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')
+	`).Scan(&hasTrigrams)
+	if err != nil {
+		return err
+	}
+
+	if !hasTrigrams {
+		return nil
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_log_lines_line_idx ON build_log_lines USING gin (line gin_trgm_ops)
+	`)
+	return err
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildTestResults(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_test_results (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			suite text NOT NULL,
+			name text NOT NULL,
+			status text NOT NULL,
+			duration double precision NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_test_results_build_id_idx ON build_test_results (build_id)
+	`)
+	return err
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildArtifacts(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_artifacts (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (build_id, name)
+		)
+	`)
+	return err
+}
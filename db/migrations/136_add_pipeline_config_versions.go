@@ -0,0 +1,27 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddPipelineConfigVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_config_versions (
+			id serial PRIMARY KEY,
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			version bigint NOT NULL,
+			config text NOT NULL,
+			author text NOT NULL DEFAULT '',
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (pipeline_id, version)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config)
+		SELECT id, version, config
+		FROM pipelines
+	`)
+	return err
+}
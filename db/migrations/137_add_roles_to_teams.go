@@ -0,0 +1,10 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddRolesToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams ADD COLUMN roles text
+	`)
+	return err
+}
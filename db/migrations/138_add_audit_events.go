@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE audit_events (
+			id serial PRIMARY KEY,
+			route text NOT NULL,
+			username text NOT NULL DEFAULT '',
+			team_name text NOT NULL DEFAULT '',
+			target text NOT NULL DEFAULT '',
+			status integer NOT NULL DEFAULT 0,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX audit_events_team_name_idx ON audit_events (team_name)
+	`)
+	return err
+}
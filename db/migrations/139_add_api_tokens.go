@@ -0,0 +1,20 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE api_tokens (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			role text NOT NULL,
+			token_hash text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			last_used_at timestamp with time zone,
+			CONSTRAINT api_tokens_team_id_name_key UNIQUE (team_id, name),
+			CONSTRAINT api_tokens_token_hash_key UNIQUE (token_hash)
+		)
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddLDAPAuthToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams ADD COLUMN ldap_auth json
+	`)
+	return err
+}
@@ -127,4 +127,18 @@ var Migrations = []migration.Migrator{
 	CascadeTeamDeletes,
 	CascadeTeamDeletesOnPipes,
 	RemoveResourceCheckingFromJobsAndAddManualyTriggeredToBuilds,
+	CreateWebhooks,
+	AddPinnedVersionToResources,
+	AddTriggersEvaluatedAtToJobs,
+	AddRerunOfToBuilds,
+	AddContainerLimitsToContainers,
+	AddEventsArchivedToBuilds,
+	AddBuildLogLines,
+	AddBuildTestResults,
+	AddBuildArtifacts,
+	AddPipelineConfigVersions,
+	AddRolesToTeams,
+	AddAuditEvents,
+	AddAPITokens,
+	AddLDAPAuthToTeams,
 }
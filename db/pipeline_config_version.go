@@ -0,0 +1,27 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// PipelineConfigVersion is a config that was saved for a pipeline, kept so
+// that it can be inspected or rolled back to after it has been replaced.
+type PipelineConfigVersion struct {
+	Version   ConfigVersion
+	Author    string
+	CreatedAt time.Time
+	RawConfig atc.RawConfig
+}
+
+func (version PipelineConfigVersion) Config() (atc.Config, error) {
+	var config atc.Config
+	err := json.Unmarshal([]byte(version.RawConfig), &config)
+	if err != nil {
+		return atc.Config{}, atc.MalformedConfigError{UnmarshalError: err}
+	}
+
+	return config, nil
+}
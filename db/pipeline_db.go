@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -21,6 +24,7 @@ type PipelineDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 	ConfigVersion() ConfigVersion
 
@@ -43,6 +47,10 @@ type PipelineDB interface {
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
 
+	PinResourceVersion(resourceName string, versionedResourceID int) (bool, error)
+	UnpinResource(resourceName string) error
+	GetPinnedResourceVersions() (map[string]atc.Version, error)
+
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
@@ -58,15 +66,19 @@ type PipelineDB interface {
 	PauseJob(job string) error
 	UnpauseJob(job string) error
 	SetMaxInFlightReached(string, bool) error
+	GetJobTriggersEvaluatedAt(jobName string) (time.Time, bool, error)
+	SetJobTriggersEvaluatedAt(jobName string, evaluatedAt time.Time) error
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
 
 	GetJobFinishedAndNextBuild(job string) (Build, Build, error)
 
 	GetJobBuilds(job string, page Page) ([]Build, Pagination, error)
 	GetAllJobBuilds(job string) ([]Build, error)
+	GetJobTestRuns(job string, builds int) ([]JobTestRun, error)
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
+	RerunJobBuild(originalBuild Build) (Build, error)
 	EnsurePendingBuildExists(jobName string) error
 	GetPendingBuildsForJob(jobName string) ([]Build, error)
 	GetAllPendingBuilds() (map[string][]Build, error)
@@ -153,6 +165,10 @@ func (pdb *pipelineDB) TeamID() int {
 	return pdb.SavedPipeline.TeamID
 }
 
+func (pdb *pipelineDB) TeamName() string {
+	return pdb.SavedPipeline.TeamName
+}
+
 func (pdb *pipelineDB) Config() atc.Config {
 	return pdb.SavedPipeline.Config
 }
@@ -261,7 +277,7 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, paused, pinned_version
 			FROM resources
 			WHERE pipeline_id = $1
 				AND active = true
@@ -602,7 +618,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, paused, pinned_version
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
@@ -611,11 +627,11 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, err
 }
 
 func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error) {
-	var checkErr sql.NullString
+	var checkErr, pinnedVersion sql.NullString
 	var resource SavedResource
 	var configBlob []byte
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused, &pinnedVersion)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -637,6 +653,13 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersion.Valid {
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, false, err
+		}
+	}
+
 	return resource, true, nil
 }
 
@@ -704,6 +727,89 @@ func (pdb *pipelineDB) UnpauseResource(resource string) error {
 	return pdb.updatePaused(resource, false)
 }
 
+// PinResourceVersion pins the resource to one of its versions, which is then
+// used by every job with the resource as an input in place of the version
+// configured on the get step.
+func (pdb *pipelineDB) PinResourceVersion(resourceName string, versionedResourceID int) (bool, error) {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources r
+		SET pinned_version = v.version
+		FROM versioned_resources v
+		WHERE v.id = $1
+			AND v.resource_id = r.id
+			AND r.name = $2
+			AND r.pipeline_id = $3
+	`, versionedResourceID, resourceName, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (pdb *pipelineDB) UnpinResource(resourceName string) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET pinned_version = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resourceName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) GetPinnedResourceVersions() (map[string]atc.Version, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT name, pinned_version
+		FROM resources
+		WHERE pipeline_id = $1
+			AND active = true
+			AND pinned_version IS NOT NULL
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pinnedVersions := map[string]atc.Version{}
+
+	for rows.Next() {
+		var name, versionJSON string
+		err := rows.Scan(&name, &versionJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(versionJSON), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		pinnedVersions[name] = version
+	}
+
+	return pinnedVersions, nil
+}
+
 func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1187,6 +1293,59 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	return build, nil
 }
 
+// RerunJobBuild creates a new build of the original build's job that uses
+// the very same inputs, rather than the job's next input mapping.
+func (pdb *pipelineDB) RerunJobBuild(originalBuild Build) (Build, error) {
+	inputs, _, err := originalBuild.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	buildName, jobID, err := getNewBuildNameForJob(tx, originalBuild.JobName(), pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, manually_triggered, rerun_of)
+		VALUES ($1, $2, $3, 'pending', TRUE, $5)
+		RETURNING `+buildColumns+`,
+			(SELECT name FROM jobs WHERE id = $2),
+			(SELECT id FROM pipelines WHERE id = $4),
+			(SELECT name FROM pipelines WHERE id = $4),
+			(SELECT name FROM teams WHERE id = $3)
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, originalBuild.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, input := range inputs {
+		_, err := pdb.saveBuildInput(tx, build.ID(), input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = createBuildEventSeq(tx, build.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
 func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1469,7 +1628,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.rerun_of, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1678,12 +1837,229 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 	return max_modified_time, err
 }
 
+// crossPipelineEdge is a passed constraint on a job in another pipeline of the
+// team, through one of that pipeline's resources configured the same as the
+// constrained input's resource in this pipeline
+type crossPipelineEdge struct {
+	JobID            int
+	PipelineID       int
+	UpstreamResource string
+	Resource         string
+}
+
+// jobs in other pipelines of the team are referred to by passed constraints
+// as "other-pipeline/job", and are looked up by that name
+func (pdb *pipelineDB) getCrossPipelineEdges() (map[string]int, []crossPipelineEdge, error) {
+	jobIDs := map[string]int{}
+	edges := []crossPipelineEdge{}
+
+	pipelineConfig := pdb.Config()
+
+	constrainedResources := map[string][]string{}
+	jobConditions := sq.Or{}
+
+	for _, jobConfig := range pipelineConfig.Jobs {
+		for _, input := range config.JobInputs(jobConfig) {
+			for _, passed := range input.Passed {
+				if _, found := pipelineConfig.Jobs.Lookup(passed); found {
+					continue
+				}
+
+				pipelineName, jobName, isCrossPipeline := config.CrossPipelinePassed(passed)
+				if !isCrossPipeline {
+					continue
+				}
+
+				if _, found := constrainedResources[passed]; !found {
+					jobConditions = append(jobConditions, sq.Eq{"p.name": pipelineName, "j.name": jobName})
+				}
+
+				constrainedResources[passed] = append(constrainedResources[passed], input.Resource)
+			}
+		}
+	}
+
+	if len(jobConditions) == 0 {
+		return jobIDs, edges, nil
+	}
+
+	query, args, err := sq.Select("p.id", "p.name", "p.config", "j.id", "j.name").
+		From("jobs j").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{"p.team_id": pdb.TeamID()}).
+		Where("j.active").
+		Where(jobConditions).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := pdb.conn.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	otherConfigs := map[int]atc.Config{}
+
+	for rows.Next() {
+		var pipelineID, jobID int
+		var pipelineName, jobName string
+		var configBlob []byte
+
+		err := rows.Scan(&pipelineID, &pipelineName, &configBlob, &jobID, &jobName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		otherConfig, found := otherConfigs[pipelineID]
+		if !found {
+			err := json.Unmarshal(configBlob, &otherConfig)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			otherConfigs[pipelineID] = otherConfig
+		}
+
+		passed := pipelineName + "/" + jobName
+		jobIDs[passed] = jobID
+
+		otherJob, found := otherConfig.Jobs.Lookup(jobName)
+		if !found {
+			continue
+		}
+
+		for _, resourceName := range constrainedResources[passed] {
+			resource, found := pipelineConfig.Resources.Lookup(resourceName)
+			if !found {
+				continue
+			}
+
+			for _, upstreamResource := range config.CrossPipelineResources(resource, otherConfig, otherJob) {
+				edges = append(edges, crossPipelineEdge{
+					JobID:            jobID,
+					PipelineID:       pipelineID,
+					UpstreamResource: upstreamResource,
+					Resource:         resourceName,
+				})
+			}
+		}
+	}
+
+	return jobIDs, edges, nil
+}
+
+func (pdb *pipelineDB) getCrossPipelineModifiedTime(jobIDs map[string]int) (time.Time, error) {
+	var maxModifiedTime time.Time
+
+	if len(jobIDs) == 0 {
+		return maxModifiedTime, nil
+	}
+
+	ids := []int{}
+	for _, jobID := range jobIDs {
+		ids = append(ids, jobID)
+	}
+
+	query, args, err := sq.Select("COALESCE(MAX(o.modified_time), 'epoch')").
+		From("build_outputs o").
+		Join("builds b ON b.id = o.build_id").
+		Where(sq.Eq{"b.job_id": ids}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return maxModifiedTime, err
+	}
+
+	err = pdb.conn.QueryRow(query, args...).Scan(&maxModifiedTime)
+
+	return maxModifiedTime, err
+}
+
+// the outputs of a job in another pipeline are translated to the versions of
+// this pipeline's resources with the same version as the other pipeline's
+// resources they are configured the same as
+func (pdb *pipelineDB) getCrossPipelineBuildOutputs(edges []crossPipelineEdge) ([]algorithm.BuildOutput, error) {
+	outputs := []algorithm.BuildOutput{}
+
+	if len(edges) == 0 {
+		return outputs, nil
+	}
+
+	edgeConditions := sq.Or{}
+	for _, edge := range edges {
+		edgeConditions = append(edgeConditions, sq.Eq{
+			"b.job_id":       edge.JobID,
+			"ur.pipeline_id": edge.PipelineID,
+			"ur.name":        edge.UpstreamResource,
+			"r.name":         edge.Resource,
+		})
+	}
+
+	query, args, err := sq.Select("v.id", "v.check_order", "r.id", "o.build_id", "b.job_id").
+		Distinct().
+		From("build_outputs o").
+		Join("builds b ON b.id = o.build_id").
+		Join("versioned_resources ov ON ov.id = o.versioned_resource_id").
+		Join("resources ur ON ur.id = ov.resource_id").
+		Join("versioned_resources v ON v.version = ov.version AND v.type = ov.type").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{"r.pipeline_id": pdb.ID}).
+		Where("ov.enabled").
+		Where("v.enabled").
+		Where("b.status = 'succeeded'").
+		Where(edgeConditions).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pdb.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		output.ResourceVersion.CheckOrder = output.CheckOrder
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	latestModifiedTime, err := pdb.getLatestModifiedTime()
 	if err != nil {
 		return nil, err
 	}
 
+	crossPipelineJobIDs, crossPipelineEdges, err := pdb.getCrossPipelineEdges()
+	if err != nil {
+		return nil, err
+	}
+
+	crossPipelineModifiedTime, err := pdb.getCrossPipelineModifiedTime(crossPipelineJobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if crossPipelineModifiedTime.After(latestModifiedTime) {
+		latestModifiedTime = crossPipelineModifiedTime
+	}
+
 	if pdb.versionsDB != nil && pdb.versionsDB.CachedAt.Equal(latestModifiedTime) {
 		return pdb.versionsDB, nil
 	}
@@ -1724,6 +2100,13 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
+	crossPipelineOutputs, err := pdb.getCrossPipelineBuildOutputs(crossPipelineEdges)
+	if err != nil {
+		return nil, err
+	}
+
+	db.BuildOutputs = append(db.BuildOutputs, crossPipelineOutputs...)
+
 	rows, err = pdb.conn.Query(`
     SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
     FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
@@ -1791,6 +2174,10 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.JobIDs[name] = id
 	}
 
+	for passed, jobID := range crossPipelineJobIDs {
+		db.JobIDs[passed] = jobID
+	}
+
 	rows, err = pdb.conn.Query(`
     SELECT r.name, r.id
     FROM resources r
@@ -2087,6 +2474,49 @@ func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error
 	return nil
 }
 
+// GetJobTriggersEvaluatedAt returns when the job's cron triggers were last
+// evaluated, and false if they never have been.
+func (pdb *pipelineDB) GetJobTriggersEvaluatedAt(jobName string) (time.Time, bool, error) {
+	var evaluatedAt pq.NullTime
+
+	err := pdb.conn.QueryRow(`
+		SELECT triggers_evaluated_at
+		FROM jobs
+		WHERE name = $1 AND pipeline_id = $2
+	`, jobName, pdb.ID).Scan(&evaluatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, false, nil
+		}
+
+		return time.Time{}, false, err
+	}
+
+	return evaluatedAt.Time, evaluatedAt.Valid, nil
+}
+
+func (pdb *pipelineDB) SetJobTriggersEvaluatedAt(jobName string, evaluatedAt time.Time) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET triggers_evaluated_at = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, evaluatedAt, jobName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 func (pdb *pipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -2268,6 +2698,50 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 	return builds, pagination, nil
 }
 
+// GetJobTestRuns returns the test results of the job's latest builds which
+// reported any, newest build first.
+func (pdb *pipelineDB) GetJobTestRuns(jobName string, builds int) ([]JobTestRun, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT b.id, b.name, r.plan_id, r.suite, r.name, r.status, r.duration
+		FROM build_test_results r
+		INNER JOIN builds b ON b.id = r.build_id
+		WHERE b.id IN (
+			SELECT tb.id
+			FROM builds tb
+			INNER JOIN jobs j ON j.id = tb.job_id
+			WHERE j.name = $1
+				AND j.pipeline_id = $2
+				AND EXISTS (SELECT 1 FROM build_test_results tr WHERE tr.build_id = tb.id)
+			ORDER BY tb.id DESC
+			LIMIT $3
+		)
+		ORDER BY b.id DESC, r.id ASC
+	`, jobName, pdb.ID, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	runs := []JobTestRun{}
+
+	for rows.Next() {
+		var run JobTestRun
+		var planID, status string
+		err := rows.Scan(&run.BuildID, &run.BuildName, &planID, &run.Result.Suite, &run.Result.Name, &status, &run.Result.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		run.Result.PlanID = atc.PlanID(planID)
+		run.Result.Status = atc.TestStatus(status)
+
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
 func (pdb *pipelineDB) GetAllJobBuilds(job string) ([]Build, error) {
 	rows, err := pdb.conn.Query(`
 		SELECT `+qualifiedBuildColumns+`
@@ -0,0 +1,173 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cross-pipeline passed constraints", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		upstreamPipelineDB   db.PipelineDB
+		downstreamPipelineDB db.PipelineDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB := db.NewSQL(dbConn, bus, lockFactory, nil)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
+
+		savePipeline := func(teamName string, pipelineName string, config atc.Config) db.PipelineDB {
+			pipeline, _, err := teamDBFactory.GetTeamDB(teamName).SaveConfig(pipelineName, config, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			return pipelineDBFactory.Build(pipeline)
+		}
+
+		upstreamConfig := atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "build",
+					Plan: atc.PlanSequence{
+						{Get: "some-repo"},
+					},
+				},
+			},
+		}
+
+		upstreamPipelineDB = savePipeline(atc.DefaultTeamName, "upstream", upstreamConfig)
+
+		// another team's pipeline of the same name must never be consulted
+		savePipeline("other-team", "upstream", upstreamConfig)
+
+		downstreamPipelineDB = savePipeline(atc.DefaultTeamName, "downstream", atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+				{Name: "other-repo", Type: "git", Source: atc.Source{"uri": "other-uri"}},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "deploy",
+					Plan: atc.PlanSequence{
+						{
+							Get:      "repo",
+							Passed:   []string{"upstream/build"},
+							Resource: "repo",
+						},
+					},
+				},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("loads the outputs of the other pipeline's job as outputs of the same versions of the same resource", func() {
+		upstreamJob, found, err := upstreamPipelineDB.GetJob("build")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		versions, err := downstreamPipelineDB.LoadVersionsDB()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.JobIDs).To(HaveKeyWithValue("upstream/build", upstreamJob.ID))
+		Expect(versions.BuildOutputs).To(BeEmpty())
+
+		err = upstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+			Name:   "some-repo",
+			Type:   "git",
+			Source: atc.Source{"uri": "some-uri"},
+		}, []atc.Version{{"ref": "abc"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		upstreamVR, found, err := upstreamPipelineDB.GetLatestVersionedResource("some-repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+			Name:   "repo",
+			Type:   "git",
+			Source: atc.Source{"uri": "some-uri"},
+		}, []atc.Version{{"ref": "abc"}, {"ref": "def"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		// a different repository that happens to be at the same version is not
+		// an output of the other pipeline's job
+		err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+			Name:   "other-repo",
+			Type:   "git",
+			Source: atc.Source{"uri": "other-uri"},
+		}, []atc.Version{{"ref": "abc"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		downstreamVR, found, err := downstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"ref": "abc"}, "repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		downstreamResource, found, err := downstreamPipelineDB.GetResource("repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		build, err := upstreamPipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = upstreamPipelineDB.SaveOutput(build.ID(), upstreamVR.VersionedResource, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.Finish(db.StatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+
+		unfinishedBuild, err := upstreamPipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = upstreamPipelineDB.SaveOutput(unfinishedBuild.ID(), upstreamVR.VersionedResource, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		versions, err = downstreamPipelineDB.LoadVersionsDB()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  downstreamVR.ID,
+				ResourceID: downstreamResource.ID,
+				CheckOrder: downstreamVR.CheckOrder,
+			},
+			BuildID: build.ID(),
+			JobID:   upstreamJob.ID,
+		}))
+	})
+})
@@ -1,5 +1,7 @@
 package db
 
+import "github.com/concourse/atc/archive"
+
 //go:generate counterfeiter . PipelineDBFactory
 
 type PipelineDBFactory interface {
@@ -10,18 +12,21 @@ type pipelineDBFactory struct {
 	conn Conn
 	bus  *notificationsBus
 
-	lockFactory LockFactory
+	lockFactory  LockFactory
+	eventArchive archive.Store
 }
 
 func NewPipelineDBFactory(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory LockFactory,
+	eventArchive archive.Store,
 ) *pipelineDBFactory {
 	return &pipelineDBFactory{
-		conn:        sqldbConnection,
-		bus:         bus,
-		lockFactory: lockFactory,
+		conn:         sqldbConnection,
+		bus:          bus,
+		lockFactory:  lockFactory,
+		eventArchive: eventArchive,
 	}
 }
 
@@ -30,7 +35,7 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
-		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory),
+		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory, pdbf.eventArchive),
 		lockFactory:  pdbf.lockFactory,
 
 		SavedPipeline: pipeline,
@@ -38,9 +38,9 @@ var _ = Describe("PipelineDB", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 	})
 
 	AfterEach(func() {
@@ -1295,6 +1295,70 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning and unpinning resources", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resourceName,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR, _, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, resourceName)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("starts out as unpinned", func() {
+				resource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resource.PinnedVersion).To(BeNil())
+
+				pinnedVersions, err := pipelineDB.GetPinnedResourceVersions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedVersions).To(BeEmpty())
+			})
+
+			It("can be pinned to one of its versions", func() {
+				pinned, err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned).To(BeTrue())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersion).To(Equal(atc.Version{"version": "1"}))
+
+				pinnedVersions, err := pipelineDB.GetPinnedResourceVersions()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedVersions).To(Equal(map[string]atc.Version{
+					resourceName: {"version": "1"},
+				}))
+
+				resource, _, err := otherPipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resource.PinnedVersion).To(BeNil())
+			})
+
+			It("cannot be pinned to another resource's version", func() {
+				pinned, err := otherPipelineDB.PinResourceVersion(resourceName, savedVR.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned).To(BeFalse())
+			})
+
+			It("can be unpinned", func() {
+				_, err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpinResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.PinnedVersion).To(BeNil())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -2097,6 +2161,58 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("RerunJobBuild", func() {
+			var originalBuild db.Build
+			var input db.BuildInput
+
+			BeforeEach(func() {
+				var err error
+				originalBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				input = db.BuildInput{
+					Name: "some-input",
+					VersionedResource: db.VersionedResource{
+						PipelineID: savedPipeline.ID,
+						Resource:   "some-other-resource",
+						Type:       "some-type",
+						Version:    db.Version{"ver": "1"},
+					},
+				}
+
+				_, err = originalBuild.SaveInput(input)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending build of the job with the original build's inputs", func() {
+				build, err := pipelineDB.RerunJobBuild(originalBuild)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(build.JobName()).To(Equal("some-job"))
+				Expect(build.Name()).To(Equal("2"))
+				Expect(build.Status()).To(Equal(db.StatusPending))
+				Expect(build.IsManuallyTriggered()).To(BeTrue())
+				Expect(build.RerunOf()).To(Equal(originalBuild.ID()))
+
+				inputs, _, err := build.GetResources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(ConsistOf(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: input.VersionedResource,
+					FirstOccurrence:   false,
+				}))
+
+				reloadedBuild, found, err := pipelineDB.GetJobBuild("some-job", "2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedBuild.RerunOf()).To(Equal(originalBuild.ID()))
+			})
+
+			It("does not link builds that are not re-runs", func() {
+				Expect(originalBuild.RerunOf()).To(BeZero())
+			})
+		})
+
 		Describe("saving build inputs", func() {
 			var (
 				buildMetadata []db.MetadataField
@@ -16,6 +16,9 @@ type SavedResource struct {
 	Paused       bool
 	PipelineName string
 	Config       atc.ResourceConfig
+
+	PinnedVersion atc.Version
+
 	Resource
 }
 
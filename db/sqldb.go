@@ -3,6 +3,8 @@ package db
 import (
 	"fmt"
 	"time"
+
+	"github.com/concourse/atc/archive"
 )
 
 type SQLDB struct {
@@ -17,12 +19,13 @@ func NewSQL(
 	sqldbConnection Conn,
 	bus *notificationsBus,
 	lockFactory LockFactory,
+	eventArchive archive.Store,
 ) *SQLDB {
 	return &SQLDB{
 		conn:         sqldbConnection,
 		lockFactory:  lockFactory,
 		bus:          bus,
-		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory),
+		buildFactory: newBuildFactory(sqldbConnection, bus, lockFactory, eventArchive),
 	}
 }
 
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// apiTokenUsageInterval is how long a token's last use is left as it is, so
+// that authenticating with a token doesn't write to the database on every
+// request.
+const apiTokenUsageInterval = time.Minute
+
+// UseAPIToken returns false if no token with the given hash exists (e.g. it
+// has been revoked), and otherwise records that it was just used, at most
+// once every apiTokenUsageInterval. Failing to record the use does not make
+// the token any less valid, so it is not reported as an error.
+func (db *SQLDB) UseAPIToken(tokenHash string) (bool, error) {
+	var stale bool
+	err := db.conn.QueryRow(`
+		SELECT last_used_at IS NULL OR now() - last_used_at > ($2 || ' SECONDS')::INTERVAL
+		FROM api_tokens
+		WHERE token_hash = $1
+	`, tokenHash, apiTokenUsageInterval.Seconds()).Scan(&stale)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if stale {
+		db.conn.Exec(`
+			UPDATE api_tokens
+			SET last_used_at = now()
+			WHERE token_hash = $1
+		`, tokenHash)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+const auditEventColumns = "id, route, username, team_name, target, status, created_at"
+
+func (db *SQLDB) SaveAuditEvent(event AuditEvent) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO audit_events (route, username, team_name, target, status)
+		VALUES ($1, $2, $3, $4, $5)
+	`, event.Route, event.User, event.Team, event.Target, event.Status)
+	return err
+}
+
+func (db *SQLDB) GetAuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	conditions := sq.Eq{}
+	if filter.Route != "" {
+		conditions["route"] = filter.Route
+	}
+
+	if filter.User != "" {
+		conditions["username"] = filter.User
+	}
+
+	if filter.Team != "" {
+		conditions["team_name"] = filter.Team
+	}
+
+	eventsQuery := sq.Select(auditEventColumns).From("audit_events")
+	idsQuery := sq.Select(
+		"COALESCE(MAX(id), 0) as maxID",
+		"COALESCE(MIN(id), 0) as minID",
+	).From("audit_events")
+
+	if len(conditions) > 0 {
+		eventsQuery = eventsQuery.Where(conditions)
+		idsQuery = idsQuery.Where(conditions)
+	}
+
+	if page.Since == 0 && page.Until == 0 {
+		eventsQuery = eventsQuery.OrderBy("id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		eventsQuery = eventsQuery.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC").Limit(uint64(page.Limit))
+		eventsQuery = sq.Select("sub.*").FromSelect(eventsQuery, "sub").OrderBy("sub.id DESC")
+	} else {
+		eventsQuery = eventsQuery.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC").Limit(uint64(page.Limit))
+	}
+
+	query, args, err := eventsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		var event AuditEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.Route,
+			&event.User,
+			&event.Team,
+			&event.Target,
+			&event.Status,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+
+	maxMinIDQuery, args, err := idsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	err = db.conn.QueryRow(maxMinIDQuery, args...).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
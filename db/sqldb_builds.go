@@ -78,6 +78,17 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 }
 
 func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
+	return db.reapBuildEvents(buildIDs, false)
+}
+
+// ArchiveBuildEventsByBuildIDs deletes the builds' events once they have been
+// written to the event archive. Unlike DeleteBuildEventsByBuildIDs the builds
+// are not marked as reaped, as their events are replayed from the archive.
+func (db *SQLDB) ArchiveBuildEventsByBuildIDs(buildIDs []int) error {
+	return db.reapBuildEvents(buildIDs, true)
+}
+
+func (db *SQLDB) reapBuildEvents(buildIDs []int, archived bool) error {
 	if len(buildIDs) == 0 {
 		return nil
 	}
@@ -107,9 +118,24 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	set := "events_archived = true"
+
+	// archived logs remain searchable, as they can still be replayed
+	if !archived {
+		set = "reap_time = now()"
+
+		_, err = tx.Exec(`
+			DELETE FROM build_log_lines
+			WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
+		`, interfaceBuildIDs...)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = tx.Exec(`
 		UPDATE builds
-		SET reap_time = now()
+		SET `+set+`
 		WHERE id IN (`+strings.Join(indexStrings, ",")+`)
 	`, interfaceBuildIDs...)
 	if err != nil {
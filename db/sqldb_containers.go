@@ -11,7 +11,7 @@ import (
 	"github.com/concourse/atc"
 )
 
-const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id"
+const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user, ttl, EXTRACT(epoch FROM expires_at - NOW()), c.id, resource_type_version, c.team_id, container_limits"
 
 const containerJoins = `
 		LEFT JOIN pipelines p
@@ -239,6 +239,17 @@ func (db *SQLDB) CreateContainer(
 		imageResourceType.Valid = true
 	}
 
+	var containerLimits sql.NullString
+	if container.ContainerLimits != (atc.ContainerLimits{}) {
+		marshaled, err := json.Marshal(container.ContainerLimits)
+		if err != nil {
+			return SavedContainer{}, err
+		}
+
+		containerLimits.String = string(marshaled)
+		containerLimits.Valid = true
+	}
+
 	maxLifetimeValue := "NULL"
 	if maxLifetime > 0 {
 		maxLifetimeValue = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(maxLifetime.Seconds()))
@@ -248,8 +259,8 @@ func (db *SQLDB) CreateContainer(
 		INSERT INTO containers (handle, resource_id, step_name, pipeline_id, build_id, type, worker_name,
 			expires_at, ttl, best_if_used_by, check_type, check_source, plan_id, working_directory,
 			env_variables, attempts, stage, image_resource_type, image_resource_source,
-			process_user, resource_type_version, team_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			process_user, resource_type_version, team_id, container_limits)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 		RETURNING id`,
 		container.Handle,
 		resourceID,
@@ -272,6 +283,7 @@ func (db *SQLDB) CreateContainer(
 		user,
 		resourceTypeVersion,
 		container.TeamID,
+		containerLimits,
 	).Scan(&id)
 	if err != nil {
 		return SavedContainer{}, err
@@ -438,6 +450,7 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		attempts            sql.NullString
 		ttlInSeconds        *float64
 		resourceTypeVersion []byte
+		containerLimits     sql.NullString
 	)
 	container := SavedContainer{}
 
@@ -466,6 +479,7 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		&container.ID,
 		&resourceTypeVersion,
 		&teamID,
+		&containerLimits,
 	)
 
 	if err != nil {
@@ -525,6 +539,13 @@ func scanContainer(row scannable) (SavedContainer, error) {
 		}
 	}
 
+	if containerLimits.Valid {
+		err = json.Unmarshal([]byte(containerLimits.String), &container.ContainerLimits)
+		if err != nil {
+			return SavedContainer{}, err
+		}
+	}
+
 	err = json.Unmarshal(envVariablesBlob, &container.EnvironmentVariables)
 	if err != nil {
 		return SavedContainer{}, err
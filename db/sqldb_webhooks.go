@@ -0,0 +1,112 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func (db *SQLDB) GetPendingWebhookDeliveries() ([]PendingWebhookDelivery, error) {
+	rows, err := db.conn.Query(`
+		SELECT ` + webhookDeliveryColumns + `, ` + webhookColumns + `
+		FROM webhook_deliveries d
+		INNER JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending'
+		AND d.next_attempt_at <= now()
+		ORDER BY d.id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pending := []PendingWebhookDelivery{}
+
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var webhook SavedWebhook
+		var buildStatus, status string
+		var deliveredAt pq.NullTime
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.BuildID,
+			&buildStatus,
+			&delivery.Payload,
+			&status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.Error,
+			&delivery.CreatedAt,
+			&delivery.NextAttemptAt,
+			&deliveredAt,
+			&webhook.ID,
+			&webhook.TeamID,
+			&webhook.Name,
+			&webhook.URL,
+			&webhook.Secret,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.BuildStatus = Status(buildStatus)
+		delivery.Status = WebhookDeliveryStatus(status)
+
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = deliveredAt.Time
+		}
+
+		pending = append(pending, PendingWebhookDelivery{
+			Delivery: delivery,
+			Webhook:  webhook,
+		})
+	}
+
+	return pending, nil
+}
+
+func (db *SQLDB) SucceedWebhookDelivery(deliveryID int, responseStatus int) error {
+	return db.updateWebhookDelivery(`
+		UPDATE webhook_deliveries
+		SET status = 'succeeded', attempts = attempts + 1, response_status = $2, error = '', delivered_at = now()
+		WHERE id = $1
+	`, deliveryID, responseStatus)
+}
+
+func (db *SQLDB) RetryWebhookDelivery(deliveryID int, responseStatus int, cause string, retryIn time.Duration) error {
+	return db.updateWebhookDelivery(`
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, response_status = $2, error = $3, next_attempt_at = now() + $4::interval
+		WHERE id = $1
+	`, deliveryID, responseStatus, cause, fmt.Sprintf("%d second", int(retryIn.Seconds())))
+}
+
+func (db *SQLDB) FailWebhookDelivery(deliveryID int, responseStatus int, cause string) error {
+	return db.updateWebhookDelivery(`
+		UPDATE webhook_deliveries
+		SET status = 'failed', attempts = attempts + 1, response_status = $2, error = $3
+		WHERE id = $1
+	`, deliveryID, responseStatus, cause)
+}
+
+func (db *SQLDB) updateWebhookDelivery(query string, deliveryID int, args ...interface{}) error {
+	result, err := db.conn.Exec(query, append([]interface{}{deliveryID}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
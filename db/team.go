@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -14,10 +15,13 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth"`
+
+	Roles atc.TeamRoleBindings `json:"roles"`
 }
 
 func (t Team) IsAuthConfigured() bool {
-	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
+	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil || t.LDAPAuth != nil
 }
 
 type BasicAuth struct {
@@ -82,3 +86,24 @@ type GenericOAuth struct {
 	DisplayName   string            `json:"display_name"`
 	Scope         string            `json:"scope"`
 }
+
+type LDAPAuth struct {
+	Host               string `json:"host"`
+	InsecureNoSSL      bool   `json:"insecure_no_ssl"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+	CACert             string `json:"ca_cert"`
+
+	BindDN       string `json:"bind_dn"`
+	BindPassword string `json:"bind_password"`
+
+	UserSearchBaseDN   string `json:"user_search_base_dn"`
+	UserSearchFilter   string `json:"user_search_filter"`
+	UserSearchUsername string `json:"user_search_username"`
+
+	GroupSearchBaseDN    string `json:"group_search_base_dn"`
+	GroupSearchFilter    string `json:"group_search_filter"`
+	GroupSearchGroupAttr string `json:"group_search_group_attr"`
+	GroupSearchNameAttr  string `json:"group_search_name_attr"`
+
+	Groups []string `json:"groups"`
+}
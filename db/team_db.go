@@ -27,9 +27,15 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error)
+	UpdateRoles(roles atc.TeamRoleBindings) (SavedTeam, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveConfigAs(author string, pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState) (SavedPipeline, bool, error)
+
+	GetConfigVersions(pipelineName string) ([]PipelineConfigVersion, bool, error)
+	GetConfigVersion(pipelineName string, version ConfigVersion) (PipelineConfigVersion, bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, error)
@@ -39,6 +45,19 @@ type TeamDB interface {
 	FindContainersByDescriptors(id Container) ([]SavedContainer, error)
 
 	GetVolumes() ([]SavedVolume, error)
+
+	GetWebhooks() ([]SavedWebhook, error)
+	GetWebhook(webhookName string) (SavedWebhook, bool, error)
+	SaveWebhook(webhook Webhook) (SavedWebhook, bool, error)
+	DeleteWebhook(webhookName string) (bool, error)
+	GetWebhookDeliveries(webhookName string) ([]WebhookDelivery, bool, error)
+	RedeliverWebhookDelivery(webhookName string, deliveryID int) (WebhookDelivery, bool, error)
+
+	SearchBuildLogs(search BuildLogSearch) ([]BuildLogMatches, error)
+
+	GetAPITokens() ([]SavedAPIToken, error)
+	CreateAPIToken(token APIToken) (SavedAPIToken, bool, error)
+	DeleteAPIToken(tokenName string) (bool, error)
 }
 
 type teamDB struct {
@@ -230,11 +249,105 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 	return config, atc.RawConfig(string(configBlob)), ConfigVersion(version), nil
 }
 
+func (db *teamDB) GetConfigVersions(pipelineName string) ([]PipelineConfigVersion, bool, error) {
+	pipeline, found, err := db.GetPipelineByName(pipelineName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT version, author, created_at, config
+		FROM pipeline_config_versions
+		WHERE pipeline_id = $1
+		ORDER BY version DESC
+	`, pipeline.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	versions := []PipelineConfigVersion{}
+
+	for rows.Next() {
+		version, err := scanPipelineConfigVersion(rows)
+		if err != nil {
+			return nil, false, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return versions, true, nil
+}
+
+func (db *teamDB) GetConfigVersion(pipelineName string, version ConfigVersion) (PipelineConfigVersion, bool, error) {
+	pipeline, found, err := db.GetPipelineByName(pipelineName)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	if !found {
+		return PipelineConfigVersion{}, false, nil
+	}
+
+	configVersion, err := scanPipelineConfigVersion(db.conn.QueryRow(`
+		SELECT version, author, created_at, config
+		FROM pipeline_config_versions
+		WHERE pipeline_id = $1
+		AND version = $2
+	`, pipeline.ID, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return configVersion, true, nil
+}
+
+func scanPipelineConfigVersion(row scannable) (PipelineConfigVersion, error) {
+	var version PipelineConfigVersion
+	var rawConfig string
+
+	err := row.Scan(&version.Version, &version.Author, &version.CreatedAt, &rawConfig)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	version.RawConfig = atc.RawConfig(rawConfig)
+
+	return version, nil
+}
+
 func (db *teamDB) SaveConfig(
 	pipelineName string,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.SaveConfigAs("", pipelineName, config, from, pausedState)
+}
+
+// SaveConfigAs saves the config like SaveConfig, recording who saved it in
+// the pipeline's config history.
+func (db *teamDB) SaveConfigAs(
+	author string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
 ) (SavedPipeline, bool, error) {
 	payload, err := json.Marshal(config)
 	if err != nil {
@@ -390,6 +503,14 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, author)
+		VALUES ($1, $2, $3, $4)
+	`, savedPipeline.ID, savedPipeline.Version, payload, author)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	for _, resource := range config.Resources {
 		err = db.saveResource(tx, resource, savedPipeline.ID)
 		if err != nil {
@@ -520,7 +641,7 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -538,7 +659,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, ldapAuth, roles sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -555,6 +676,8 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&ldapAuth,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -592,6 +715,20 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if ldapAuth.Valid {
+		err = json.Unmarshal([]byte(ldapAuth.String), &savedTeam.LDAPAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &savedTeam.Roles)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -605,7 +742,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -627,7 +764,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -643,7 +780,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -659,12 +796,44 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateLDAPAuth(ldapAuth *LDAPAuth) (SavedTeam, error) {
+	jsonEncodedLDAPAuth, err := json.Marshal(ldapAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET ldap_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
+	`
+	params := []interface{}{string(jsonEncodedLDAPAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateRoles(roles atc.TeamRoleBindings) (SavedTeam, error) {
+	jsonEncodedRoles, err := json.Marshal(roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET roles = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, ldap_auth, roles
+	`
+	params := []interface{}{string(jsonEncodedRoles), db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -0,0 +1,67 @@
+package db
+
+import "database/sql"
+
+func (db *teamDB) GetAPITokens() ([]SavedAPIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		WHERE a.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($1)
+		)
+		ORDER BY a.name
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAPITokens(rows)
+}
+
+// CreateAPIToken saves a new token, returning false if the team already has a
+// token with the same name.
+func (db *teamDB) CreateAPIToken(token APIToken) (SavedAPIToken, bool, error) {
+	savedToken, err := scanAPIToken(db.conn.QueryRow(`
+		WITH a AS (
+			INSERT INTO api_tokens (team_id, name, role, token_hash)
+			SELECT t.id, $2, $3, $4
+			FROM teams t
+			WHERE LOWER(t.name) = LOWER($1)
+			AND NOT EXISTS (
+				SELECT 1 FROM api_tokens e WHERE e.team_id = t.id AND e.name = $2
+			)
+			RETURNING *
+		)
+		SELECT `+apiTokenColumns+`
+		FROM a
+	`, db.teamName, token.Name, string(token.Role), token.TokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedAPIToken{}, false, nil
+		}
+
+		return SavedAPIToken{}, false, err
+	}
+
+	return savedToken, true, nil
+}
+
+func (db *teamDB) DeleteAPIToken(tokenName string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+	`, tokenName, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,170 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API tokens", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB       *db.SQLDB
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateAPIToken", func() {
+		It("creates the token", func() {
+			created, isNew, err := teamDB.CreateAPIToken(db.APIToken{
+				Name:      "some-token",
+				Role:      atc.TeamRoleOperator,
+				TokenHash: "some-hash",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNew).To(BeTrue())
+			Expect(created.Name).To(Equal("some-token"))
+			Expect(created.Role).To(Equal(atc.TeamRoleOperator))
+			Expect(created.TokenHash).To(Equal("some-hash"))
+			Expect(created.CreatedAt).NotTo(BeZero())
+			Expect(created.LastUsedAt).To(BeZero())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]db.SavedAPIToken{created}))
+		})
+
+		It("does not replace a token with the same name", func() {
+			_, _, err := teamDB.CreateAPIToken(db.APIToken{Name: "some-token", Role: atc.TeamRoleViewer, TokenHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, isNew, err := teamDB.CreateAPIToken(db.APIToken{Name: "some-token", Role: atc.TeamRoleOwner, TokenHash: "other-hash"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNew).To(BeFalse())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].TokenHash).To(Equal("some-hash"))
+		})
+
+		It("scopes tokens to the team", func() {
+			_, _, err := teamDB.CreateAPIToken(db.APIToken{Name: "some-token", Role: atc.TeamRoleViewer, TokenHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, isNew, err := otherTeamDB.CreateAPIToken(db.APIToken{Name: "some-token", Role: atc.TeamRoleViewer, TokenHash: "other-hash"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNew).To(BeTrue())
+
+			found, err := otherTeamDB.DeleteAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+		})
+	})
+
+	Describe("UseAPIToken", func() {
+		BeforeEach(func() {
+			_, _, err := teamDB.CreateAPIToken(db.APIToken{Name: "some-token", Role: atc.TeamRoleViewer, TokenHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records when the token was last used", func() {
+			found, err := sqlDB.UseAPIToken("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("does not record its use again within a minute", func() {
+			_, err := dbConn.Exec(`UPDATE api_tokens SET last_used_at = now() - interval '30 seconds'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := sqlDB.UseAPIToken("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now().Add(-30*time.Second), 10*time.Second))
+		})
+
+		It("records its use again once a minute has passed", func() {
+			_, err := dbConn.Exec(`UPDATE api_tokens SET last_used_at = now() - interval '2 minutes'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := sqlDB.UseAPIToken("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens[0].LastUsedAt).To(BeTemporally("~", time.Now(), 10*time.Second))
+		})
+
+		It("does not find unknown tokens", func() {
+			found, err := sqlDB.UseAPIToken("bogus-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find revoked tokens", func() {
+			found, err := teamDB.DeleteAPIToken("some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = sqlDB.UseAPIToken("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
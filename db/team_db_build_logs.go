@@ -0,0 +1,143 @@
+package db
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	maxBuildLogMatchesPerBuild = 10
+	buildLogExcerptLength      = 200
+)
+
+type BuildLogSearch struct {
+	Query string
+
+	PipelineName string
+	JobName      string
+
+	// Limit is the maximum number of builds to return.
+	Limit int
+}
+
+type BuildLogMatches struct {
+	BuildID int
+	Lines   []BuildLogLine
+}
+
+type BuildLogLine struct {
+	OriginID string
+	Excerpt  string
+}
+
+func (db *teamDB) SearchBuildLogs(search BuildLogSearch) ([]BuildLogMatches, error) {
+	matchingLines := sq.Select(
+		"l.id",
+		"l.build_id",
+		"l.origin_id",
+		"l.line",
+		"row_number() OVER (PARTITION BY l.build_id ORDER BY l.id) AS line_rank",
+		"dense_rank() OVER (ORDER BY l.build_id DESC) AS build_rank",
+	).
+		From("build_log_lines l").
+		Join("builds b ON b.id = l.build_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Expr("b.team_id = (SELECT id FROM teams WHERE LOWER(name) = LOWER(?))", db.teamName)).
+		// the query may appear anywhere in the line, even mid-word; only the
+		// pg_trgm index, when the operator has installed the extension, keeps
+		// this from scanning every line
+		Where(sq.Expr("l.line ILIKE ?", "%"+escapeLike(search.Query)+"%"))
+
+	if search.PipelineName != "" {
+		matchingLines = matchingLines.Where(sq.Eq{"p.name": search.PipelineName})
+	}
+
+	if search.JobName != "" {
+		matchingLines = matchingLines.Where(sq.Eq{"j.name": search.JobName})
+	}
+
+	query, args, err := sq.Select("build_id", "origin_id", "line").
+		FromSelect(matchingLines, "matches").
+		Where(sq.Expr("line_rank <= ?", maxBuildLogMatchesPerBuild)).
+		Where(sq.Expr("build_rank <= ?", search.Limit)).
+		OrderBy("build_id DESC", "id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	matches := []BuildLogMatches{}
+
+	for rows.Next() {
+		var buildID int
+		var originID, line string
+		err := rows.Scan(&buildID, &originID, &line)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 || matches[len(matches)-1].BuildID != buildID {
+			matches = append(matches, BuildLogMatches{BuildID: buildID})
+		}
+
+		current := &matches[len(matches)-1]
+		current.Lines = append(current.Lines, BuildLogLine{
+			OriginID: originID,
+			Excerpt:  excerpt(line, search.Query),
+		})
+	}
+
+	return matches, rows.Err()
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the query so that it's matched literally by LIKE.
+func escapeLike(query string) string {
+	return likeEscaper.Replace(query)
+}
+
+// excerpt trims long lines to a window around the first occurrence of the
+// query.
+func excerpt(line string, query string) string {
+	if len(line) <= buildLogExcerptLength {
+		return line
+	}
+
+	start := strings.Index(strings.ToLower(line), strings.ToLower(query))
+	if start == -1 {
+		start = 0
+	}
+
+	start -= (buildLogExcerptLength - len(query)) / 2
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + buildLogExcerptLength
+	if end > len(line) {
+		end = len(line)
+		start = end - buildLogExcerptLength
+	}
+
+	for start > 0 && !utf8.RuneStart(line[start]) {
+		start--
+	}
+
+	for end < len(line) && !utf8.RuneStart(line[end]) {
+		end++
+	}
+
+	return line[start:end]
+}
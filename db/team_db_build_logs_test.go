@@ -0,0 +1,269 @@
+package db_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build log search", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB       *db.SQLDB
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+		pipelineDB  db.PipelineDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+		pipeline, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "other-job"},
+			},
+		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDB = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil).Build(pipeline)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	saveLog := func(build db.Build, originID string, payload string) {
+		err := build.SaveEvent(event.Log{
+			Origin:  event.Origin{ID: event.OriginID(originID)},
+			Payload: payload,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	search := func(teamDB db.TeamDB, search db.BuildLogSearch) []db.BuildLogMatches {
+		if search.Limit == 0 {
+			search.Limit = 10
+		}
+
+		matches, err := teamDB.SearchBuildLogs(search)
+		Expect(err).NotTo(HaveOccurred())
+
+		return matches
+	}
+
+	It("finds the lines of log events containing the query, grouped by build, newest first", func() {
+		build1, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build1, "some-step", "fetching\ndial tcp: Connection refused\r\n")
+		saveLog(build1, "other-step", "connection refused again\n")
+
+		build2, err := teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build2, "one-off", "\x1b[31mconnection refused\x1b[0m\n")
+
+		build3, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build3, "some-step", "all good\n")
+
+		err = build3.SaveEvent(event.Status{Status: atc.StatusSucceeded})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "connection refused"})).To(Equal([]db.BuildLogMatches{
+			{
+				BuildID: build2.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "one-off", Excerpt: "connection refused"},
+				},
+			},
+			{
+				BuildID: build1.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "some-step", Excerpt: "dial tcp: Connection refused"},
+					{OriginID: "other-step", Excerpt: "connection refused again"},
+				},
+			},
+		}))
+
+		By("requiring the query to appear verbatim")
+		Expect(search(teamDB, db.BuildLogSearch{Query: "refused connection"})).To(BeEmpty())
+	})
+
+	It("finds queries which are only part of a word", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build, "some-step", "dial tcp: connection refused\n")
+		saveLog(build, "other-step", "--- FAIL: TestFooBar (0.01s)\n")
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "refus"})).To(Equal([]db.BuildLogMatches{
+			{
+				BuildID: build.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "some-step", Excerpt: "dial tcp: connection refused"},
+				},
+			},
+		}))
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "TestFoo"})).To(Equal([]db.BuildLogMatches{
+			{
+				BuildID: build.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "other-step", Excerpt: "--- FAIL: TestFooBar (0.01s)"},
+				},
+			},
+		}))
+	})
+
+	It("finds queries made up only of punctuation, matching % and _ literally", func() {
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build, "some-step", "x := 42\n")
+		saveLog(build, "other-step", "100% done!!!\n")
+		saveLog(build, "another-step", "some_var = 1\n")
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: ":="})).To(Equal([]db.BuildLogMatches{
+			{
+				BuildID: build.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "some-step", Excerpt: "x := 42"},
+				},
+			},
+		}))
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "!!!"})).To(Equal([]db.BuildLogMatches{
+			{
+				BuildID: build.ID(),
+				Lines: []db.BuildLogLine{
+					{OriginID: "other-step", Excerpt: "100% done!!!"},
+				},
+			},
+		}))
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "0%"})).To(HaveLen(1))
+		Expect(search(teamDB, db.BuildLogSearch{Query: "%"})[0].Lines).To(HaveLen(1))
+		Expect(search(teamDB, db.BuildLogSearch{Query: "e_v"})[0].Lines).To(Equal([]db.BuildLogLine{
+			{OriginID: "another-step", Excerpt: "some_var = 1"},
+		}))
+		Expect(search(teamDB, db.BuildLogSearch{Query: "x_:"})).To(BeEmpty())
+	})
+
+	It("filters by pipeline and job", func() {
+		someJobBuild, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(someJobBuild, "some-step", "flaky test failed\n")
+
+		otherJobBuild, err := pipelineDB.CreateJobBuild("other-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(otherJobBuild, "some-step", "flaky test failed\n")
+
+		oneOffBuild, err := teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(oneOffBuild, "some-step", "flaky test failed\n")
+
+		buildIDs := func(matches []db.BuildLogMatches) []int {
+			ids := []int{}
+			for _, match := range matches {
+				ids = append(ids, match.BuildID)
+			}
+			return ids
+		}
+
+		Expect(buildIDs(search(teamDB, db.BuildLogSearch{
+			Query:        "flaky",
+			PipelineName: "some-pipeline",
+		}))).To(Equal([]int{otherJobBuild.ID(), someJobBuild.ID()}))
+
+		Expect(buildIDs(search(teamDB, db.BuildLogSearch{
+			Query:        "flaky",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}))).To(Equal([]int{someJobBuild.ID()}))
+
+		Expect(buildIDs(search(teamDB, db.BuildLogSearch{
+			Query: "flaky",
+			Limit: 2,
+		}))).To(Equal([]int{oneOffBuild.ID(), otherJobBuild.ID()}))
+	})
+
+	It("only searches the team's own builds", func() {
+		build, err := otherTeamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build, "some-step", "secret stuff\n")
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "secret"})).To(BeEmpty())
+		Expect(search(otherTeamDB, db.BuildLogSearch{Query: "secret"})).To(HaveLen(1))
+	})
+
+	It("trims long lines to an excerpt around the match", func() {
+		build, err := teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build, "some-step", strings.Repeat("a", 500)+" needle "+strings.Repeat("b", 500)+"\n")
+
+		matches := search(teamDB, db.BuildLogSearch{Query: "needle"})
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Lines).To(HaveLen(1))
+
+		excerpt := matches[0].Lines[0].Excerpt
+		Expect(len(excerpt)).To(Equal(200))
+		Expect(excerpt).To(ContainSubstring(" needle "))
+	})
+
+	It("forgets the logs of reaped builds", func() {
+		build, err := teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		saveLog(build, "some-step", "needle\n")
+
+		err = build.Finish(db.StatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search(teamDB, db.BuildLogSearch{Query: "needle"})).To(BeEmpty())
+	})
+})
@@ -46,14 +46,14 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		database = db.NewSQL(dbConn, bus, lockFactory)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		var err error
 		team, err = database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
 		config = atc.Config{
@@ -837,6 +837,49 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(invalidConfigVersion).NotTo(Equal(db.ConfigVersion(1)))
 	})
 
+	It("keeps every saved config in the pipeline's history", func() {
+		_, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, firstVersion, err := teamDB.GetConfig("a-pipeline-name")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = teamDB.SaveConfig("a-pipeline-name", otherConfig, firstVersion, db.PipelineNoChange)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, secondVersion, err := teamDB.GetConfig("a-pipeline-name")
+		Expect(err).NotTo(HaveOccurred())
+
+		versions, found, err := teamDB.GetConfigVersions("a-pipeline-name")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(versions).To(HaveLen(2))
+
+		Expect(versions[0].Version).To(Equal(secondVersion))
+		Expect(versions[0].Author).To(BeEmpty())
+		Expect(versions[1].Version).To(Equal(firstVersion))
+		Expect(versions[1].Author).To(Equal("some-team"))
+		Expect(versions[1].CreatedAt).NotTo(BeZero())
+
+		firstConfig, found, err := teamDB.GetConfigVersion("a-pipeline-name", firstVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(firstConfig.Author).To(Equal("some-team"))
+		Expect(firstConfig.Config()).To(Equal(config))
+
+		_, found, err = teamDB.GetConfigVersion("a-pipeline-name", secondVersion+1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = teamDB.GetConfigVersions("bogus-pipeline")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = teamDBFactory.GetTeamDB(atc.DefaultTeamName).GetConfigVersion("a-pipeline-name", firstVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
 	Context("when there are multiple teams", func() {
 		var otherTeamDB db.TeamDB
 
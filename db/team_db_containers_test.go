@@ -44,8 +44,8 @@ var _ = Describe("TeamDbContainers", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		team := db.Team{Name: "team-name"}
 		savedTeam, err := database.CreateTeam(team)
@@ -59,7 +59,7 @@ var _ = Describe("TeamDbContainers", func() {
 
 		teamDB = teamDBFactory.GetTeamDB("team-name")
 
-		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		config := atc.Config{
 			Jobs: atc.JobConfigs{
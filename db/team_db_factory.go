@@ -1,5 +1,7 @@
 package db
 
+import "github.com/concourse/atc/archive"
+
 //go:generate counterfeiter . TeamDBFactory
 
 type TeamDBFactory interface {
@@ -7,16 +9,18 @@ type TeamDBFactory interface {
 }
 
 type teamDBFactory struct {
-	conn        Conn
-	bus         *notificationsBus
-	lockFactory LockFactory
+	conn         Conn
+	bus          *notificationsBus
+	lockFactory  LockFactory
+	eventArchive archive.Store
 }
 
-func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory) TeamDBFactory {
+func NewTeamDBFactory(conn Conn, bus *notificationsBus, lockFactory LockFactory, eventArchive archive.Store) TeamDBFactory {
 	return &teamDBFactory{
-		conn:        conn,
-		bus:         bus,
-		lockFactory: lockFactory,
+		conn:         conn,
+		bus:          bus,
+		lockFactory:  lockFactory,
+		eventArchive: eventArchive,
 	}
 }
 
@@ -24,6 +28,6 @@ func (f *teamDBFactory) GetTeamDB(teamName string) TeamDB {
 	return &teamDB{
 		teamName:     teamName,
 		conn:         f.conn,
-		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory),
+		buildFactory: newBuildFactory(f.conn, f.bus, f.lockFactory, f.eventArchive),
 	}
 }
@@ -44,8 +44,8 @@ var _ = Describe("TeamDB", func() {
 		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
 
 		lockFactory := db.NewLockFactory(retryableConn)
-		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
-		database = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		database = db.NewSQL(dbConn, bus, lockFactory, nil)
 
 		team := db.Team{Name: "TEAM-name"}
 		var err error
@@ -55,7 +55,7 @@ var _ = Describe("TeamDB", func() {
 		teamDB = teamDBFactory.GetTeamDB("team-NAME")
 		nonExistentTeamDB = teamDBFactory.GetTeamDB("non-existent-name")
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory, nil)
 
 		team = db.Team{Name: "other-team-name"}
 		otherSavedTeam, err = database.CreateTeam(team)
@@ -272,6 +272,7 @@ var _ = Describe("TeamDB", func() {
 		var gitHubAuth *db.GitHubAuth
 		var uaaAuth *db.UAAAuth
 		var genericOAuth *db.GenericOAuth
+		var ldapAuth *db.LDAPAuth
 
 		BeforeEach(func() {
 			basicAuth = &db.BasicAuth{
@@ -314,6 +315,16 @@ var _ = Describe("TeamDB", func() {
 				Scope:         "read",
 				TokenURL:      "https://token.url",
 			}
+
+			ldapAuth = &db.LDAPAuth{
+				Host:               "ldap.example.com:636",
+				BindDN:             "cn=concourse,dc=example,dc=com",
+				BindPassword:       "some-password",
+				UserSearchBaseDN:   "ou=people,dc=example,dc=com",
+				UserSearchUsername: "uid",
+				GroupSearchBaseDN:  "ou=groups,dc=example,dc=com",
+				Groups:             []string{"some-group"},
+			}
 		})
 
 		Describe("UpdateBasicAuth", func() {
@@ -446,6 +457,55 @@ var _ = Describe("TeamDB", func() {
 				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
 			})
 		})
+
+		Describe("UpdateLDAPAuth", func() {
+			It("saves ldap auth info to the existing team", func() {
+				savedTeam, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(Equal(ldapAuth))
+
+				savedTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedTeam.LDAPAuth).To(Equal(ldapAuth))
+			})
+
+			It("clears ldap auth", func() {
+				_, err := teamDB.UpdateLDAPAuth(ldapAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateLDAPAuth(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.LDAPAuth).To(BeNil())
+			})
+		})
+
+		Describe("UpdateRoles", func() {
+			roles := atc.TeamRoleBindings{
+				{Role: atc.TeamRoleOwner, Users: []string{"some-user"}},
+				{Role: atc.TeamRoleViewer, Groups: []string{"some-org/some-team"}},
+			}
+
+			It("saves the roles to the existing team", func() {
+				savedTeam, err := teamDB.UpdateRoles(roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.Roles).To(Equal(roles))
+
+				savedTeam, found, err := teamDB.GetTeam()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedTeam.Roles).To(Equal(roles))
+			})
+
+			It("does not overwrite the team's auth", func() {
+				_, err := teamDB.UpdateGenericOAuth(genericOAuth)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedTeam, err := teamDB.UpdateRoles(roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
+			})
+		})
 	})
 
 	Describe("GetTeam", func() {
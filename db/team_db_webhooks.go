@@ -0,0 +1,164 @@
+package db
+
+import "database/sql"
+
+func (db *teamDB) GetWebhooks() ([]SavedWebhook, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+webhookColumns+`
+		FROM webhooks w
+		WHERE w.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($1)
+		)
+		ORDER BY w.name
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWebhooks(rows)
+}
+
+func (db *teamDB) GetWebhook(webhookName string) (SavedWebhook, bool, error) {
+	webhook, err := scanWebhook(db.conn.QueryRow(`
+		SELECT `+webhookColumns+`
+		FROM webhooks w
+		WHERE w.name = $1
+		AND w.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+	`, webhookName, db.teamName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedWebhook{}, false, nil
+		}
+
+		return SavedWebhook{}, false, err
+	}
+
+	return webhook, true, nil
+}
+
+// SaveWebhook creates or updates the named webhook, returning whether it was
+// newly created.
+func (db *teamDB) SaveWebhook(webhook Webhook) (SavedWebhook, bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return SavedWebhook{}, false, err
+	}
+
+	defer tx.Rollback()
+
+	created := false
+
+	savedWebhook, err := scanWebhook(tx.QueryRow(`
+		UPDATE webhooks w
+		SET url = $1, secret = $2
+		WHERE w.name = $3
+		AND w.team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($4)
+		)
+		RETURNING `+webhookColumns+`
+	`, webhook.URL, webhook.Secret, webhook.Name, db.teamName))
+	if err == sql.ErrNoRows {
+		created = true
+
+		savedWebhook, err = scanWebhook(tx.QueryRow(`
+			INSERT INTO webhooks (team_id, name, url, secret)
+			VALUES (
+				(SELECT id FROM teams WHERE LOWER(name) = LOWER($1)),
+				$2, $3, $4
+			)
+			RETURNING id, team_id, name, url, secret
+		`, db.teamName, webhook.Name, webhook.URL, webhook.Secret))
+	}
+	if err != nil {
+		return SavedWebhook{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SavedWebhook{}, false, err
+	}
+
+	return savedWebhook, created, nil
+}
+
+func (db *teamDB) DeleteWebhook(webhookName string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM webhooks
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+	`, webhookName, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (db *teamDB) GetWebhookDeliveries(webhookName string) ([]WebhookDelivery, bool, error) {
+	webhook, found, err := db.GetWebhook(webhookName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.id DESC
+		LIMIT 100
+	`, webhook.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return deliveries, true, nil
+}
+
+// RedeliverWebhookDelivery queues a new delivery with the same payload as an
+// earlier one, leaving the original in place for the delivery log.
+func (db *teamDB) RedeliverWebhookDelivery(webhookName string, deliveryID int) (WebhookDelivery, bool, error) {
+	webhook, found, err := db.GetWebhook(webhookName)
+	if err != nil {
+		return WebhookDelivery{}, false, err
+	}
+
+	if !found {
+		return WebhookDelivery{}, false, nil
+	}
+
+	delivery, err := scanWebhookDelivery(db.conn.QueryRow(`
+		INSERT INTO webhook_deliveries (webhook_id, build_id, build_status, payload)
+		SELECT webhook_id, build_id, build_status, payload
+		FROM webhook_deliveries
+		WHERE id = $1
+		AND webhook_id = $2
+		RETURNING id, webhook_id, build_id, build_status, payload, status, attempts, response_status, error, created_at, next_attempt_at, delivered_at
+	`, deliveryID, webhook.ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WebhookDelivery{}, false, nil
+		}
+
+		return WebhookDelivery{}, false, err
+	}
+
+	return delivery, true, nil
+}
@@ -0,0 +1,267 @@
+package db_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB       *db.SQLDB
+		teamDB      db.TeamDB
+		otherTeamDB db.TeamDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory, nil)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory, nil)
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("SaveWebhook", func() {
+		It("creates the webhook, then updates it", func() {
+			created, isNew, err := teamDB.SaveWebhook(db.Webhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+				Secret: "shh",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNew).To(BeTrue())
+			Expect(created.Name).To(Equal("some-webhook"))
+
+			updated, isNew, err := teamDB.SaveWebhook(db.Webhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/other-hook",
+				Secret: "shh",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNew).To(BeFalse())
+			Expect(updated.ID).To(Equal(created.ID))
+			Expect(updated.URL).To(Equal("https://example.com/other-hook"))
+
+			webhooks, err := teamDB.GetWebhooks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhooks).To(Equal([]db.SavedWebhook{updated}))
+		})
+
+		It("scopes webhooks to the team", func() {
+			_, _, err := teamDB.SaveWebhook(db.Webhook{Name: "some-webhook", URL: "https://example.com/hook"})
+			Expect(err).NotTo(HaveOccurred())
+
+			webhooks, err := otherTeamDB.GetWebhooks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhooks).To(BeEmpty())
+
+			_, found, err := otherTeamDB.GetWebhook("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			found, err = otherTeamDB.DeleteWebhook("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("DeleteWebhook", func() {
+		It("removes the webhook", func() {
+			_, _, err := teamDB.SaveWebhook(db.Webhook{Name: "some-webhook", URL: "https://example.com/hook"})
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := teamDB.DeleteWebhook("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = teamDB.GetWebhook("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("build status transitions", func() {
+		var build db.Build
+		var webhook db.SavedWebhook
+
+		BeforeEach(func() {
+			var err error
+			webhook, _, err = teamDB.SaveWebhook(db.Webhook{Name: "some-webhook", URL: "https://example.com/hook"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = otherTeamDB.SaveWebhook(db.Webhook{Name: "other-webhook", URL: "https://example.com/other-hook"})
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("queues a delivery to the team's webhooks for each transition", func() {
+			started, err := build.Start("some-engine", "some-metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, found, err := teamDB.GetWebhookDeliveries("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].BuildStatus).To(Equal(db.StatusSucceeded))
+			Expect(deliveries[1].BuildStatus).To(Equal(db.StatusStarted))
+
+			for _, delivery := range deliveries {
+				Expect(delivery.WebhookID).To(Equal(webhook.ID))
+				Expect(delivery.BuildID).To(Equal(build.ID()))
+				Expect(delivery.Status).To(Equal(db.WebhookDeliveryPending))
+			}
+
+			var payload atc.Build
+			err = json.Unmarshal([]byte(deliveries[0].Payload), &payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload.ID).To(Equal(build.ID()))
+			Expect(payload.TeamName).To(Equal("some-team"))
+			Expect(payload.Status).To(Equal("succeeded"))
+			Expect(payload.StartTime).NotTo(BeZero())
+			Expect(payload.EndTime).NotTo(BeZero())
+
+			otherDeliveries, _, err := otherTeamDB.GetWebhookDeliveries("other-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherDeliveries).To(BeEmpty())
+		})
+
+		It("queues a single delivery when an aborted build is finished as aborted", func() {
+			err := build.Abort()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.StatusAborted)
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, _, err := teamDB.GetWebhookDeliveries("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].BuildStatus).To(Equal(db.StatusAborted))
+		})
+
+		It("queues an errored delivery when the build is marked as failed", func() {
+			err := build.MarkAsFailed(errors.New("disaster"))
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, _, err := teamDB.GetWebhookDeliveries("some-webhook")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].BuildStatus).To(Equal(db.StatusErrored))
+		})
+
+		Describe("delivering", func() {
+			var delivery db.WebhookDelivery
+
+			BeforeEach(func() {
+				_, err := build.Start("some-engine", "some-metadata")
+				Expect(err).NotTo(HaveOccurred())
+
+				pending, err := sqlDB.GetPendingWebhookDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pending).To(HaveLen(1))
+				Expect(pending[0].Webhook).To(Equal(webhook))
+
+				delivery = pending[0].Delivery
+			})
+
+			It("records a successful delivery", func() {
+				err := sqlDB.SucceedWebhookDelivery(delivery.ID, 200)
+				Expect(err).NotTo(HaveOccurred())
+
+				pending, err := sqlDB.GetPendingWebhookDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+
+				deliveries, _, err := teamDB.GetWebhookDeliveries("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries[0].Status).To(Equal(db.WebhookDeliverySucceeded))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].ResponseStatus).To(Equal(200))
+				Expect(deliveries[0].DeliveredAt).NotTo(BeZero())
+			})
+
+			It("defers a retried delivery until it is due", func() {
+				err := sqlDB.RetryWebhookDelivery(delivery.ID, 500, "boom", time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+
+				pending, err := sqlDB.GetPendingWebhookDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+
+				deliveries, _, err := teamDB.GetWebhookDeliveries("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries[0].Status).To(Equal(db.WebhookDeliveryPending))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].Error).To(Equal("boom"))
+				Expect(deliveries[0].NextAttemptAt).To(BeTemporally(">", time.Now().Add(59*time.Minute)))
+			})
+
+			It("records a failed delivery, which can be redelivered", func() {
+				err := sqlDB.FailWebhookDelivery(delivery.ID, 500, "boom")
+				Expect(err).NotTo(HaveOccurred())
+
+				redelivery, found, err := teamDB.RedeliverWebhookDelivery("some-webhook", delivery.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(redelivery.ID).NotTo(Equal(delivery.ID))
+				Expect(redelivery.Payload).To(Equal(delivery.Payload))
+				Expect(redelivery.Status).To(Equal(db.WebhookDeliveryPending))
+
+				pending, err := sqlDB.GetPendingWebhookDeliveries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pending).To(HaveLen(1))
+				Expect(pending[0].Delivery.ID).To(Equal(redelivery.ID))
+			})
+
+			It("does not redeliver another team's delivery", func() {
+				_, found, err := otherTeamDB.RedeliverWebhookDelivery("other-webhook", delivery.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
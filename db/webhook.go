@@ -0,0 +1,140 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Webhook struct {
+	Name   string
+	URL    string
+	Secret string
+}
+
+type SavedWebhook struct {
+	ID     int
+	TeamID int
+
+	Webhook
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID          int
+	WebhookID   int
+	BuildID     int
+	BuildStatus Status
+	Payload     string
+
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus int
+	Error          string
+
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	DeliveredAt   time.Time
+}
+
+// PendingWebhookDelivery is a delivery that is due to be attempted, along
+// with the webhook it is to be sent to.
+type PendingWebhookDelivery struct {
+	Delivery WebhookDelivery
+	Webhook  SavedWebhook
+}
+
+const webhookColumns = "w.id, w.team_id, w.name, w.url, w.secret"
+const webhookDeliveryColumns = "d.id, d.webhook_id, d.build_id, d.build_status, d.payload, d.status, d.attempts, d.response_status, d.error, d.created_at, d.next_attempt_at, d.delivered_at"
+
+func scanWebhook(row scannable) (SavedWebhook, error) {
+	var webhook SavedWebhook
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.TeamID,
+		&webhook.Name,
+		&webhook.URL,
+		&webhook.Secret,
+	)
+	if err != nil {
+		return SavedWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func scanWebhooks(rows *sql.Rows) ([]SavedWebhook, error) {
+	defer rows.Close()
+
+	webhooks := []SavedWebhook{}
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhookDelivery(row scannable) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	var buildStatus, status string
+	var deliveredAt pq.NullTime
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.BuildID,
+		&buildStatus,
+		&delivery.Payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.ResponseStatus,
+		&delivery.Error,
+		&delivery.CreatedAt,
+		&delivery.NextAttemptAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+
+	delivery.BuildStatus = Status(buildStatus)
+	delivery.Status = WebhookDeliveryStatus(status)
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = deliveredAt.Time
+	}
+
+	return delivery, nil
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
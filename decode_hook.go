@@ -48,6 +48,33 @@ var VersionConfigDecodeHook = func(
 	return data, nil
 }
 
+var AttemptsConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(AttemptsConfig{}) {
+		return data, nil
+	}
+
+	switch srcType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return AttemptsConfig{
+			Count: int(reflect.ValueOf(data).Int()),
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return AttemptsConfig{
+			Count: int(reflect.ValueOf(data).Uint()),
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return AttemptsConfig{
+			Count: int(reflect.ValueOf(data).Float()),
+		}, nil
+	}
+
+	return data, nil
+}
+
 var SanitizeDecodeHook = func(
 	dataKind reflect.Kind,
 	valKind reflect.Kind,
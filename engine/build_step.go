@@ -1,9 +1,13 @@
 package engine
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/condition"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
@@ -22,6 +26,23 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -61,6 +82,22 @@ func (build *execBuild) buildOnSuccessStep(logger lager.Logger, plan atc.Plan) e
 	return exec.OnSuccess(step, next)
 }
 
+func (build *execBuild) buildOnErrorStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnError.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnError.Step)
+	plan.OnError.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnError.Next)
+	return exec.OnError(step, next)
+}
+
+func (build *execBuild) buildOnAbortStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	plan.OnAbort.Step.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, plan.OnAbort.Step)
+	plan.OnAbort.Next.Attempts = plan.Attempts
+	next := build.buildStepFactory(logger, plan.OnAbort.Next)
+	return exec.OnAbort(step, next)
+}
+
 func (build *execBuild) buildOnFailureStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	plan.OnFailure.Step.Attempts = plan.Attempts
 	step := build.buildStepFactory(logger, plan.OnFailure.Step)
@@ -141,6 +178,10 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		"get",
 	)
 
+	if plan.Get.ContainerLimits != nil {
+		workerMetadata.ContainerLimits = *plan.Get.ContainerLimits
+	}
+
 	return build.factory.Get(
 		logger,
 		build.stepMetadata,
@@ -177,6 +218,10 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		"put",
 	)
 
+	if plan.Put.ContainerLimits != nil {
+		workerMetadata.ContainerLimits = *plan.Put.ContainerLimits
+	}
+
 	return build.factory.Put(
 		logger,
 		build.stepMetadata,
@@ -212,6 +257,10 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		"get",
 	)
 
+	if getPlan.ContainerLimits != nil {
+		workerMetadata.ContainerLimits = *getPlan.ContainerLimits
+	}
+
 	return build.factory.DependentGet(
 		logger,
 		build.stepMetadata,
@@ -236,14 +285,63 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
-	step := exec.Retry{}
+	step := exec.Retry{
+		Backoff:    plan.Retry.Backoff,
+		MaxBackoff: plan.Retry.MaxBackoff,
+		On:         plan.Retry.On,
+		Delegate:   build.delegate.RetryDelegate(logger, event.OriginID(plan.ID)),
+		Clock:      clock.NewClock(),
+	}
 
-	for index, innerPlan := range *plan.Retry {
+	for index, innerPlan := range plan.Retry.Attempts {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		stepFactory := build.buildStepFactory(logger, innerPlan)
-		step = append(step, stepFactory)
+		step.Attempts = append(step.Attempts, stepFactory)
 	}
 
 	return step
 }
+
+func (build *execBuild) buildConditionalStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("conditional")
+
+	innerPlan := plan.Conditional.Step
+	innerPlan.Attempts = plan.Attempts
+	step := build.buildStepFactory(logger, innerPlan)
+
+	return exec.Conditional{
+		Condition: plan.Conditional.Condition,
+		Step:      step,
+		Variables: conditionVariables(build.stepMetadata, plan.Conditional.Params),
+		Delegate:  build.delegate.ConditionalDelegate(logger, event.OriginID(plan.ID)),
+	}
+}
+
+// conditionVariables returns the variables describing the build, and the
+// step's params, for its condition to refer to. Params that aren't strings or
+// bools are compared by their JSON encoding.
+func conditionVariables(metadata StepMetadata, params atc.Params) condition.Variables {
+	variables := condition.Variables{
+		"build.id":                 strconv.Itoa(metadata.BuildID),
+		"build.name":               metadata.BuildName,
+		"build.job_name":           metadata.JobName,
+		"build.pipeline_name":      metadata.PipelineName,
+		"build.team_name":          metadata.TeamName,
+		"build.manually_triggered": metadata.ManuallyTriggered,
+	}
+
+	for name, value := range params {
+		switch v := value.(type) {
+		case string, bool:
+			variables["params."+name] = v
+		default:
+			payload, err := json.Marshal(v)
+			if err == nil {
+				variables["params."+name] = string(payload)
+			}
+		}
+	}
+
+	return variables
+}
@@ -50,6 +50,30 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	RetainArtifactsStub        func(arg1 lager.Logger, arg2 map[string]exec.ArtifactSource)
+	retainArtifactsMutex       sync.RWMutex
+	retainArtifactsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 map[string]exec.ArtifactSource
+	}
+	RetryDelegateStub        func(lager.Logger, event.OriginID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	ConditionalDelegateStub        func(lager.Logger, event.OriginID) exec.ConditionalDelegate
+	conditionalDelegateMutex       sync.RWMutex
+	conditionalDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}
+	conditionalDelegateReturns struct {
+		result1 exec.ConditionalDelegate
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -186,6 +210,99 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) RetainArtifacts(arg1 lager.Logger, arg2 map[string]exec.ArtifactSource) {
+	fake.retainArtifactsMutex.Lock()
+	fake.retainArtifactsArgsForCall = append(fake.retainArtifactsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 map[string]exec.ArtifactSource
+	}{arg1, arg2})
+	fake.recordInvocation("RetainArtifacts", []interface{}{arg1, arg2})
+	fake.retainArtifactsMutex.Unlock()
+	if fake.RetainArtifactsStub != nil {
+		fake.RetainArtifactsStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildDelegate) RetainArtifactsCallCount() int {
+	fake.retainArtifactsMutex.RLock()
+	defer fake.retainArtifactsMutex.RUnlock()
+	return len(fake.retainArtifactsArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetainArtifactsArgsForCall(i int) (lager.Logger, map[string]exec.ArtifactSource) {
+	fake.retainArtifactsMutex.RLock()
+	defer fake.retainArtifactsMutex.RUnlock()
+	return fake.retainArtifactsArgsForCall[i].arg1, fake.retainArtifactsArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 lager.Logger, arg2 event.OriginID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}{arg1, arg2})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1, arg2})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1, arg2)
+	} else {
+		return fake.retryDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) (lager.Logger, event.OriginID) {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1, fake.retryDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegate(arg1 lager.Logger, arg2 event.OriginID) exec.ConditionalDelegate {
+	fake.conditionalDelegateMutex.Lock()
+	fake.conditionalDelegateArgsForCall = append(fake.conditionalDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}{arg1, arg2})
+	fake.recordInvocation("ConditionalDelegate", []interface{}{arg1, arg2})
+	fake.conditionalDelegateMutex.Unlock()
+	if fake.ConditionalDelegateStub != nil {
+		return fake.ConditionalDelegateStub(arg1, arg2)
+	} else {
+		return fake.conditionalDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateCallCount() int {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return len(fake.conditionalDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateArgsForCall(i int) (lager.Logger, event.OriginID) {
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
+	return fake.conditionalDelegateArgsForCall[i].arg1, fake.conditionalDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) ConditionalDelegateReturns(result1 exec.ConditionalDelegate) {
+	fake.ConditionalDelegateStub = nil
+	fake.conditionalDelegateReturns = struct {
+		result1 exec.ConditionalDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -197,6 +314,12 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.outputDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.retainArtifactsMutex.RLock()
+	defer fake.retainArtifactsMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.conditionalDelegateMutex.RLock()
+	defer fake.conditionalDelegateMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -156,6 +156,8 @@ func buildMetadata(build db.Build, externalURL string) StepMetadata {
 		PipelineName: build.PipelineName(),
 		TeamName:     build.TeamName(),
 		ExternalURL:  externalURL,
+
+		ManuallyTriggered: build.IsManuallyTriggered(),
 	}
 }
 
@@ -199,7 +201,8 @@ func (build *execBuild) Abort(lager.Logger) error {
 
 func (build *execBuild) Resume(logger lager.Logger) {
 	stepFactory := build.buildStepFactory(logger, build.metadata.Plan)
-	source := stepFactory.Using(&exec.NoopStep{}, exec.NewSourceRepository())
+	repo := exec.NewSourceRepository()
+	source := stepFactory.Using(&exec.NoopStep{}, repo)
 
 	defer source.Release()
 
@@ -220,6 +223,10 @@ func (build *execBuild) Resume(logger lager.Logger) {
 				succeeded = false
 			}
 
+			if !aborted {
+				build.retainArtifacts(logger.Session("retain-artifacts"), repo)
+			}
+
 			build.delegate.Finish(logger.Session("finish"), err, succeeded, aborted)
 			return
 
@@ -233,11 +240,45 @@ func (build *execBuild) Resume(logger lager.Logger) {
 	}
 }
 
+// retainArtifacts hands the task outputs named as artifacts in the plan to
+// the delegate. Outputs of steps which never ran are skipped.
+func (build *execBuild) retainArtifacts(logger lager.Logger, repo *exec.SourceRepository) {
+	artifacts := map[string]exec.ArtifactSource{}
+
+	atc.NewPlanTraversal(func(plan *atc.Plan) error {
+		if plan.Task == nil {
+			return nil
+		}
+
+		for _, name := range plan.Task.Artifacts {
+			source, found := repo.SourceFor(exec.SourceName(name))
+			if !found {
+				logger.Info("artifact-not-produced", lager.Data{"artifact": name})
+				continue
+			}
+
+			artifacts[name] = source
+		}
+
+		return nil
+	}).Traverse(&build.metadata.Plan)
+
+	if len(artifacts) == 0 {
+		return
+	}
+
+	build.delegate.RetainArtifacts(logger, artifacts)
+}
+
 func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	if plan.Aggregate != nil {
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -258,6 +299,14 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildOnFailureStep(logger, plan)
 	}
 
+	if plan.OnError != nil {
+		return build.buildOnErrorStep(logger, plan)
+	}
+
+	if plan.OnAbort != nil {
+		return build.buildOnAbortStep(logger, plan)
+	}
+
 	if plan.Ensure != nil {
 		return build.buildEnsureStep(logger, plan)
 	}
@@ -282,6 +331,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.Conditional != nil {
+		return build.buildConditionalStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
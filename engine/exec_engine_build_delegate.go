@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/archive"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
@@ -25,6 +26,10 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	RetryDelegate(lager.Logger, event.OriginID) exec.RetryDelegate
+	ConditionalDelegate(lager.Logger, event.OriginID) exec.ConditionalDelegate
+
+	RetainArtifacts(lager.Logger, map[string]exec.ArtifactSource)
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -35,29 +40,41 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	artifactStore archive.Store
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+// NewBuildDelegateFactory constructs a BuildDelegateFactory. Artifacts are
+// only retained when an artifact store is given.
+func NewBuildDelegateFactory(artifactStore archive.Store) BuildDelegateFactory {
+	return buildDelegateFactory{
+		artifactStore: artifactStore,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(build, factory.artifactStore)
 }
 
 type delegate struct {
 	build db.Build
 
+	artifactStore archive.Store
+
 	implicitOutputs map[string]implicitOutput
+	fetchedInputs   map[string]exec.VersionInfo
 
 	lock sync.Mutex
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, artifactStore archive.Store) BuildDelegate {
 	return &delegate{
 		build: build,
 
+		artifactStore: artifactStore,
+
 		implicitOutputs: make(map[string]implicitOutput),
+		fetchedInputs:   make(map[string]exec.VersionInfo),
 	}
 }
 
@@ -91,6 +108,55 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) RetryDelegate(logger lager.Logger, id event.OriginID) exec.RetryDelegate {
+	return &retryDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) ConditionalDelegate(logger lager.Logger, id event.OriginID) exec.ConditionalDelegate {
+	return &conditionalDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
+// RetainArtifacts streams each of the given sources into the artifact store
+// and records them against the build. A source which fails to stream is
+// logged and skipped, rather than failing the build.
+func (delegate *delegate) RetainArtifacts(logger lager.Logger, artifacts map[string]exec.ArtifactSource) {
+	if delegate.artifactStore == nil {
+		logger.Info("no-artifact-store-configured")
+		return
+	}
+
+	for name, source := range artifacts {
+		alogger := logger.Session("retain", lager.Data{"artifact": name})
+
+		err := source.StreamTo(&artifactStoreDestination{
+			store: delegate.artifactStore,
+			key:   archive.BuildArtifactKey(delegate.build.ID(), name),
+		})
+		if err != nil {
+			alogger.Error("failed-to-stream-artifact", err)
+			continue
+		}
+
+		err = delegate.build.SaveArtifact(name)
+		if err != nil {
+			alogger.Error("failed-to-save-artifact", err)
+			continue
+		}
+
+		alogger.Info("retained")
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -129,6 +195,45 @@ func (delegate *delegate) unregisterImplicitOutput(resource string) {
 	delegate.lock.Unlock()
 }
 
+func (delegate *delegate) registerFetchedInput(name string, info exec.VersionInfo) {
+	delegate.lock.Lock()
+	delegate.fetchedInputs[name] = info
+	delegate.lock.Unlock()
+}
+
+// conditionInputs returns the build's inputs as determined by the scheduler,
+// overridden by the versions that have been fetched so far, which covers
+// one-off builds.
+func (delegate *delegate) conditionInputs() (map[string]exec.ConditionInput, error) {
+	buildInputs, _, err := delegate.build.GetResources()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]exec.ConditionInput{}
+
+	for _, input := range buildInputs {
+		inputs[input.Name] = exec.ConditionInput{
+			VersionInfo: exec.VersionInfo{
+				Version:  atc.Version(input.Version),
+				Metadata: dbMetadataToATCMetadata(input.Metadata),
+			},
+			Triggered: input.FirstOccurrence,
+		}
+	}
+
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	for name, info := range delegate.fetchedInputs {
+		input := inputs[name]
+		input.VersionInfo = info
+		inputs[name] = input
+	}
+
+	return inputs, nil
+}
+
 func (delegate *delegate) saveInitializeTask(logger lager.Logger, taskConfig atc.TaskConfig, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.InitializeTask{
 		TaskConfig: event.ShadowTaskConfig(taskConfig),
@@ -319,6 +424,7 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 
 	if info != nil {
 		input.delegate.registerImplicitOutput(input.plan.Resource, implicitOutput{input.plan, *info})
+		input.delegate.registerFetchedInput(input.plan.Name, *info)
 	}
 
 	input.logger.Info("finished", lager.Data{"version-info": info})
@@ -442,6 +548,18 @@ func (execution *executionDelegate) ImageVersionDetermined(identifier worker.Vol
 	return execution.delegate.build.SaveImageResourceVersion(atc.PlanID(execution.id), *identifier.ResourceCache)
 }
 
+func (execution *executionDelegate) SaveTestResults(results []atc.TestResult) error {
+	err := execution.delegate.build.SaveTestResults(atc.PlanID(execution.id), results)
+	if err != nil {
+		execution.logger.Error("failed-to-save-test-results", err)
+		return err
+	}
+
+	execution.logger.Info("saved-test-results", lager.Data{"count": len(results)})
+
+	return nil
+}
+
 func (execution *executionDelegate) Stdout() io.Writer {
 	return execution.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -456,6 +574,66 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type retryDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (retry *retryDelegate) Retrying(attempt int, wait time.Duration) {
+	err := retry.delegate.build.SaveEvent(event.Retry{
+		Time:    time.Now().Unix(),
+		Attempt: attempt,
+		Wait:    wait.String(),
+		Origin: event.Origin{
+			ID: retry.id,
+		},
+	})
+	if err != nil {
+		retry.logger.Error("failed-to-save-retry-event", err)
+	}
+
+	retry.logger.Info("retrying", lager.Data{"attempt": attempt, "wait": wait.String()})
+}
+
+type conditionalDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (conditional *conditionalDelegate) Inputs() (map[string]exec.ConditionInput, error) {
+	return conditional.delegate.conditionInputs()
+}
+
+func (conditional *conditionalDelegate) Skipped(condition string) {
+	err := conditional.delegate.build.SaveEvent(event.Skipped{
+		Time:      time.Now().Unix(),
+		Condition: condition,
+		Origin: event.Origin{
+			ID: conditional.id,
+		},
+	})
+	if err != nil {
+		conditional.logger.Error("failed-to-save-skipped-event", err)
+	}
+
+	conditional.logger.Info("skipped", lager.Data{"condition": condition})
+}
+
+type artifactStoreDestination struct {
+	store archive.Store
+	key   string
+}
+
+func (dest *artifactStoreDestination) StreamIn(path string, tarStream io.Reader) error {
+	return dest.store.Put(dest.key, tarStream)
+}
+
 type dbEventWriter struct {
 	build db.Build
 
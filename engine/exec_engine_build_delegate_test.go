@@ -3,15 +3,19 @@ package engine_test
 import (
 	"errors"
 	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/archive/archivefakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
@@ -22,7 +26,8 @@ var _ = Describe("BuildDelegate", func() {
 	var (
 		factory BuildDelegateFactory
 
-		fakeBuild *dbfakes.FakeBuild
+		fakeBuild         *dbfakes.FakeBuild
+		fakeArtifactStore *archivefakes.FakeStore
 
 		delegate BuildDelegate
 
@@ -32,9 +37,11 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		fakeArtifactStore = new(archivefakes.FakeStore)
+		factory = NewBuildDelegateFactory(fakeArtifactStore)
 
 		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
 		delegate = factory.Delegate(fakeBuild)
 
 		logger = lagertest.NewTestLogger("test")
@@ -42,6 +49,63 @@ var _ = Describe("BuildDelegate", func() {
 		originID = event.OriginID("some-origin-id")
 	})
 
+	Describe("RetainArtifacts", func() {
+		var (
+			someSource  *execfakes.FakeArtifactSource
+			otherSource *execfakes.FakeArtifactSource
+
+			stored map[string]string
+		)
+
+		BeforeEach(func() {
+			stored = map[string]string{}
+			fakeArtifactStore.PutStub = func(key string, content io.Reader) error {
+				payload, err := ioutil.ReadAll(content)
+				Expect(err).NotTo(HaveOccurred())
+
+				stored[key] = string(payload)
+				return nil
+			}
+
+			someSource = new(execfakes.FakeArtifactSource)
+			someSource.StreamToStub = func(dest exec.ArtifactDestination) error {
+				return dest.StreamIn(".", strings.NewReader("some-tarball"))
+			}
+
+			otherSource = new(execfakes.FakeArtifactSource)
+			otherSource.StreamToReturns(errors.New("volume went away"))
+		})
+
+		JustBeforeEach(func() {
+			delegate.RetainArtifacts(logger, map[string]exec.ArtifactSource{
+				"some-output":  someSource,
+				"other-output": otherSource,
+			})
+		})
+
+		It("streams the artifacts into the store", func() {
+			Expect(stored).To(Equal(map[string]string{
+				"build-artifacts/42/some-output.tar": "some-tarball",
+			}))
+		})
+
+		It("records the artifacts which were stored against the build", func() {
+			Expect(fakeBuild.SaveArtifactCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveArtifactArgsForCall(0)).To(Equal("some-output"))
+		})
+
+		Context("when no artifact store is configured", func() {
+			BeforeEach(func() {
+				delegate = NewBuildDelegateFactory(nil).Delegate(fakeBuild)
+			})
+
+			It("does not retain anything", func() {
+				Expect(someSource.StreamToCallCount()).To(BeZero())
+				Expect(fakeBuild.SaveArtifactCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("InputDelegate", func() {
 		var (
 			getPlan atc.GetPlan
@@ -753,6 +817,30 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("SaveTestResults", func() {
+			results := []atc.TestResult{
+				{Suite: "some-suite", Name: "some-test", Status: atc.TestStatusPassed, Duration: 1.5},
+			}
+
+			It("saves the results against the step", func() {
+				err := executionDelegate.SaveTestResults(results)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeBuild.SaveTestResultsCallCount()).To(Equal(1))
+				actualPlanID, actualResults := fakeBuild.SaveTestResultsArgsForCall(0)
+				Expect(actualPlanID).To(Equal(atc.PlanID("some-origin-id")))
+				Expect(actualResults).To(Equal(results))
+			})
+
+			It("propagates errors", func() {
+				disaster := errors.New("nope")
+				fakeBuild.SaveTestResultsReturns(disaster)
+
+				err := executionDelegate.SaveTestResults(results)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -1170,4 +1258,123 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("ConditionalDelegate", func() {
+		var conditionalDelegate exec.ConditionalDelegate
+
+		BeforeEach(func() {
+			conditionalDelegate = delegate.ConditionalDelegate(logger, originID)
+		})
+
+		Describe("Inputs", func() {
+			BeforeEach(func() {
+				fakeBuild.GetResourcesReturns([]db.BuildInput{
+					{
+						Name: "some-input",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-input-resource",
+							Version:  db.Version{"some": "version"},
+							Metadata: []db.MetadataField{{"some", "metadata"}},
+						},
+						FirstOccurrence: true,
+					},
+					{
+						Name: "some-other-input",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-other-input-resource",
+							Version:  db.Version{"some": "other-version"},
+						},
+					},
+				}, nil, nil)
+			})
+
+			It("returns the build's inputs, and whether they're new to the job", func() {
+				inputs, err := conditionalDelegate.Inputs()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs).To(Equal(map[string]exec.ConditionInput{
+					"some-input": {
+						VersionInfo: exec.VersionInfo{
+							Version:  atc.Version{"some": "version"},
+							Metadata: []atc.MetadataField{{"some", "metadata"}},
+						},
+						Triggered: true,
+					},
+					"some-other-input": {
+						VersionInfo: exec.VersionInfo{
+							Version:  atc.Version{"some": "other-version"},
+							Metadata: []atc.MetadataField{},
+						},
+						Triggered: false,
+					},
+				}))
+			})
+
+			Context("when inputs have been fetched", func() {
+				BeforeEach(func() {
+					delegate.InputDelegate(logger, atc.GetPlan{
+						Name:     "some-input",
+						Resource: "some-input-resource",
+					}, "some-get-origin-id").Completed(exec.ExitStatus(0), &exec.VersionInfo{
+						Version:  atc.Version{"some": "fetched-version"},
+						Metadata: []atc.MetadataField{{"some", "fetched-metadata"}},
+					})
+
+					delegate.InputDelegate(logger, atc.GetPlan{
+						Name:     "some-one-off-input",
+						Resource: "some-one-off-resource",
+					}, "some-other-get-origin-id").Completed(exec.ExitStatus(0), &exec.VersionInfo{
+						Version: atc.Version{"some": "one-off-version"},
+					})
+				})
+
+				It("returns the fetched versions", func() {
+					inputs, err := conditionalDelegate.Inputs()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(inputs["some-input"]).To(Equal(exec.ConditionInput{
+						VersionInfo: exec.VersionInfo{
+							Version:  atc.Version{"some": "fetched-version"},
+							Metadata: []atc.MetadataField{{"some", "fetched-metadata"}},
+						},
+						Triggered: true,
+					}))
+
+					Expect(inputs["some-one-off-input"]).To(Equal(exec.ConditionInput{
+						VersionInfo: exec.VersionInfo{
+							Version: atc.Version{"some": "one-off-version"},
+						},
+					}))
+				})
+			})
+
+			Context("when the build's inputs cannot be found", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.GetResourcesReturns(nil, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					_, err := conditionalDelegate.Inputs()
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+
+		Describe("Skipped", func() {
+			It("saves a skipped event", func() {
+				conditionalDelegate.Skipped("build.manually_triggered")
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Skipped{}))
+				Expect(savedEvent.(event.Skipped).Condition).To(Equal("build.manually_triggered"))
+				Expect(savedEvent.(event.Skipped).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+	})
 })
@@ -1,6 +1,9 @@
 package engine_test
 
 import (
+	"errors"
+	"os"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -455,6 +458,133 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			})
 		})
 
+		Context("when the step errors", func() {
+			var planFactory atc.PlanFactory
+
+			BeforeEach(func() {
+				planFactory = atc.NewPlanFactory(123)
+				inputStep.RunReturns(errors.New("disaster"))
+			})
+
+			It("only runs the error hooks", func() {
+				plan := planFactory.NewPlan(atc.OnAbortPlan{
+					Step: planFactory.NewPlan(atc.OnErrorPlan{
+						Step: planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						}),
+						Next: planFactory.NewPlan(atc.TaskPlan{
+							Name:   "some-resource",
+							Config: &atc.TaskConfig{},
+						}),
+					}),
+					Next: planFactory.NewPlan(atc.PutPlan{
+						Name: "some-put",
+					}),
+				})
+
+				build, err := execEngine.CreateBuild(logger, build, plan)
+
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Expect(inputStep.RunCallCount()).To(Equal(1))
+				Expect(inputStep.ReleaseCallCount()).To(Equal(1))
+
+				Expect(taskStep.RunCallCount()).To(Equal(1))
+				Expect(taskStep.ReleaseCallCount()).To(Equal(1))
+
+				Expect(outputStep.RunCallCount()).To(Equal(0))
+
+				_, cbErr, _, aborted := fakeDelegate.FinishArgsForCall(0)
+				Expect(cbErr).To(HaveOccurred())
+				Expect(cbErr.Error()).To(ContainSubstring("disaster"))
+				Expect(aborted).To(BeFalse())
+			})
+		})
+
+		Context("when a step with an abort hook is interrupted", func() {
+			var planFactory atc.PlanFactory
+			var plan atc.Plan
+
+			BeforeEach(func() {
+				planFactory = atc.NewPlanFactory(123)
+
+				inputStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					<-signals
+					return exec.ErrInterrupted
+				}
+			})
+
+			Context("because it timed out", func() {
+				BeforeEach(func() {
+					plan = planFactory.NewPlan(atc.TimeoutPlan{
+						Duration: "10ms",
+						Step: planFactory.NewPlan(atc.OnAbortPlan{
+							Step: planFactory.NewPlan(atc.GetPlan{
+								Name: "some-input",
+							}),
+							Next: planFactory.NewPlan(atc.PutPlan{
+								Name: "some-put",
+							}),
+						}),
+					})
+				})
+
+				It("does not run the abort hook, and fails", func() {
+					build, err := execEngine.CreateBuild(logger, build, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+
+					Expect(inputStep.RunCallCount()).To(Equal(1))
+					Expect(outputStep.RunCallCount()).To(Equal(0))
+
+					_, cbErr, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(cbErr).NotTo(HaveOccurred())
+					Expect(successful).To(Equal(exec.Success(false)))
+					Expect(aborted).To(BeFalse())
+				})
+			})
+
+			Context("because the build was aborted before it timed out", func() {
+				BeforeEach(func() {
+					plan = planFactory.NewPlan(atc.TimeoutPlan{
+						Duration: "1h",
+						Step: planFactory.NewPlan(atc.OnAbortPlan{
+							Step: planFactory.NewPlan(atc.GetPlan{
+								Name: "some-input",
+							}),
+							Next: planFactory.NewPlan(atc.PutPlan{
+								Name: "some-put",
+							}),
+						}),
+					})
+				})
+
+				It("runs the abort hook", func() {
+					build, err := execEngine.CreateBuild(logger, build, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					go func() {
+						defer GinkgoRecover()
+
+						Eventually(inputStep.RunCallCount).Should(Equal(1))
+						Expect(build.Abort(logger)).To(Succeed())
+					}()
+
+					build.Resume(logger)
+
+					Expect(outputStep.RunCallCount()).To(Equal(1))
+
+					_, _, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(successful).To(Equal(exec.Success(false)))
+					Expect(aborted).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when a step in the aggregate fails the step fails", func() {
 			var planFactory atc.PlanFactory
 
@@ -111,6 +111,8 @@ var _ = Describe("ExecEngine", func() {
 			fakeOutputDelegate = new(execfakes.FakePutDelegate)
 			fakeDelegate.OutputDelegateReturns(fakeOutputDelegate)
 
+			fakeDelegate.RetryDelegateReturns(new(execfakes.FakeRetryDelegate))
+
 			inputStepFactory = new(execfakes.FakeStepFactory)
 			inputStep = new(execfakes.FakeStep)
 			inputStep.ResultStub = successResult(true)
@@ -136,6 +138,63 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory.DependentGetReturns(dependentStepFactory)
 		})
 
+		Describe("with a task retaining artifacts", func() {
+			var fakeArtifactSource *execfakes.FakeArtifactSource
+
+			BeforeEach(func() {
+				fakeArtifactSource = new(execfakes.FakeArtifactSource)
+
+				taskStepFactory.UsingStub = func(prev exec.Step, repo *exec.SourceRepository) exec.Step {
+					repo.RegisterSource("some-output", fakeArtifactSource)
+					repo.RegisterSource("other-output", new(execfakes.FakeArtifactSource))
+					return taskStep
+				}
+
+				plan := planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					ConfigPath: "some-input/task.yml",
+					Artifacts:  []string{"some-output", "never-produced"},
+				})
+
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("retains the artifacts which were produced", func() {
+				build.Resume(logger)
+
+				Expect(fakeDelegate.RetainArtifactsCallCount()).To(Equal(1))
+				_, artifacts := fakeDelegate.RetainArtifactsArgsForCall(0)
+				Expect(artifacts).To(Equal(map[string]exec.ArtifactSource{
+					"some-output": fakeArtifactSource,
+				}))
+			})
+
+			Context("when the build is aborted", func() {
+				BeforeEach(func() {
+					taskStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+						close(ready)
+						<-signals
+						return exec.ErrInterrupted
+					}
+				})
+
+				It("does not retain the artifacts", func() {
+					go func() {
+						defer GinkgoRecover()
+
+						Eventually(taskStep.RunCallCount).Should(Equal(1))
+						Expect(build.Abort(logger)).To(Succeed())
+					}()
+
+					build.Resume(logger)
+
+					Expect(fakeDelegate.RetainArtifactsCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Describe("with a putget in an aggregate", func() {
 			var (
 				putPlan               atc.Plan
@@ -408,8 +467,10 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlanTwo = planFactory.NewPlan(atc.RetryPlan{
-					taskPlan,
-					taskPlan,
+					Attempts: []atc.Plan{
+						taskPlan,
+						taskPlan,
+					},
 				})
 
 				aggregatePlan = planFactory.NewPlan(atc.AggregatePlan{retryPlanTwo})
@@ -422,9 +483,11 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlan = planFactory.NewPlan(atc.RetryPlan{
-					getPlan,
-					timeoutPlan,
-					getPlan,
+					Attempts: []atc.Plan{
+						getPlan,
+						timeoutPlan,
+						getPlan,
+					},
 				})
 
 				build, err = execEngine.CreateBuild(logger, dbBuild, retryPlan)
@@ -435,7 +498,14 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the retry correctly", func() {
-				Expect(*retryPlan.Retry).To(HaveLen(3))
+				Expect(retryPlan.Retry.Attempts).To(HaveLen(3))
+			})
+
+			It("constructs a retry delegate for each retry", func() {
+				Expect(fakeDelegate.RetryDelegateCallCount()).To(Equal(2))
+
+				_, originID := fakeDelegate.RetryDelegateArgsForCall(0)
+				Expect(originID).To(Equal(event.OriginID(retryPlan.ID)))
 			})
 
 			It("constructs the first get correctly", func() {
@@ -495,7 +565,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested retries correctly", func() {
-				Expect(*retryPlanTwo.Retry).To(HaveLen(2))
+				Expect(retryPlanTwo.Retry.Attempts).To(HaveLen(2))
 			})
 
 			It("constructs nested steps correctly", func() {
@@ -579,7 +649,9 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlan = planFactory.NewPlan(atc.RetryPlan{
-					ensurePlan,
+					Attempts: []atc.Plan{
+						ensurePlan,
+					},
 				})
 
 				build, err = execEngine.CreateBuild(logger, dbBuild, retryPlan)
@@ -600,6 +672,85 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with a conditional plan", func() {
+			var (
+				fakeConditionalDelegate *execfakes.FakeConditionalDelegate
+				conditionalPlan         atc.Plan
+			)
+
+			BeforeEach(func() {
+				dbBuild.IsManuallyTriggeredReturns(true)
+
+				fakeConditionalDelegate = new(execfakes.FakeConditionalDelegate)
+				fakeConditionalDelegate.InputsReturns(map[string]exec.ConditionInput{
+					"some-input": {
+						VersionInfo: exec.VersionInfo{Version: atc.Version{"ref": "abc123"}},
+						Triggered:   true,
+					},
+				}, nil)
+				fakeDelegate.ConditionalDelegateReturns(fakeConditionalDelegate)
+			})
+
+			JustBeforeEach(func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, conditionalPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			Context("when the condition holds", func() {
+				BeforeEach(func() {
+					conditionalPlan = planFactory.NewPlan(atc.ConditionalPlan{
+						Condition: `build.manually_triggered && build.id == 42 && build.job_name == "some-job" && params.go_version == 1.7 && inputs.some-input.version.ref == "abc123"`,
+						Params:    atc.Params{"go_version": 1.7},
+						Step: planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-config-path",
+						}),
+					})
+				})
+
+				It("runs the step", func() {
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+					Expect(fakeConditionalDelegate.SkippedCallCount()).To(BeZero())
+
+					_, _, _, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(aborted).To(BeFalse())
+				})
+
+				It("constructs a conditional delegate for the conditional plan", func() {
+					Expect(fakeDelegate.ConditionalDelegateCallCount()).To(Equal(1))
+
+					_, originID := fakeDelegate.ConditionalDelegateArgsForCall(0)
+					Expect(originID).To(Equal(event.OriginID(conditionalPlan.ID)))
+				})
+			})
+
+			Context("when the condition does not hold", func() {
+				BeforeEach(func() {
+					conditionalPlan = planFactory.NewPlan(atc.ConditionalPlan{
+						Condition: "!inputs.some-input.triggered",
+						Step: planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-config-path",
+						}),
+					})
+				})
+
+				It("skips the step, and the build succeeds", func() {
+					Expect(taskStep.RunCallCount()).To(BeZero())
+
+					Expect(fakeConditionalDelegate.SkippedCallCount()).To(Equal(1))
+					Expect(fakeConditionalDelegate.SkippedArgsForCall(0)).To(Equal("!inputs.some-input.triggered"))
+
+					_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(Equal(exec.Success(true)))
+					Expect(aborted).To(BeFalse())
+				})
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var plan atc.Plan
 			Context("that contains inputs", func() {
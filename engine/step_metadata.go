@@ -10,6 +10,8 @@ type StepMetadata struct {
 	BuildName    string
 	ExternalURL  string
 	TeamName     string
+
+	ManuallyTriggered bool
 }
 
 func (metadata StepMetadata) Env() []string {
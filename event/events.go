@@ -77,6 +77,25 @@ type StartTask struct {
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
 func (StartTask) Version() atc.EventVersion { return "4.0" }
 
+type Retry struct {
+	Time    int64  `json:"time"`
+	Attempt int    `json:"attempt"`
+	Wait    string `json:"wait"`
+	Origin  Origin `json:"origin"`
+}
+
+func (Retry) EventType() atc.EventType  { return EventTypeRetry }
+func (Retry) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Time      int64  `json:"time"`
+	Condition string `json:"condition"`
+	Origin    Origin `json:"origin"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
@@ -43,6 +43,8 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(Retry{})
+	registerEvent(Skipped{})
 
 	// deprecated:
 	registerEvent(FinishV10{})
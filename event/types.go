@@ -32,4 +32,10 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// step about to be attempted again, after waiting
+	EventTypeRetry atc.EventType = "retry"
+
+	// step skipped, as its condition did not hold
+	EventTypeSkipped atc.EventType = "skipped"
 )
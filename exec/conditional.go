@@ -0,0 +1,126 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/concourse/atc/condition"
+)
+
+// Conditional constructs a Step that only runs its step if the condition holds
+// when it's reached. The condition is evaluated against the Variables, along
+// with the build's inputs as looked up by the Delegate.
+type Conditional struct {
+	Condition string
+	Step      StepFactory
+	Variables condition.Variables
+	Delegate  ConditionalDelegate
+}
+
+// ConditionInput is an input to the build that a condition can refer to.
+type ConditionInput struct {
+	VersionInfo
+
+	// Triggered is true if the job had not used the version before, which is
+	// the case for whichever input triggered the build.
+	Triggered bool
+}
+
+// Using constructs a *ConditionalStep.
+func (stepFactory Conditional) Using(prev Step, repo *SourceRepository) Step {
+	return &ConditionalStep{
+		condition: stepFactory.Condition,
+		variables: stepFactory.Variables,
+		delegate:  stepFactory.Delegate,
+
+		step: stepFactory.Step.Using(prev, repo),
+	}
+}
+
+// ConditionalStep is a step that only runs its step if the condition holds.
+type ConditionalStep struct {
+	condition string
+	variables condition.Variables
+	delegate  ConditionalDelegate
+
+	step    Step
+	skipped bool
+}
+
+// Run evaluates the condition and runs the step if it holds. Otherwise, the
+// delegate is told that the step was skipped, and it exits successfully
+// without running it.
+//
+// The inputs are available to the condition as inputs.NAME.version.FIELD,
+// inputs.NAME.metadata.FIELD, and inputs.NAME.triggered.
+func (step *ConditionalStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	expression, err := condition.Parse(step.condition)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := step.delegate.Inputs()
+	if err != nil {
+		return err
+	}
+
+	variables := condition.Variables{}
+	for name, value := range step.variables {
+		variables[name] = value
+	}
+
+	for name, input := range inputs {
+		prefix := "inputs." + name + "."
+
+		variables[prefix+"triggered"] = input.Triggered
+
+		for field, value := range input.Version {
+			variables[prefix+"version."+field] = value
+		}
+
+		for _, field := range input.Metadata {
+			variables[prefix+"metadata."+field.Name] = field.Value
+		}
+	}
+
+	holds, err := expression.Evaluate(variables)
+	if err != nil {
+		return err
+	}
+
+	if !holds {
+		step.skipped = true
+		step.delegate.Skipped(step.condition)
+		close(ready)
+		return nil
+	}
+
+	return step.step.Run(signals, ready)
+}
+
+// Release releases the nested step.
+func (step *ConditionalStep) Release() {
+	step.step.Release()
+}
+
+// Result indicates whether the step was Skipped. If it was, it indicates
+// Success as true, and returns false for everything else. Otherwise, it
+// delegates to the nested step.
+func (step *ConditionalStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Skipped:
+		*v = Skipped(step.skipped)
+		return true
+
+	case *Success:
+		if step.skipped {
+			*v = Success(true)
+			return true
+		}
+	}
+
+	if step.skipped {
+		return false
+	}
+
+	return step.step.Result(x)
+}
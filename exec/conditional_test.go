@@ -0,0 +1,175 @@
+package exec_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/condition"
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		fakeDelegate    *execfakes.FakeConditionalDelegate
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStep *execfakes.FakeStep
+
+		conditional Conditional
+		step        Step
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		fakeDelegate = new(execfakes.FakeConditionalDelegate)
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStep = new(execfakes.FakeStep)
+		outStep.ResultStub = successResult(false)
+		fakeStepFactory.UsingReturns(outStep)
+
+		fakeDelegate.InputsReturns(map[string]ConditionInput{
+			"some-input": {
+				VersionInfo: VersionInfo{
+					Version:  atc.Version{"ref": "abc123"},
+					Metadata: []atc.MetadataField{{Name: "author", Value: "someone"}},
+				},
+				Triggered: true,
+			},
+		}, nil)
+
+		conditional = Conditional{
+			Step:     fakeStepFactory,
+			Delegate: fakeDelegate,
+			Variables: condition.Variables{
+				"build.manually_triggered": false,
+				"params.platform":          "linux",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = conditional.Using(inStep, repo)
+		runErr = step.Run(nil, make(chan struct{}))
+	})
+
+	It("uses the input source for the step", func() {
+		Expect(fakeStepFactory.UsingCallCount()).To(Equal(1))
+
+		step, repo := fakeStepFactory.UsingArgsForCall(0)
+		Expect(step).To(Equal(inStep))
+		Expect(repo).To(Equal(repo))
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			conditional.Condition = `!build.manually_triggered && params.platform == "linux" && inputs.some-input.triggered && inputs.some-input.version.ref == "abc123" && inputs.some-input.metadata.author == "someone"`
+		})
+
+		It("runs the step", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(outStep.RunCallCount()).To(Equal(1))
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		It("delegates its result to the step", func() {
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+
+			var skipped Skipped
+			Expect(step.Result(&skipped)).To(BeTrue())
+			Expect(skipped).To(Equal(Skipped(false)))
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				outStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			conditional.Condition = `inputs.some-input.version.ref == "def456"`
+		})
+
+		It("skips the step and tells the delegate", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(outStep.RunCallCount()).To(BeZero())
+
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.SkippedArgsForCall(0)).To(Equal(`inputs.some-input.version.ref == "def456"`))
+		})
+
+		It("is skipped and successful", func() {
+			var skipped Skipped
+			Expect(step.Result(&skipped)).To(BeTrue())
+			Expect(skipped).To(Equal(Skipped(true)))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(true)))
+		})
+
+		It("has no other results", func() {
+			var exitStatus ExitStatus
+			Expect(step.Result(&exitStatus)).To(BeFalse())
+			Expect(outStep.ResultCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the condition cannot be evaluated", func() {
+		BeforeEach(func() {
+			conditional.Condition = "params.platform"
+		})
+
+		It("returns an error without running the step", func() {
+			Expect(runErr).To(MatchError("params.platform is 'linux', not true or false"))
+			Expect(outStep.RunCallCount()).To(BeZero())
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when looking up the inputs fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			conditional.Condition = "true"
+			fakeDelegate.InputsReturns(nil, disaster)
+		})
+
+		It("returns the error without running the step", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(outStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("releasing", func() {
+		BeforeEach(func() {
+			conditional.Condition = "false"
+		})
+
+		It("releases the step", func() {
+			step.Release()
+			Expect(outStep.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeConditionalDelegate struct {
+	InputsStub        func() (map[string]exec.ConditionInput, error)
+	inputsMutex       sync.RWMutex
+	inputsArgsForCall []struct{}
+	inputsReturns     struct {
+		result1 map[string]exec.ConditionInput
+		result2 error
+	}
+	SkippedStub        func(condition string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		condition string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeConditionalDelegate) Inputs() (map[string]exec.ConditionInput, error) {
+	fake.inputsMutex.Lock()
+	fake.inputsArgsForCall = append(fake.inputsArgsForCall, struct{}{})
+	fake.recordInvocation("Inputs", []interface{}{})
+	fake.inputsMutex.Unlock()
+	if fake.InputsStub != nil {
+		return fake.InputsStub()
+	} else {
+		return fake.inputsReturns.result1, fake.inputsReturns.result2
+	}
+}
+
+func (fake *FakeConditionalDelegate) InputsCallCount() int {
+	fake.inputsMutex.RLock()
+	defer fake.inputsMutex.RUnlock()
+	return len(fake.inputsArgsForCall)
+}
+
+func (fake *FakeConditionalDelegate) InputsReturns(result1 map[string]exec.ConditionInput, result2 error) {
+	fake.InputsStub = nil
+	fake.inputsReturns = struct {
+		result1 map[string]exec.ConditionInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConditionalDelegate) Skipped(condition string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		condition string
+	}{condition})
+	fake.recordInvocation("Skipped", []interface{}{condition})
+	fake.skippedMutex.Unlock()
+	if fake.SkippedStub != nil {
+		fake.SkippedStub(condition)
+	}
+}
+
+func (fake *FakeConditionalDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeConditionalDelegate) SkippedArgsForCall(i int) string {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return fake.skippedArgsForCall[i].condition
+}
+
+func (fake *FakeConditionalDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.inputsMutex.RLock()
+	defer fake.inputsMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeConditionalDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ConditionalDelegate = new(FakeConditionalDelegate)
@@ -0,0 +1,67 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeRetryDelegate struct {
+	RetryingStub        func(attempt int, wait time.Duration)
+	retryingMutex       sync.RWMutex
+	retryingArgsForCall []struct {
+		attempt int
+		wait    time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRetryDelegate) Retrying(attempt int, wait time.Duration) {
+	fake.retryingMutex.Lock()
+	fake.retryingArgsForCall = append(fake.retryingArgsForCall, struct {
+		attempt int
+		wait    time.Duration
+	}{attempt, wait})
+	fake.recordInvocation("Retrying", []interface{}{attempt, wait})
+	fake.retryingMutex.Unlock()
+	if fake.RetryingStub != nil {
+		fake.RetryingStub(attempt, wait)
+	}
+}
+
+func (fake *FakeRetryDelegate) RetryingCallCount() int {
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	return len(fake.retryingArgsForCall)
+}
+
+func (fake *FakeRetryDelegate) RetryingArgsForCall(i int) (int, time.Duration) {
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	return fake.retryingArgsForCall[i].attempt, fake.retryingArgsForCall[i].wait
+}
+
+func (fake *FakeRetryDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRetryDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.RetryDelegate = new(FakeRetryDelegate)
@@ -106,6 +106,8 @@ type TaskDelegate interface {
 
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
+	SaveTestResults([]atc.TestResult) error
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 }
@@ -140,6 +142,24 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . RetryDelegate
+
+// RetryDelegate is used to record events related to a RetryStep's runtime
+// behavior.
+type RetryDelegate interface {
+	Retrying(attempt int, wait time.Duration)
+}
+
+//go:generate counterfeiter . ConditionalDelegate
+
+// ConditionalDelegate is used to look up the build's inputs for a
+// ConditionalStep's condition, and to record when its step is skipped.
+type ConditionalDelegate interface {
+	Inputs() (map[string]ConditionInput, error)
+
+	Skipped(condition string)
+}
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
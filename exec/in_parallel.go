@@ -0,0 +1,165 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InParallel constructs a Step that will run its steps in parallel, at most
+// Limit at a time.
+type InParallel struct {
+	Steps []StepFactory
+
+	// Limit is the maximum number of steps to run at once. Zero means no limit.
+	Limit int
+
+	// FailFast causes the remaining steps to be interrupted, and any steps
+	// that haven't been started yet to be skipped, as soon as one step fails.
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an *InParallelStep.
+func (p InParallel) Using(prev Step, repo *SourceRepository) Step {
+	step := &InParallelStep{
+		Limit:    p.Limit,
+		FailFast: p.FailFast,
+	}
+
+	for _, subStepFactory := range p.Steps {
+		step.Steps = append(step.Steps, subStepFactory.Using(prev, repo))
+	}
+
+	return step
+}
+
+// InParallelStep is a step of steps to run in parallel, at most Limit at a
+// time.
+type InParallelStep struct {
+	Steps    []Step
+	Limit    int
+	FailFast bool
+
+	failedFast bool
+}
+
+type inParallelExit struct {
+	step int
+	err  error
+}
+
+// Run starts as many steps as the limit allows, starting the next one each
+// time a running step exits. It indicates that it's ready immediately, and
+// propagates any signal received to all running steps, after which no more
+// steps are started.
+//
+// If FailFast is set, the first step to fail or error causes the running steps
+// to be interrupted and the rest to be skipped. Otherwise, every step is run.
+// After all started steps exit, their errors (if any) will be aggregated and
+// returned as a single error.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	limit := step.Limit
+	if limit <= 0 || limit > len(step.Steps) {
+		limit = len(step.Steps)
+	}
+
+	exits := make(chan inParallelExit, len(step.Steps))
+	running := map[int]ifrit.Process{}
+
+	var interrupted bool
+	var errorMessages []string
+
+	next := 0
+	for {
+		for !interrupted && !step.failedFast && next < len(step.Steps) && len(running) < limit {
+			i := next
+			process := ifrit.Background(step.Steps[i])
+			running[i] = process
+
+			go func() {
+				exits <- inParallelExit{step: i, err: <-process.Wait()}
+			}()
+
+			next++
+		}
+
+		if len(running) == 0 {
+			break
+		}
+
+		select {
+		case sig := <-signals:
+			interrupted = true
+
+			for _, process := range running {
+				process.Signal(sig)
+			}
+
+		case exit := <-exits:
+			delete(running, exit.step)
+
+			if exit.err == ErrInterrupted && (interrupted || step.failedFast) {
+				continue
+			}
+
+			if exit.err != nil {
+				errorMessages = append(errorMessages, exit.err.Error())
+			}
+
+			if step.FailFast && !interrupted && !step.failedFast && step.failed(exit) {
+				step.failedFast = true
+
+				for _, process := range running {
+					process.Signal(os.Interrupt)
+				}
+			}
+		}
+	}
+
+	if interrupted {
+		return ErrInterrupted
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+func (step *InParallelStep) failed(exit inParallelExit) bool {
+	if exit.err != nil {
+		return true
+	}
+
+	var succeeded Success
+	return step.Steps[exit.step].Result(&succeeded) && !bool(succeeded)
+}
+
+// Release releases each nested step.
+func (step *InParallelStep) Release() {
+	for _, src := range step.Steps {
+		src.Release()
+	}
+}
+
+// Result indicates Success as false if a step failed fast, and otherwise
+// behaves like AggregateStep.Result.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	if success, ok := x.(*Success); ok {
+		if step.failedFast {
+			*success = Success(false)
+			return true
+		}
+
+		return AggregateStep(step.Steps).Result(success)
+	}
+
+	return false
+}
@@ -0,0 +1,304 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		inParallel InParallel
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		inParallel = InParallel{
+			Steps: []StepFactory{
+				fakeStepA,
+				fakeStepB,
+				fakeStepC,
+			},
+		}
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = inParallel.Using(inStep, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		for _, fakeStep := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(fakeStep.UsingCallCount()).To(Equal(1))
+			step, repo := fakeStep.UsingArgsForCall(0)
+			Expect(step).To(Equal(inStep))
+			Expect(repo).To(Equal(repo))
+		}
+	})
+
+	It("runs every step and exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(outStepA.RunCallCount()).To(Equal(1))
+		Expect(outStepB.RunCallCount()).To(Equal(1))
+		Expect(outStepC.RunCallCount()).To(Equal(1))
+	})
+
+	Context("with a limit", func() {
+		var finishA chan struct{}
+		var finishB chan struct{}
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+
+			finishA = make(chan struct{})
+			finishB = make(chan struct{})
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-finishA
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-finishB
+				return nil
+			}
+		})
+
+		It("runs no more than that many steps at once", func() {
+			Eventually(outStepA.RunCallCount).Should(Equal(1))
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Consistently(outStepC.RunCallCount).Should(BeZero())
+
+			close(finishB)
+
+			Eventually(outStepC.RunCallCount).Should(Equal(1))
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			close(finishA)
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+
+			receivedSignals = make(chan os.Signal, 2)
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+		})
+
+		It("propagates to the running steps, starts no more, and returns ErrInterrupted", func() {
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Kill)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Kill)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Kill)))
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+			Expect(outStepC.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a step errors", func() {
+		var receivedSignals chan os.Signal
+		var finishB chan struct{}
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+
+			receivedSignals = make(chan os.Signal, 1)
+			finishB = make(chan struct{})
+
+			outStepA.RunReturns(errors.New("nope A"))
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+
+				select {
+				case sig := <-signals:
+					receivedSignals <- sig
+					return ErrInterrupted
+				case <-finishB:
+					return nil
+				}
+			}
+		})
+
+		Context("without fail_fast", func() {
+			BeforeEach(func() {
+				outStepC.RunReturns(errors.New("nope C"))
+			})
+
+			It("keeps running the remaining steps, and returns all of the errors", func() {
+				Eventually(outStepC.RunCallCount).Should(Equal(1))
+
+				close(finishB)
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope A"))
+				Expect(err.Error()).To(ContainSubstring("nope C"))
+
+				Expect(receivedSignals).NotTo(Receive())
+			})
+		})
+
+		Context("with fail_fast", func() {
+			BeforeEach(func() {
+				inParallel.FailFast = true
+			})
+
+			It("interrupts the running steps, skips the rest, and returns the error", func() {
+				Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope A"))
+				Expect(err.Error()).NotTo(ContainSubstring(ErrInterrupted.Error()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("is not successful", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Context("when a step fails with fail_fast", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			inParallel.Limit = 2
+			inParallel.FailFast = true
+
+			receivedSignals = make(chan os.Signal, 1)
+
+			outStepA.ResultStub = successResult(false)
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+		})
+
+		It("interrupts the running steps and skips the rest", func() {
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(outStepC.RunCallCount()).To(BeZero())
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			Eventually(process.Wait()).Should(Receive())
+
+			step.Release()
+
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("getting a result", func() {
+		BeforeEach(func() {
+			outStepA.ResultStub = successResult(true)
+			outStepB.ResultStub = successResult(true)
+		})
+
+		JustBeforeEach(func() {
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		Context("when the result type is bad", func() {
+			It("returns false", func() {
+				result := "this-is-bad"
+				Expect(step.Result(&result)).To(BeFalse())
+			})
+		})
+
+		Context("when all of the steps that indicate success are successful", func() {
+			It("yields true", func() {
+				var result Success
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(true)))
+			})
+		})
+
+		Context("when some steps are not successful", func() {
+			BeforeEach(func() {
+				outStepC.ResultStub = successResult(false)
+			})
+
+			It("yields false", func() {
+				var result Success
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(false)))
+			})
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// OnAbortStep will run one step, and then a second step if the first step is
+// interrupted because the build is aborted.
+type OnAbortStep struct {
+	stepFactory  StepFactory
+	abortFactory StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step  Step
+	abort Step
+}
+
+// OnAbort constructs an OnAbortStep factory.
+func OnAbort(firstStep StepFactory, secondStep StepFactory) OnAbortStep {
+	return OnAbortStep{
+		stepFactory:  firstStep,
+		abortFactory: secondStep,
+	}
+}
+
+// Using constructs an *OnAbortStep.
+func (o OnAbortStep) Using(prev Step, repo *SourceRepository) Step {
+	o.repo = repo
+	o.prev = prev
+
+	o.step = o.stepFactory.Using(o.prev, o.repo)
+	return &o
+}
+
+// Run will call Run on the first step and wait for it to complete. If the
+// first step does anything other than return ErrInterrupted after the build
+// was aborted, Run returns its error (if any). OnAbortStep is ready as soon as
+// the first step is ready.
+//
+// The build is aborted by sending os.Kill. Steps are also interrupted with
+// os.Interrupt when they time out or when a sibling step fails fast, which
+// does not run the second step.
+//
+// If the first step returns ErrInterrupted after the build was aborted, the
+// second step is executed, and ErrInterrupted is returned regardless of how
+// the second step exits, so that the steps around it still treat it as
+// interrupted. Any further signals are passed on to the second step.
+func (o *OnAbortStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	process := ifrit.Background(o.step)
+
+	stepReady := process.Ready()
+	stepExited := process.Wait()
+
+	aborted := false
+
+	var stepRunErr error
+
+dance:
+	for {
+		select {
+		case <-stepReady:
+			close(ready)
+			stepReady = nil
+		case stepRunErr = <-stepExited:
+			break dance
+		case sig := <-signals:
+			if sig == os.Kill {
+				aborted = true
+			}
+
+			process.Signal(sig)
+		}
+	}
+
+	if stepRunErr != ErrInterrupted || !aborted {
+		return stepRunErr
+	}
+
+	o.abort = o.abortFactory.Using(o.step, o.repo)
+	_ = o.abort.Run(signals, make(chan struct{}))
+
+	return ErrInterrupted
+}
+
+// Result indicates Success as false if the first step was interrupted by the
+// build being aborted, and otherwise delegates to the first step.
+//
+// Any other type is ignored.
+func (o *OnAbortStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		if o.abort != nil {
+			*v = false
+			return true
+		}
+
+		return o.step.Result(v)
+
+	default:
+		return false
+	}
+}
+
+// Release releases both steps.
+func (o *OnAbortStep) Release() {
+	if o.step != nil {
+		o.step.Release()
+	}
+
+	if o.abort != nil {
+		o.abort.Release()
+	}
+}
@@ -0,0 +1,262 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("On Abort Step", func() {
+	var (
+		stepFactory  *execfakes.FakeStepFactory
+		abortFactory *execfakes.FakeStepFactory
+
+		step *execfakes.FakeStep
+		hook *execfakes.FakeStep
+
+		previousStep *execfakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onAbortFactory exec.StepFactory
+		onAbortStep    exec.Step
+	)
+
+	interruptedBySignal := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+
+		<-signals
+		return exec.ErrInterrupted
+	}
+
+	BeforeEach(func() {
+		stepFactory = &execfakes.FakeStepFactory{}
+		abortFactory = &execfakes.FakeStepFactory{}
+
+		step = &execfakes.FakeStep{}
+		hook = &execfakes.FakeStep{}
+
+		previousStep = &execfakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		abortFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onAbortFactory = exec.OnAbort(stepFactory, abortFactory)
+		onAbortStep = onAbortFactory.Using(previousStep, repo)
+	})
+
+	It("runs the abort hook if the step is interrupted by the build being aborted, and remains interrupted", func() {
+		step.RunStub = interruptedBySignal
+
+		process := ifrit.Background(onAbortStep)
+		process.Signal(os.Kill)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	It("does not run the abort hook if the step is interrupted otherwise, e.g. by timing out", func() {
+		step.RunStub = interruptedBySignal
+
+		process := ifrit.Background(onAbortStep)
+		process.Signal(os.Interrupt)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step returns interrupted without the build being aborted", func() {
+		step.RunReturns(exec.ErrInterrupted)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("provides the step as the previous step to the hook", func() {
+		step.RunStub = interruptedBySignal
+
+		process := ifrit.Background(onAbortStep)
+		process.Signal(os.Kill)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(abortFactory.UsingCallCount).Should(Equal(1))
+
+		argsPrev, argsRepo := abortFactory.UsingArgsForCall(0)
+		Expect(argsPrev).To(Equal(step))
+		Expect(argsRepo).To(Equal(repo))
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	It("remains interrupted if the hook errors", func() {
+		step.RunStub = interruptedBySignal
+		hook.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onAbortStep)
+		process.Signal(os.Kill)
+
+		Eventually(hook.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	It("does not run the abort hook if the step errors", func() {
+		step.RunReturns(errors.New("disaster"))
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(errorMatching("disaster")))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step fails", func() {
+		step.ResultStub = successResult(false)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("does not run the abort hook if the step succeeds", func() {
+		step.ResultStub = successResult(true)
+
+		process := ifrit.Background(onAbortStep)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(noError()))
+		Expect(hook.RunCallCount()).To(Equal(0))
+	})
+
+	It("propagates signals to the first step when first step is running", func() {
+		step.RunStub = interruptedBySignal
+
+		process := ifrit.Background(onAbortStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(step.RunCallCount).Should(Equal(1))
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+		Expect(hook.RunCallCount()).To(Equal(1))
+	})
+
+	It("propagates signals to the hook when the hook is running", func() {
+		step.RunStub = interruptedBySignal
+
+		hook.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+
+			<-signals
+			return errors.New("interrupted")
+		}
+
+		process := ifrit.Background(onAbortStep)
+
+		process.Signal(os.Kill)
+
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
+		process.Signal(os.Kill)
+
+		Eventually(process.Wait()).Should(Receive(Equal(exec.ErrInterrupted)))
+	})
+
+	Describe("Result", func() {
+		Context("when the provided interface is type Success", func() {
+			var signals chan os.Signal
+			var ready chan struct{}
+
+			BeforeEach(func() {
+				signals = make(chan os.Signal, 1)
+				ready = make(chan struct{}, 1)
+			})
+
+			Context("when step is interrupted by the build being aborted and hook succeeds", func() {
+				BeforeEach(func() {
+					signals <- os.Kill
+					step.RunStub = interruptedBySignal
+					hook.ResultStub = successResult(true)
+				})
+
+				It("doesn't indicate success", func() {
+					var succeeded exec.Success
+					onAbortStep.Run(signals, ready)
+					Expect(onAbortStep.Result(&succeeded)).To(BeTrue())
+					Expect(hook.RunCallCount()).To(Equal(1))
+					Expect(hook.ResultCallCount()).To(Equal(0))
+					Expect(bool(succeeded)).To(BeFalse())
+				})
+			})
+
+			Context("when step succeeds", func() {
+				BeforeEach(func() {
+					step.ResultStub = successResult(true)
+				})
+
+				It("never runs hook, and indicates success", func() {
+					var succeeded exec.Success
+					onAbortStep.Run(signals, ready)
+					Expect(onAbortStep.Result(&succeeded)).To(BeTrue())
+					Expect(hook.RunCallCount()).To(Equal(0))
+					Expect(hook.ResultCallCount()).To(Equal(0))
+					Expect(bool(succeeded)).To(BeTrue())
+				})
+			})
+		})
+
+		Describe("Release", func() {
+			var (
+				signals chan os.Signal
+				ready   chan struct{}
+			)
+
+			BeforeEach(func() {
+				signals = make(chan os.Signal, 1)
+				ready = make(chan struct{}, 1)
+			})
+
+			Context("when both step and hook are run", func() {
+				BeforeEach(func() {
+					signals <- os.Kill
+					step.RunStub = interruptedBySignal
+				})
+
+				It("calls release on both step and hook", func() {
+					onAbortStep.Run(signals, ready)
+					onAbortStep.Release()
+					Expect(step.ReleaseCallCount()).To(Equal(1))
+					Expect(hook.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when only step runs", func() {
+				BeforeEach(func() {
+					step.ResultStub = successResult(true)
+				})
+
+				It("calls release only on step", func() {
+					onAbortStep.Run(signals, ready)
+					onAbortStep.Release()
+					Expect(step.ReleaseCallCount()).To(Equal(1))
+					Expect(hook.ReleaseCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+})
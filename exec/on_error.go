@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// OnErrorStep will run one step, and then a second step if the first step
+// errors (but not fails, or is interrupted).
+type OnErrorStep struct {
+	stepFactory  StepFactory
+	errorFactory StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step      Step
+	errorStep Step
+}
+
+// OnError constructs an OnErrorStep factory.
+func OnError(firstStep StepFactory, secondStep StepFactory) OnErrorStep {
+	return OnErrorStep{
+		stepFactory:  firstStep,
+		errorFactory: secondStep,
+	}
+}
+
+// Using constructs an *OnErrorStep.
+func (o OnErrorStep) Using(prev Step, repo *SourceRepository) Step {
+	o.repo = repo
+	o.prev = prev
+
+	o.step = o.stepFactory.Using(o.prev, o.repo)
+	return &o
+}
+
+// Run will call Run on the first step and wait for it to complete. If the
+// first step succeeds, fails, or is interrupted, Run returns its error (if
+// any). OnErrorStep is ready as soon as the first step is ready.
+//
+// If the first step errors, the second step is executed, and an aggregate of
+// their errors is returned.
+func (o *OnErrorStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	stepRunErr := o.step.Run(signals, ready)
+
+	if stepRunErr == nil || stepRunErr == ErrInterrupted {
+		return stepRunErr
+	}
+
+	var errors error
+	errors = multierror.Append(errors, stepRunErr)
+
+	o.errorStep = o.errorFactory.Using(o.step, o.repo)
+
+	hookErr := o.errorStep.Run(signals, make(chan struct{}))
+	if hookErr != nil {
+		errors = multierror.Append(errors, hookErr)
+	}
+
+	return errors
+}
+
+// Result indicates Success as false if the first step errored, and otherwise
+// delegates to the first step.
+//
+// Any other type is ignored.
+func (o *OnErrorStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		if o.errorStep != nil {
+			*v = false
+			return true
+		}
+
+		return o.step.Result(v)
+
+	default:
+		return false
+	}
+}
+
+// Release releases both steps.
+func (o *OnErrorStep) Release() {
+	if o.step != nil {
+		o.step.Release()
+	}
+
+	if o.errorStep != nil {
+		o.errorStep.Release()
+	}
+}
@@ -1,16 +1,44 @@
 package exec
 
-import "os"
+import (
+	"math"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc"
+)
 
 // Retry constructs a Step that will run the steps in order until one of them
 // succeeds.
-type Retry []StepFactory
+type Retry struct {
+	Attempts []StepFactory
+
+	// Backoff is how long to wait before the second attempt, doubling for each
+	// attempt after that, up to MaxBackoff. Attempts are run back-to-back if
+	// it's empty.
+	Backoff    string
+	MaxBackoff string
+
+	// On is the outcomes to retry. Both failures and errors are retried if
+	// it's empty.
+	On []atc.RetryCondition
+
+	Delegate RetryDelegate
+	Clock    clock.Clock
+}
 
 // Using constructs a *RetryStep.
 func (stepFactory Retry) Using(prev Step, repo *SourceRepository) Step {
-	retry := &RetryStep{}
+	retry := &RetryStep{
+		backoff:    stepFactory.Backoff,
+		maxBackoff: stepFactory.MaxBackoff,
+		on:         stepFactory.On,
+		delegate:   stepFactory.Delegate,
+		clock:      stepFactory.Clock,
+	}
 
-	for _, subStepFactory := range stepFactory {
+	for _, subStepFactory := range stepFactory.Attempts {
 		retry.Attempts = append(retry.Attempts, subStepFactory.Using(prev, repo))
 	}
 
@@ -22,16 +50,48 @@ func (stepFactory Retry) Using(prev Step, repo *SourceRepository) Step {
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	backoff    string
+	maxBackoff string
+	on         []atc.RetryCondition
+	delegate   RetryDelegate
+	clock      clock.Clock
 }
 
-// Run iterates through each step, stopping once a step succeeds. If all steps
-// fail, the RetryStep will fail.
+// Run iterates through each step, stopping once a step succeeds, or once a
+// step fails or errors in a way that isn't to be retried. If all steps fail,
+// the RetryStep will fail.
+//
+// Before each attempt after the first, the delegate is told how long the
+// RetryStep will wait, and the wait is cut short by any signal received.
 func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	close(ready)
 
+	backoff, maxBackoff, err := step.parseBackoff()
+	if err != nil {
+		return err
+	}
+
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
+		if i > 0 {
+			wait := backoffFor(i, backoff, maxBackoff)
+
+			step.delegate.Retrying(i+1, wait)
+
+			if wait > 0 {
+				timer := step.clock.NewTimer(wait)
+
+				select {
+				case <-timer.C():
+				case <-signals:
+					timer.Stop()
+					return ErrInterrupted
+				}
+			}
+		}
+
 		step.LastAttempt = attempt
 
 		var succeeded Success
@@ -41,12 +101,20 @@ func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 		}
 
 		if attemptErr != nil {
+			if !step.retries(atc.RetryOnErrored) {
+				break
+			}
+
 			continue
 		}
 
 		if attempt.Result(&succeeded) && bool(succeeded) {
 			break
 		}
+
+		if !step.retries(atc.RetryOnFailed) {
+			break
+		}
 	}
 
 	return attemptErr
@@ -59,7 +127,66 @@ func (step *RetryStep) Release() {
 	}
 }
 
-// Result delegates to the last step that it ran.
+// Result delegates to the last step that it ran. If it never ran a step, it
+// returns false.
 func (step *RetryStep) Result(x interface{}) bool {
+	if step.LastAttempt == nil {
+		return false
+	}
+
 	return step.LastAttempt.Result(x)
 }
+
+func (step *RetryStep) parseBackoff() (time.Duration, time.Duration, error) {
+	var backoff, maxBackoff time.Duration
+	var err error
+
+	if step.backoff != "" {
+		backoff, err = time.ParseDuration(step.backoff)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if step.maxBackoff != "" {
+		maxBackoff, err = time.ParseDuration(step.maxBackoff)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return backoff, maxBackoff, nil
+}
+
+func (step *RetryStep) retries(condition atc.RetryCondition) bool {
+	if len(step.on) == 0 {
+		return true
+	}
+
+	for _, on := range step.on {
+		if on == condition {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoffFor is the wait before the given retry, doubling the backoff for each
+// retry after the first, up to the max backoff (if any).
+func backoffFor(retry int, backoff time.Duration, maxBackoff time.Duration) time.Duration {
+	wait := backoff
+	for i := 1; i < retry; i++ {
+		if maxBackoff > 0 && wait >= maxBackoff || wait > math.MaxInt64/2 {
+			break
+		}
+
+		wait *= 2
+	}
+
+	if maxBackoff > 0 && wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	return wait
+}
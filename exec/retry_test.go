@@ -3,7 +3,10 @@ package exec_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/tedsuo/ifrit"
 
@@ -23,8 +26,11 @@ var _ = Describe("Retry Step", func() {
 		attempt3Factory *execfakes.FakeStepFactory
 		attempt3Step    *execfakes.FakeStep
 
-		stepFactory StepFactory
-		step        Step
+		fakeDelegate *execfakes.FakeRetryDelegate
+		fakeClock    *fakeclock.FakeClock
+
+		retry Retry
+		step  Step
 	)
 
 	BeforeEach(func() {
@@ -40,8 +46,18 @@ var _ = Describe("Retry Step", func() {
 		attempt3Step = new(execfakes.FakeStep)
 		attempt3Factory.UsingReturns(attempt3Step)
 
-		stepFactory = Retry{attempt1Factory, attempt2Factory, attempt3Factory}
-		step = stepFactory.Using(nil, nil)
+		fakeDelegate = new(execfakes.FakeRetryDelegate)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		retry = Retry{
+			Attempts: []StepFactory{attempt1Factory, attempt2Factory, attempt3Factory},
+			Delegate: fakeDelegate,
+			Clock:    fakeClock,
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = retry.Using(nil, nil)
 	})
 
 	Context("when attempt 1 succeeds", func() {
@@ -105,6 +121,15 @@ var _ = Describe("Retry Step", func() {
 				Expect(attempt3Step.RunCallCount()).To(Equal(0))
 			})
 
+			It("tells the delegate about the second attempt, without waiting", func() {
+				<-process.Wait()
+
+				Expect(fakeDelegate.RetryingCallCount()).To(Equal(1))
+				attempt, wait := fakeDelegate.RetryingArgsForCall(0)
+				Expect(attempt).To(Equal(2))
+				Expect(wait).To(BeZero())
+			})
+
 			Describe("Result", func() {
 				It("delegates to attempt 2", func() {
 					<-process.Wait()
@@ -123,6 +148,107 @@ var _ = Describe("Retry Step", func() {
 				})
 			})
 		})
+
+		Context("when only errors are retried", func() {
+			BeforeEach(func() {
+				retry.On = []atc.RetryCondition{atc.RetryOnErrored}
+			})
+
+			It("returns nil having only run the first attempt", func() {
+				process := ifrit.Invoke(step)
+				Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(0))
+
+				var succeeded Success
+				Expect(step.Result(&succeeded)).To(BeTrue())
+				Expect(succeeded).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Context("when every attempt fails, with a backoff", func() {
+		BeforeEach(func() {
+			retry.Backoff = "10s"
+			retry.MaxBackoff = "15s"
+
+			attempt1Step.ResultStub = successResult(false)
+			attempt2Step.ResultStub = successResult(false)
+			attempt3Step.ResultStub = successResult(false)
+		})
+
+		It("waits before each attempt after the first, doubling the wait up to the max", func() {
+			process := ifrit.Invoke(step)
+
+			Eventually(fakeDelegate.RetryingCallCount).Should(Equal(1))
+			attempt, wait := fakeDelegate.RetryingArgsForCall(0)
+			Expect(attempt).To(Equal(2))
+			Expect(wait).To(Equal(10 * time.Second))
+
+			Consistently(attempt2Step.RunCallCount).Should(BeZero())
+			fakeClock.Increment(10 * time.Second)
+			Eventually(attempt2Step.RunCallCount).Should(Equal(1))
+
+			Eventually(fakeDelegate.RetryingCallCount).Should(Equal(2))
+			attempt, wait = fakeDelegate.RetryingArgsForCall(1)
+			Expect(attempt).To(Equal(3))
+			Expect(wait).To(Equal(15 * time.Second))
+
+			Consistently(attempt3Step.RunCallCount).Should(BeZero())
+			fakeClock.Increment(15 * time.Second)
+			Eventually(attempt3Step.RunCallCount).Should(Equal(1))
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("returns ErrInterrupted if signalled while waiting", func() {
+			process := ifrit.Invoke(step)
+
+			Eventually(fakeDelegate.RetryingCallCount).Should(Equal(1))
+			process.Signal(os.Interrupt)
+
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+			Expect(attempt2Step.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the backoff is not a duration", func() {
+		BeforeEach(func() {
+			retry.Backoff = "nope"
+		})
+
+		It("returns an error without running any attempts", func() {
+			process := ifrit.Invoke(step)
+			Expect(<-process.Wait()).To(HaveOccurred())
+			Expect(attempt1Step.RunCallCount()).To(BeZero())
+		})
+
+		It("has no result", func() {
+			process := ifrit.Invoke(step)
+			Expect(<-process.Wait()).To(HaveOccurred())
+
+			var success Success
+			Expect(step.Result(&success)).To(BeFalse())
+			Expect(bool(success)).To(BeFalse())
+		})
+	})
+
+	Context("when attempt 1 errors, and only failures are retried", func() {
+		BeforeEach(func() {
+			retry.On = []atc.RetryCondition{atc.RetryOnFailed}
+
+			attempt1Step.RunReturns(errors.New("nope"))
+		})
+
+		It("returns the error having only run the first attempt", func() {
+			process := ifrit.Invoke(step)
+			Expect(<-process.Wait()).To(MatchError("nope"))
+
+			Expect(attempt1Step.RunCallCount()).To(Equal(1))
+			Expect(attempt2Step.RunCallCount()).To(Equal(0))
+			Expect(fakeDelegate.RetryingCallCount()).To(BeZero())
+		})
 	})
 
 	Context("when attempt 1 errors, and attempt 2 succeeds", func() {
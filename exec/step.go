@@ -74,6 +74,10 @@ type Success bool
 // Typically if the ExitStatus result is 0, the Success result is true.
 type ExitStatus int
 
+// Skipped indicates whether a step was skipped because its condition did not
+// hold.
+type Skipped bool
+
 // VersionInfo is the version and metadata of a resource that was fetched or
 // produced. It is used by Put, Get, and DependentGet.
 type VersionInfo struct {
@@ -17,6 +17,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/testreport"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
 )
@@ -286,7 +287,12 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		step.exitStatus = processStatus
 
-		err := step.container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
+		err := step.saveReports(config)
+		if err != nil {
+			return err
+		}
+
+		err = step.container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
 		if err != nil {
 			return err
 		}
@@ -423,6 +429,10 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		User:      config.Run.User,
 	}
 
+	if config.ContainerLimits != nil {
+		containerSpec.Limits = *config.ContainerLimits
+	}
+
 	runContainerID := step.containerID
 	runContainerID.Stage = db.ContainerStageRun
 
@@ -477,6 +487,40 @@ func (step *TaskStep) registerSource(config atc.TaskConfig) {
 	}
 }
 
+// saveReports reads the test reports declared by the task out of its outputs
+// and records their results. A report which is missing or malformed is only
+// warned about, as the task may well have failed before writing it.
+func (step *TaskStep) saveReports(config atc.TaskConfig) error {
+	for _, report := range config.Reports {
+		output, filePath, found := config.OutputContaining(report.Path)
+		if !found {
+			continue
+		}
+
+		source := newContainerSource(step.artifactsRoot, step.container, output, step.logger, "")
+
+		file, err := source.StreamFile(filePath)
+		if err != nil {
+			fmt.Fprintf(step.delegate.Stderr(), "failed to read report '%s': %s\n", report.Path, err)
+			continue
+		}
+
+		results, err := testreport.ParseJUnit(file)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(step.delegate.Stderr(), "failed to parse report '%s': %s\n", report.Path, err)
+			continue
+		}
+
+		err = step.delegate.SaveTestResults(results)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Result indicates Success as true if the script's exit status was 0.
 //
 // It also indicates ExitStatus as the exit status of the script.
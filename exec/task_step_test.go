@@ -628,6 +628,90 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies reports", func() {
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Outputs: []atc.TaskOutputConfig{
+										{Name: "some-output", Path: "some-output-configured-path"},
+									},
+									Reports: []atc.TaskReportConfig{
+										{Path: "some-output-configured-path/reports/junit.xml", Format: "junit"},
+									},
+								}, nil)
+
+								fakeWorker.CreateVolumeReturns(nil, worker.ErrNoVolumeManager)
+								fakeProcess.WaitReturns(1, nil)
+							})
+
+							Context("when the report can be streamed out", func() {
+								BeforeEach(func() {
+									report := `<testsuite name="some-suite"><testcase name="some-test" time="1.5"><failure/></testcase></testsuite>`
+
+									tarBuffer := gbytes.NewBuffer()
+									tarWriter := tar.NewWriter(tarBuffer)
+
+									err := tarWriter.WriteHeader(&tar.Header{
+										Name: "junit.xml",
+										Mode: 0644,
+										Size: int64(len(report)),
+									})
+									Expect(err).NotTo(HaveOccurred())
+
+									_, err = tarWriter.Write([]byte(report))
+									Expect(err).NotTo(HaveOccurred())
+
+									fakeContainer.StreamOutReturns(tarBuffer, nil)
+								})
+
+								It("streams the report out of the output", func() {
+									Expect(<-process.Wait()).To(BeNil())
+
+									Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
+									spec := fakeContainer.StreamOutArgsForCall(0)
+									Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-output-configured-path/reports/junit.xml"))
+								})
+
+								It("saves the results via the delegate, even though the task failed", func() {
+									Expect(<-process.Wait()).To(BeNil())
+
+									Expect(taskDelegate.SaveTestResultsCallCount()).To(Equal(1))
+									Expect(taskDelegate.SaveTestResultsArgsForCall(0)).To(Equal([]atc.TestResult{
+										{Suite: "some-suite", Name: "some-test", Status: atc.TestStatusFailed, Duration: 1.5},
+									}))
+								})
+
+								Context("when saving the results fails", func() {
+									disaster := errors.New("nope")
+
+									BeforeEach(func() {
+										taskDelegate.SaveTestResultsReturns(disaster)
+									})
+
+									It("exits with the error", func() {
+										Expect(<-process.Wait()).To(Equal(disaster))
+									})
+								})
+							})
+
+							Context("when the report cannot be streamed out", func() {
+								BeforeEach(func() {
+									fakeContainer.StreamOutReturns(nil, errors.New("no such file"))
+								})
+
+								It("warns about it without failing the step", func() {
+									Expect(<-process.Wait()).To(BeNil())
+
+									Expect(taskDelegate.SaveTestResultsCallCount()).To(BeZero())
+									Expect(stderrBuf).To(gbytes.Say("failed to read report 'some-output-configured-path/reports/junit.xml': no such file"))
+								})
+							})
+						})
+
 						Context("when the configuration specifies paths for outputs", func() {
 							BeforeEach(func() {
 								configSource.FetchConfigReturns(atc.TaskConfig{
@@ -0,0 +1,79 @@
+package buildreaper
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . BuildArchiver
+
+// A BuildArchiver copies the event stream of a finished build into the event
+// archive, so that the build's events can be reaped without losing its logs.
+type BuildArchiver interface {
+	Archive(logger lager.Logger, build db.Build) error
+}
+
+type buildArchiver struct {
+	store archive.Store
+}
+
+func NewBuildArchiver(store archive.Store) BuildArchiver {
+	return &buildArchiver{
+		store: store,
+	}
+}
+
+func (archiver *buildArchiver) Archive(logger lager.Logger, build db.Build) error {
+	logger = logger.Session("archive", lager.Data{"build": build.ID()})
+
+	events, err := build.Events(0)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		return err
+	}
+
+	defer events.Close()
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(archiver.writeEvents(writer, events))
+	}()
+
+	err = archiver.store.Put(archive.BuildEventsKey(build.ID()), reader)
+
+	// unblock the writer if the store gave up early
+	reader.Close()
+
+	if err != nil {
+		logger.Error("failed-to-store-events", err)
+		return err
+	}
+
+	return nil
+}
+
+func (archiver *buildArchiver) writeEvents(w io.Writer, events db.EventSource) error {
+	eventWriter := archive.NewEventWriter(w)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			return err
+		}
+
+		err = eventWriter.Write(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return eventWriter.Close()
+}
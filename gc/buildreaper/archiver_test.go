@@ -0,0 +1,119 @@
+package buildreaper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/archive"
+	"github.com/concourse/atc/archive/archivefakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	. "github.com/concourse/atc/gc/buildreaper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArchiver", func() {
+	var (
+		fakeStore       *archivefakes.FakeStore
+		fakeBuild       *dbfakes.FakeBuild
+		fakeEventSource *dbfakes.FakeEventSource
+
+		envelopes []event.Envelope
+		stored    []byte
+
+		archiveErr error
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(archivefakes.FakeStore)
+		fakeStore.PutStub = func(key string, content io.Reader) error {
+			var err error
+			stored, err = ioutil.ReadAll(content)
+			return err
+		}
+
+		data := json.RawMessage(`{"payload":"hello"}`)
+		envelopes = []event.Envelope{
+			{Data: &data, Event: "log", Version: "5.0"},
+			{Data: &data, Event: "log", Version: "5.0"},
+		}
+
+		fakeEventSource = new(dbfakes.FakeEventSource)
+		fakeEventSource.NextStub = func() (event.Envelope, error) {
+			callCount := fakeEventSource.NextCallCount()
+			if callCount > len(envelopes) {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			return envelopes[callCount-1], nil
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.EventsReturns(fakeEventSource, nil)
+	})
+
+	JustBeforeEach(func() {
+		archiveErr = NewBuildArchiver(fakeStore).Archive(lagertest.NewTestLogger("test"), fakeBuild)
+	})
+
+	It("stores the build's entire event stream under its key", func() {
+		Expect(archiveErr).NotTo(HaveOccurred())
+
+		Expect(fakeBuild.EventsCallCount()).To(Equal(1))
+		Expect(fakeBuild.EventsArgsForCall(0)).To(BeZero())
+
+		Expect(fakeStore.PutCallCount()).To(Equal(1))
+		key, _ := fakeStore.PutArgsForCall(0)
+		Expect(key).To(Equal("build-events/42.json.gz"))
+
+		reader, err := archive.NewEventReader(ioutil.NopCloser(bytes.NewBuffer(stored)))
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, envelope := range envelopes {
+			ev, err := reader.Read()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev).To(Equal(envelope))
+		}
+
+		_, err = reader.Read()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	It("closes the event source", func() {
+		Expect(fakeEventSource.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when reading the events fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeEventSource.NextReturns(event.Envelope{}, disaster)
+			fakeEventSource.NextStub = nil
+		})
+
+		It("returns the error", func() {
+			Expect(archiveErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when storing the events fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStore.PutStub = nil
+			fakeStore.PutReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(archiveErr).To(Equal(disaster))
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 type BuildReaperDB interface {
 	GetAllPipelines() ([]db.SavedPipeline, error)
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	ArchiveBuildEventsByBuildIDs(buildIDs []int) error
 }
 
 type BuildReaper interface {
@@ -21,19 +22,24 @@ type buildReaper struct {
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
 	batchSize         int
+	archiver          BuildArchiver
 }
 
+// NewBuildReaper constructs a BuildReaper. If archiver is nil, reaped build
+// events are discarded rather than archived.
 func NewBuildReaper(
 	logger lager.Logger,
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	batchSize int,
+	archiver BuildArchiver,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
 		batchSize:         batchSize,
+		archiver:          archiver,
 	}
 }
 
@@ -122,6 +128,7 @@ func (br *buildReaper) Run() error {
 
 			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
 
+			buildsToDelete := []db.Build{}
 			buildIDsToDelete := []int{}
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
@@ -130,6 +137,7 @@ func (br *buildReaper) Run() error {
 					break
 				}
 
+				buildsToDelete = append(buildsToDelete, build)
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
@@ -137,7 +145,11 @@ func (br *buildReaper) Run() error {
 				continue
 			}
 
-			err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+			if br.archiver != nil {
+				err = br.archiveBuildEvents(buildsToDelete, buildIDsToDelete)
+			} else {
+				err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+			}
 			if err != nil {
 				br.logger.Error("could-not-delete-build-events", err)
 				return err
@@ -153,3 +165,15 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+func (br *buildReaper) archiveBuildEvents(builds []db.Build, buildIDs []int) error {
+	for _, build := range builds {
+		err := br.archiver.Archive(br.logger, build)
+		if err != nil {
+			br.logger.Error("could-not-archive-build-events", err)
+			return err
+		}
+	}
+
+	return br.db.ArchiveBuildEventsByBuildIDs(buildIDs)
+}
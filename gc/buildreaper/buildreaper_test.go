@@ -21,12 +21,14 @@ var _ = Describe("BuildReaper", func() {
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
 		batchSize             int
+		buildArchiver         BuildArchiver
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		batchSize = 5
+		buildArchiver = nil
 	})
 
 	JustBeforeEach(func() {
@@ -36,6 +38,7 @@ var _ = Describe("BuildReaper", func() {
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
 			batchSize,
+			buildArchiver,
 		)
 	})
 
@@ -145,6 +148,60 @@ var _ = Describe("BuildReaper", func() {
 					})
 				})
 
+				Context("when an archiver is configured", func() {
+					var fakeBuildArchiver *buildreaperfakes.FakeBuildArchiver
+
+					BeforeEach(func() {
+						fakeBuildArchiver = new(buildreaperfakes.FakeBuildArchiver)
+						buildArchiver = fakeBuildArchiver
+					})
+
+					It("archives each reaped build before deleting its events", func() {
+						err := buildReaper.Run()
+						Expect(err).NotTo(HaveOccurred())
+
+						archivedBuildIDs := []int{}
+						for i := 0; i < fakeBuildArchiver.ArchiveCallCount(); i++ {
+							_, build := fakeBuildArchiver.ArchiveArgsForCall(i)
+							archivedBuildIDs = append(archivedBuildIDs, build.ID())
+						}
+
+						Expect(archivedBuildIDs).To(ConsistOf(6, 7, 8, 9, 10))
+
+						Expect(fakeBuildReaperDB.ArchiveBuildEventsByBuildIDsCallCount()).To(Equal(1))
+						Expect(fakeBuildReaperDB.ArchiveBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7, 8, 9, 10))
+						Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+					})
+
+					Context("when archiving a build fails", func() {
+						var disaster error
+
+						BeforeEach(func() {
+							disaster = errors.New("major malfunction")
+
+							fakeBuildArchiver.ArchiveReturns(disaster)
+						})
+
+						It("returns the error", func() {
+							err := buildReaper.Run()
+							Expect(err).To(Equal(disaster))
+						})
+
+						It("does not delete any build events", func() {
+							buildReaper.Run()
+
+							Expect(fakeBuildReaperDB.ArchiveBuildEventsByBuildIDsCallCount()).To(BeZero())
+							Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+						})
+
+						It("does not update first logged build id", func() {
+							buildReaper.Run()
+
+							Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when updating first logged build id fails", func() {
 					var disaster error
 
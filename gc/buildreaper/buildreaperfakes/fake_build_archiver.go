@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package buildreaperfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/gc/buildreaper"
+)
+
+type FakeBuildArchiver struct {
+	ArchiveStub        func(logger lager.Logger, build db.Build) error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+		logger lager.Logger
+		build  db.Build
+	}
+	archiveReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildArchiver) Archive(logger lager.Logger, build db.Build) error {
+	fake.archiveMutex.Lock()
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+		logger lager.Logger
+		build  db.Build
+	}{logger, build})
+	fake.recordInvocation("Archive", []interface{}{logger, build})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub(logger, build)
+	} else {
+		return fake.archiveReturns.result1
+	}
+}
+
+func (fake *FakeBuildArchiver) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakeBuildArchiver) ArchiveArgsForCall(i int) (lager.Logger, db.Build) {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return fake.archiveArgsForCall[i].logger, fake.archiveArgsForCall[i].build
+}
+
+func (fake *FakeBuildArchiver) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildArchiver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBuildArchiver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildreaper.BuildArchiver = new(FakeBuildArchiver)
@@ -24,6 +24,14 @@ type FakeBuildReaperDB struct {
 	deleteBuildEventsByBuildIDsReturns struct {
 		result1 error
 	}
+	ArchiveBuildEventsByBuildIDsStub        func(buildIDs []int) error
+	archiveBuildEventsByBuildIDsMutex       sync.RWMutex
+	archiveBuildEventsByBuildIDsArgsForCall []struct {
+		buildIDs []int
+	}
+	archiveBuildEventsByBuildIDsReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +100,44 @@ func (fake *FakeBuildReaperDB) DeleteBuildEventsByBuildIDsReturns(result1 error)
 	}{result1}
 }
 
+func (fake *FakeBuildReaperDB) ArchiveBuildEventsByBuildIDs(buildIDs []int) error {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	fake.archiveBuildEventsByBuildIDsMutex.Lock()
+	fake.archiveBuildEventsByBuildIDsArgsForCall = append(fake.archiveBuildEventsByBuildIDsArgsForCall, struct {
+		buildIDs []int
+	}{buildIDsCopy})
+	fake.recordInvocation("ArchiveBuildEventsByBuildIDs", []interface{}{buildIDsCopy})
+	fake.archiveBuildEventsByBuildIDsMutex.Unlock()
+	if fake.ArchiveBuildEventsByBuildIDsStub != nil {
+		return fake.ArchiveBuildEventsByBuildIDsStub(buildIDs)
+	} else {
+		return fake.archiveBuildEventsByBuildIDsReturns.result1
+	}
+}
+
+func (fake *FakeBuildReaperDB) ArchiveBuildEventsByBuildIDsCallCount() int {
+	fake.archiveBuildEventsByBuildIDsMutex.RLock()
+	defer fake.archiveBuildEventsByBuildIDsMutex.RUnlock()
+	return len(fake.archiveBuildEventsByBuildIDsArgsForCall)
+}
+
+func (fake *FakeBuildReaperDB) ArchiveBuildEventsByBuildIDsArgsForCall(i int) []int {
+	fake.archiveBuildEventsByBuildIDsMutex.RLock()
+	defer fake.archiveBuildEventsByBuildIDsMutex.RUnlock()
+	return fake.archiveBuildEventsByBuildIDsArgsForCall[i].buildIDs
+}
+
+func (fake *FakeBuildReaperDB) ArchiveBuildEventsByBuildIDsReturns(result1 error) {
+	fake.ArchiveBuildEventsByBuildIDsStub = nil
+	fake.archiveBuildEventsByBuildIDsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +145,8 @@ func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPipelinesMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.archiveBuildEventsByBuildIDsMutex.RLock()
+	defer fake.archiveBuildEventsByBuildIDsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -8,6 +8,7 @@ type Job struct {
 	DisableManualTrigger bool   `json:"disable_manual_trigger,omitempty"`
 	NextBuild            *Build `json:"next_build"`
 	FinishedBuild        *Build `json:"finished_build"`
+	NextTriggerTime      int64  `json:"next_trigger_time,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
@@ -1,94 +1,48 @@
 package metric
 
 import (
-	"time"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 )
 
-type eventEmission struct {
-	event  goryman.Event
-	logger lager.Logger
-}
-
-var riemannClient *goryman.GorymanClient
-var eventHost string
-var eventTags []string
-var eventAttributes map[string]string
-var eventPrefix string
-
-var clientConnected bool
-var emissions = make(chan eventEmission, 1000)
+type EventState string
 
-func Initialize(logger lager.Logger, riemannAddr string, host string, tags []string, attributes map[string]string, prefix string) {
-	client := goryman.NewGorymanClient(riemannAddr)
-
-	riemannClient = client
-	eventHost = host
-	eventTags = tags
-	eventAttributes = attributes
-	eventPrefix = prefix
+const (
+	EventStateOK       EventState = "ok"
+	EventStateWarning  EventState = "warning"
+	EventStateCritical EventState = "critical"
+)
 
-	go emitLoop()
+type Event struct {
+	Name       string
+	Value      float64
+	State      EventState
+	Attributes map[string]string
 }
 
-func emit(logger lager.Logger, event goryman.Event) {
-	logger.Debug("emit")
-
-	if riemannClient == nil {
-		return
-	}
-
-	if eventPrefix != "" {
-		event.Service = eventPrefix + event.Service
-	}
+//go:generate counterfeiter . Emitter
 
-	event.Host = eventHost
-	event.Time = time.Now().Unix()
-	event.Tags = append(event.Tags, eventTags...)
-
-	mergedAttributes := map[string]string{}
-	for k, v := range eventAttributes {
-		mergedAttributes[k] = v
-	}
-
-	if event.Attributes != nil {
-		for k, v := range event.Attributes {
-			mergedAttributes[k] = v
-		}
-	}
-
-	event.Attributes = mergedAttributes
-
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
-	}
+type Emitter interface {
+	Emit(lager.Logger, Event)
 }
 
-func emitLoop() {
-	for emission := range emissions {
-		if !clientConnected {
-			err := riemannClient.Connect()
-			if err != nil {
-				emission.logger.Error("connection-failed", err)
-				continue
-			}
+var emittersLock sync.RWMutex
+var emitters []Emitter
 
-			clientConnected = true
-		}
+func RegisterEmitter(emitter Emitter) {
+	emittersLock.Lock()
+	emitters = append(emitters, emitter)
+	emittersLock.Unlock()
+}
 
-		err := riemannClient.SendEvent(&emission.event)
-		if err != nil {
-			emission.logger.Error("failed-to-emit", err)
+func emit(logger lager.Logger, event Event) {
+	logger.Debug("emit")
 
-			if err := riemannClient.Close(); err != nil {
-				emission.logger.Error("failed-to-close", err)
-			}
+	emittersLock.RLock()
+	defer emittersLock.RUnlock()
 
-			clientConnected = false
-		}
+	for _, emitter := range emitters {
+		emitter.Emit(logger, event)
 	}
 }
@@ -0,0 +1,46 @@
+package metric_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Emitting events", func() {
+	var fakeEmitter *metricfakes.FakeEmitter
+
+	BeforeEach(func() {
+		fakeEmitter = new(metricfakes.FakeEmitter)
+		RegisterEmitter(fakeEmitter)
+	})
+
+	It("sends events to every registered emitter", func() {
+		otherEmitter := new(metricfakes.FakeEmitter)
+		RegisterEmitter(otherEmitter)
+
+		SchedulingJobDuration{
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			Duration:     2 * time.Second,
+		}.Emit(lagertest.NewTestLogger("test"))
+
+		Expect(fakeEmitter.EmitCallCount()).To(Equal(1))
+		Expect(otherEmitter.EmitCallCount()).To(Equal(1))
+
+		_, event := fakeEmitter.EmitArgsForCall(0)
+		Expect(event).To(Equal(Event{
+			Name:  "scheduling: job duration (ms)",
+			Value: 2000,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline": "some-pipeline",
+				"job":      "some-job",
+			},
+		}))
+	})
+})
@@ -0,0 +1,164 @@
+package metric
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type PrometheusEmitter struct {
+	registry *prometheus.Registry
+
+	schedulingFullDuration *prometheus.HistogramVec
+	schedulingJobDuration  *prometheus.HistogramVec
+
+	buildsStarted  *prometheus.CounterVec
+	buildsFinished *prometheus.CounterVec
+	buildDuration  *prometheus.HistogramVec
+
+	workerContainers *prometheus.GaugeVec
+
+	databaseQueries     prometheus.Counter
+	databaseConnections prometheus.Gauge
+
+	httpResponseDuration *prometheus.HistogramVec
+}
+
+func NewPrometheusEmitter() *PrometheusEmitter {
+	emitter := &PrometheusEmitter{
+		registry: prometheus.NewRegistry(),
+
+		schedulingFullDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "scheduling",
+				Name:      "full_duration_seconds",
+				Help:      "Time taken to schedule an entire pipeline.",
+			},
+			[]string{"pipeline"},
+		),
+
+		schedulingJobDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "scheduling",
+				Name:      "job_duration_seconds",
+				Help:      "Time taken to schedule a single job.",
+			},
+			[]string{"pipeline", "job"},
+		),
+
+		buildsStarted: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "concourse",
+				Subsystem: "builds",
+				Name:      "started_total",
+				Help:      "Total number of builds started.",
+			},
+			[]string{"pipeline", "job"},
+		),
+
+		buildsFinished: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "concourse",
+				Subsystem: "builds",
+				Name:      "finished_total",
+				Help:      "Total number of builds finished, by status.",
+			},
+			[]string{"pipeline", "job", "status"},
+		),
+
+		buildDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "builds",
+				Name:      "duration_seconds",
+				Help:      "Duration of finished builds.",
+				Buckets:   []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200},
+			},
+			[]string{"pipeline", "job", "status"},
+		),
+
+		workerContainers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "concourse",
+				Subsystem: "workers",
+				Name:      "containers",
+				Help:      "Number of containers on each worker.",
+			},
+			[]string{"worker"},
+		),
+
+		databaseQueries: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "concourse",
+				Subsystem: "db",
+				Name:      "queries_total",
+				Help:      "Total number of database queries.",
+			},
+		),
+
+		databaseConnections: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "concourse",
+				Subsystem: "db",
+				Name:      "connections",
+				Help:      "Number of open database connections.",
+			},
+		),
+
+		httpResponseDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "http",
+				Name:      "response_duration_seconds",
+				Help:      "Time taken to respond to HTTP requests, by route.",
+			},
+			[]string{"route"},
+		),
+	}
+
+	emitter.registry.MustRegister(
+		emitter.schedulingFullDuration,
+		emitter.schedulingJobDuration,
+		emitter.buildsStarted,
+		emitter.buildsFinished,
+		emitter.buildDuration,
+		emitter.workerContainers,
+		emitter.databaseQueries,
+		emitter.databaseConnections,
+		emitter.httpResponseDuration,
+	)
+
+	return emitter
+}
+
+func (emitter *PrometheusEmitter) Handler() http.Handler {
+	return promhttp.HandlerFor(emitter.registry, promhttp.HandlerOpts{})
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event Event) {
+	attr := event.Attributes
+
+	switch event.Name {
+	case "scheduling: full duration (ms)":
+		emitter.schedulingFullDuration.WithLabelValues(attr["pipeline"]).Observe(event.Value / 1000)
+	case "scheduling: job duration (ms)":
+		emitter.schedulingJobDuration.WithLabelValues(attr["pipeline"], attr["job"]).Observe(event.Value / 1000)
+	case "build started":
+		emitter.buildsStarted.WithLabelValues(attr["pipeline"], attr["job"]).Inc()
+	case "build finished":
+		emitter.buildsFinished.WithLabelValues(attr["pipeline"], attr["job"], attr["build_status"]).Inc()
+		emitter.buildDuration.WithLabelValues(attr["pipeline"], attr["job"], attr["build_status"]).Observe(event.Value / 1000)
+	case "worker containers":
+		emitter.workerContainers.WithLabelValues(attr["worker"]).Set(event.Value)
+	case "database queries":
+		emitter.databaseQueries.Add(event.Value)
+	case "database connections":
+		emitter.databaseConnections.Set(event.Value)
+	case "http response time":
+		emitter.httpResponseDuration.WithLabelValues(attr["route"]).Observe(event.Value / 1000)
+	}
+}
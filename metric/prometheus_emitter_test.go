@@ -0,0 +1,102 @@
+package metric_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		emitter *PrometheusEmitter
+		logger  *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		emitter = NewPrometheusEmitter()
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		emitter.Handler().ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(body)
+	}
+
+	It("counts started and finished builds", func() {
+		emitter.Emit(logger, Event{
+			Name:       "build started",
+			Value:      42,
+			Attributes: map[string]string{"pipeline": "some-pipeline", "job": "some-job"},
+		})
+
+		emitter.Emit(logger, Event{
+			Name:  "build finished",
+			Value: 90000,
+			Attributes: map[string]string{
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": string(db.StatusSucceeded),
+			},
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_builds_started_total{job="some-job",pipeline="some-pipeline"} 1`))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_finished_total{job="some-job",pipeline="some-pipeline",status="succeeded"} 1`))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{job="some-job",pipeline="some-pipeline",status="succeeded"} 90`))
+	})
+
+	It("observes scheduling durations in seconds", func() {
+		emitter.Emit(logger, Event{
+			Name:       "scheduling: full duration (ms)",
+			Value:      1500,
+			Attributes: map[string]string{"pipeline": "some-pipeline"},
+		})
+
+		Expect(scrape()).To(ContainSubstring(`concourse_scheduling_full_duration_seconds_sum{pipeline="some-pipeline"} 1.5`))
+	})
+
+	It("accumulates database queries and tracks connections", func() {
+		emitter.Emit(logger, Event{Name: "database queries", Value: 3})
+		emitter.Emit(logger, Event{Name: "database queries", Value: 4})
+		emitter.Emit(logger, Event{Name: "database connections", Value: 5})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("concourse_db_queries_total 7"))
+		Expect(metrics).To(ContainSubstring("concourse_db_connections 5"))
+	})
+
+	It("observes HTTP response times per route when registered", func() {
+		RegisterEmitter(emitter)
+
+		HTTPResponseTime{
+			Route:    "GetBuild",
+			Path:     "/api/v1/builds/1",
+			Duration: 250 * time.Millisecond,
+		}.Emit(logger)
+
+		Expect(scrape()).To(ContainSubstring(`concourse_http_response_duration_seconds_sum{route="GetBuild"} 0.25`))
+	})
+
+	It("ignores events it does not know about", func() {
+		emitter.Emit(logger, Event{Name: "tracked volumes", Value: 10})
+
+		Expect(scrape()).NotTo(ContainSubstring("volumes"))
+	})
+})
@@ -0,0 +1,94 @@
+package metric
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/bigdatadev/goryman"
+)
+
+type riemannEmission struct {
+	event  goryman.Event
+	logger lager.Logger
+}
+
+type RiemannEmitter struct {
+	client *goryman.GorymanClient
+
+	host       string
+	tags       []string
+	attributes map[string]string
+	prefix     string
+
+	connected bool
+	emissions chan riemannEmission
+}
+
+func NewRiemannEmitter(riemannAddr string, host string, tags []string, attributes map[string]string, prefix string) *RiemannEmitter {
+	emitter := &RiemannEmitter{
+		client: goryman.NewGorymanClient(riemannAddr),
+
+		host:       host,
+		tags:       tags,
+		attributes: attributes,
+		prefix:     prefix,
+
+		emissions: make(chan riemannEmission, 1000),
+	}
+
+	go emitter.emitLoop()
+
+	return emitter
+}
+
+func (emitter *RiemannEmitter) Emit(logger lager.Logger, event Event) {
+	mergedAttributes := map[string]string{}
+	for k, v := range emitter.attributes {
+		mergedAttributes[k] = v
+	}
+
+	for k, v := range event.Attributes {
+		mergedAttributes[k] = v
+	}
+
+	riemannEvent := goryman.Event{
+		Service:    emitter.prefix + event.Name,
+		Metric:     event.Value,
+		State:      string(event.State),
+		Host:       emitter.host,
+		Time:       time.Now().Unix(),
+		Tags:       emitter.tags,
+		Attributes: mergedAttributes,
+	}
+
+	select {
+	case emitter.emissions <- riemannEmission{logger: logger, event: riemannEvent}:
+	default:
+		logger.Error("queue-full", nil)
+	}
+}
+
+func (emitter *RiemannEmitter) emitLoop() {
+	for emission := range emitter.emissions {
+		if !emitter.connected {
+			err := emitter.client.Connect()
+			if err != nil {
+				emission.logger.Error("connection-failed", err)
+				continue
+			}
+
+			emitter.connected = true
+		}
+
+		err := emitter.client.SendEvent(&emission.event)
+		if err != nil {
+			emission.logger.Error("failed-to-emit", err)
+
+			if err := emitter.client.Close(); err != nil {
+				emission.logger.Error("failed-to-close", err)
+			}
+
+			emitter.connected = false
+		}
+	}
+}
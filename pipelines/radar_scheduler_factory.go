@@ -5,11 +5,13 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
@@ -24,34 +26,44 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker     resource.Tracker
+	interval    time.Duration
+	engine      engine.Engine
+	credentials credentials.Manager
+	buildQueue  buildqueue.Queue
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
 	engine engine.Engine,
+	credentialsManager credentials.Manager,
+	buildQueue buildqueue.Queue,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:     tracker,
+		interval:    interval,
+		engine:      engine,
+		credentials: credentialsManager,
+		buildQueue:  buildQueue,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	variables := credentials.VariablesFor(rsf.credentials, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL, variables)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
+	variables := credentials.VariablesFor(rsf.credentials, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+
 	scanner := radar.NewResourceScanner(
 		clock.NewClock(),
 		rsf.tracker,
 		rsf.interval,
 		pipelineDB,
 		externalURL,
+		variables,
 	)
 	inputMapper := inputmapper.NewInputMapper(
 		pipelineDB,
@@ -63,9 +75,11 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		BuildStarter: scheduler.NewBuildStarter(
 			pipelineDB,
 			maxinflight.NewUpdater(pipelineDB),
+			rsf.buildQueue,
 			factory.NewBuildFactory(
 				pipelineDB.GetPipelineID(),
 				atc.NewPlanFactory(time.Now().Unix()),
+				variables,
 			),
 			scanner,
 			inputMapper,
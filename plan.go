@@ -1,10 +1,13 @@
 package atc
 
+import "encoding/json"
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
@@ -12,10 +15,13 @@ type Plan struct {
 	Ensure       *EnsurePlan       `json:"ensure,omitempty"`
 	OnSuccess    *OnSuccessPlan    `json:"on_success,omitempty"`
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
+	OnError      *OnErrorPlan      `json:"on_error,omitempty"`
+	OnAbort      *OnAbortPlan      `json:"on_abort,omitempty"`
 	Try          *TryPlan          `json:"try,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+	Conditional  *ConditionalPlan  `json:"conditional,omitempty"`
 }
 
 type PlanID string
@@ -30,6 +36,8 @@ type DependentGetPlan struct {
 	Params        Params        `json:"params,omitempty"`
 	Tags          Tags          `json:"tags,omitempty"`
 	Source        Source        `json:"source"`
+
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
 }
 
 func (plan DependentGetPlan) GetPlan() GetPlan {
@@ -43,6 +51,8 @@ func (plan DependentGetPlan) GetPlan() GetPlan {
 		Source:        plan.Source,
 		Tags:          plan.Tags,
 		Params:        plan.Params,
+
+		ContainerLimits: plan.ContainerLimits,
 	}
 }
 
@@ -51,6 +61,16 @@ type OnFailurePlan struct {
 	Next Plan `json:"on_failure"`
 }
 
+type OnErrorPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_error"`
+}
+
+type OnAbortPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_abort"`
+}
+
 type EnsurePlan struct {
 	Step Plan `json:"step"`
 	Next Plan `json:"ensure"`
@@ -70,8 +90,23 @@ type TryPlan struct {
 	Step Plan `json:"step"`
 }
 
+// ConditionalPlan runs its step only if the condition holds when it's
+// reached, and is skipped otherwise. Params are the params of the step as
+// configured, which the condition can refer to.
+type ConditionalPlan struct {
+	Condition string `json:"condition"`
+	Params    Params `json:"params,omitempty"`
+	Step      Plan   `json:"step"`
+}
+
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -85,6 +120,8 @@ type GetPlan struct {
 	Params        Params        `json:"params,omitempty"`
 	Version       Version       `json:"version,omitempty"`
 	Tags          Tags          `json:"tags,omitempty"`
+
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
 }
 
 type PutPlan struct {
@@ -97,6 +134,8 @@ type PutPlan struct {
 	Source        Source        `json:"source"`
 	Params        Params        `json:"params,omitempty"`
 	Tags          Tags          `json:"tags,omitempty"`
+
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
 }
 
 type TaskPlan struct {
@@ -112,10 +151,37 @@ type TaskPlan struct {
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
+	Artifacts         []string          `json:"artifacts,omitempty"`
 
 	Pipeline      string        `json:"pipeline"`
 	PipelineID    int           `json:"pipeline_id"`
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
 
-type RetryPlan []Plan
+type RetryPlan struct {
+	Attempts   []Plan           `json:"attempts"`
+	Backoff    string           `json:"backoff,omitempty"`
+	MaxBackoff string           `json:"max_backoff,omitempty"`
+	On         []RetryCondition `json:"on,omitempty"`
+}
+
+type retryPlan RetryPlan
+
+// UnmarshalJSON also accepts the plain list of attempts that retry plans used
+// to be, so that builds planned before retry policies can still be resumed.
+func (plan *RetryPlan) UnmarshalJSON(payload []byte) error {
+	var attempts []Plan
+	if err := json.Unmarshal(payload, &attempts); err == nil {
+		*plan = RetryPlan{Attempts: attempts}
+		return nil
+	}
+
+	var decoded retryPlan
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return err
+	}
+
+	*plan = RetryPlan(decoded)
+
+	return nil
+}
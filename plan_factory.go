@@ -27,6 +27,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 	switch t := step.(type) {
 	case AggregatePlan:
 		plan.Aggregate = &t
+	case InParallelPlan:
+		plan.InParallel = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -41,6 +43,10 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.OnSuccess = &t
 	case OnFailurePlan:
 		plan.OnFailure = &t
+	case OnErrorPlan:
+		plan.OnError = &t
+	case OnAbortPlan:
+		plan.OnAbort = &t
 	case TryPlan:
 		plan.Try = &t
 	case DependentGetPlan:
@@ -49,6 +55,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case ConditionalPlan:
+		plan.Conditional = &t
 	default:
 		panic(fmt.Sprintf("don't know how to construct plan from %T", step))
 	}
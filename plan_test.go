@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -212,8 +214,82 @@ var _ = Describe("Plan", func() {
 				atc.Plan{
 					ID: "22",
 					Retry: &atc.RetryPlan{
-						atc.Plan{
-							ID: "23",
+						Attempts: []atc.Plan{
+							atc.Plan{
+								ID: "23",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+							atc.Plan{
+								ID: "24",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+							atc.Plan{
+								ID: "25",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+					},
+				},
+
+				atc.Plan{
+					ID: "26",
+					InParallel: &atc.InParallelPlan{
+						Steps: []atc.Plan{
+							atc.Plan{
+								ID: "27",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+						Limit:    2,
+						FailFast: true,
+					},
+				},
+
+				atc.Plan{
+					ID: "28",
+					Conditional: &atc.ConditionalPlan{
+						Condition: `params.platform == "linux"`,
+						Params:    atc.Params{"platform": "linux", "some": "secret"},
+						Step: atc.Plan{
+							ID: "29",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Params:     atc.Params{"platform": "linux", "some": "secret"},
+							},
+						},
+					},
+				},
+
+				atc.Plan{
+					ID: "30",
+					OnError: &atc.OnErrorPlan{
+						Step: atc.Plan{
+							ID: "31",
 							Task: &atc.TaskPlan{
 								Name:       "name",
 								ConfigPath: "some/config/path.yml",
@@ -222,8 +298,8 @@ var _ = Describe("Plan", func() {
 								},
 							},
 						},
-						atc.Plan{
-							ID: "24",
+						Next: atc.Plan{
+							ID: "32",
 							Task: &atc.TaskPlan{
 								Name:       "name",
 								ConfigPath: "some/config/path.yml",
@@ -232,8 +308,24 @@ var _ = Describe("Plan", func() {
 								},
 							},
 						},
-						atc.Plan{
-							ID: "25",
+					},
+				},
+
+				atc.Plan{
+					ID: "33",
+					OnAbort: &atc.OnAbortPlan{
+						Step: atc.Plan{
+							ID: "34",
+							Task: &atc.TaskPlan{
+								Name:       "name",
+								ConfigPath: "some/config/path.yml",
+								Config: &atc.TaskConfig{
+									Params: map[string]string{"some": "secret"},
+								},
+							},
+						},
+						Next: atc.Plan{
+							ID: "35",
 							Task: &atc.TaskPlan{
 								Name:       "name",
 								ConfigPath: "some/config/path.yml",
@@ -417,9 +509,112 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "in_parallel": {
+        "steps": [
+          {
+            "id": "27",
+            "task": {
+              "name": "name",
+              "privileged": false
+            }
+          }
+        ],
+        "limit": 2,
+        "fail_fast": true
+      }
+    },
+    {
+      "id": "28",
+      "conditional": {
+        "condition": "params.platform == \"linux\"",
+        "step": {
+          "id": "29",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    },
+    {
+      "id": "30",
+      "on_error": {
+        "step": {
+          "id": "31",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        },
+        "on_error": {
+          "id": "32",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
+    },
+    {
+      "id": "33",
+      "on_abort": {
+        "step": {
+          "id": "34",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        },
+        "on_abort": {
+          "id": "35",
+          "task": {
+            "name": "name",
+            "privileged": false
+          }
+        }
+      }
     }
   ]
 }
 `))
 	})
+
+	Describe("RetryPlan", func() {
+		It("can be decoded from the list of attempts that it used to be", func() {
+			var plan atc.Plan
+			err := json.Unmarshal([]byte(`{"id":"1","retry":[{"id":"2","task":{"name":"some-task"}}]}`), &plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Retry).To(Equal(&atc.RetryPlan{
+				Attempts: []atc.Plan{
+					{ID: "2", Task: &atc.TaskPlan{Name: "some-task"}},
+				},
+			}))
+		})
+
+		It("round-trips with its policy", func() {
+			original := atc.Plan{
+				ID: "1",
+				Retry: &atc.RetryPlan{
+					Attempts: []atc.Plan{
+						{ID: "2", Task: &atc.TaskPlan{Name: "some-task"}},
+					},
+					Backoff:    "10s",
+					MaxBackoff: "2m",
+					On:         []atc.RetryCondition{atc.RetryOnErrored},
+				},
+			}
+
+			payload, err := json.Marshal(original)
+			Expect(err).NotTo(HaveOccurred())
+
+			var plan atc.Plan
+			err = json.Unmarshal(payload, &plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).To(Equal(original))
+		})
+	})
 })
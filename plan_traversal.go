@@ -29,6 +29,14 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 			}
 		}
 
+	case plan.InParallel != nil:
+		for i := range plan.InParallel.Steps {
+			err = pt.Traverse(&plan.InParallel.Steps[i])
+			if err != nil {
+				return err
+			}
+		}
+
 	case plan.Do != nil:
 		for i := range *plan.Do {
 			err = pt.Traverse(&(*plan.Do)[i])
@@ -43,6 +51,9 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 	case plan.Try != nil:
 		return pt.Traverse(&plan.Try.Step)
 
+	case plan.Conditional != nil:
+		return pt.Traverse(&plan.Conditional.Step)
+
 	case plan.OnSuccess != nil:
 		err = pt.Traverse(&plan.OnSuccess.Step)
 		if err != nil {
@@ -57,6 +68,20 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 		}
 		return pt.Traverse(&plan.OnFailure.Next)
 
+	case plan.OnError != nil:
+		err = pt.Traverse(&plan.OnError.Step)
+		if err != nil {
+			return err
+		}
+		return pt.Traverse(&plan.OnError.Next)
+
+	case plan.OnAbort != nil:
+		err = pt.Traverse(&plan.OnAbort.Step)
+		if err != nil {
+			return err
+		}
+		return pt.Traverse(&plan.OnAbort.Next)
+
 	case plan.Ensure != nil:
 		err = pt.Traverse(&plan.Ensure.Step)
 		if err != nil {
@@ -65,8 +90,8 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 		return pt.Traverse(&plan.Ensure.Next)
 
 	case plan.Retry != nil:
-		for i := range *plan.Retry {
-			err = pt.Traverse(&(*plan.Retry)[i])
+		for i := range plan.Retry.Attempts {
+			err = pt.Traverse(&plan.Retry.Attempts[i])
 			if err != nil {
 				return err
 			}
@@ -7,6 +7,7 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel   *json.RawMessage `json:"in_parallel,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
 		Put          *json.RawMessage `json:"put,omitempty"`
@@ -14,10 +15,13 @@ func (plan Plan) Public() *json.RawMessage {
 		Ensure       *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess    *json.RawMessage `json:"on_success,omitempty"`
 		OnFailure    *json.RawMessage `json:"on_failure,omitempty"`
+		OnError      *json.RawMessage `json:"on_error,omitempty"`
+		OnAbort      *json.RawMessage `json:"on_abort,omitempty"`
 		Try          *json.RawMessage `json:"try,omitempty"`
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+		Conditional  *json.RawMessage `json:"conditional,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -26,6 +30,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -54,6 +62,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.OnFailure = plan.OnFailure.Public()
 	}
 
+	if plan.OnError != nil {
+		public.OnError = plan.OnError.Public()
+	}
+
+	if plan.OnAbort != nil {
+		public.OnAbort = plan.OnAbort.Public()
+	}
+
 	if plan.Try != nil {
 		public.Try = plan.Try.Public()
 	}
@@ -70,6 +86,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.Conditional != nil {
+		public.Conditional = plan.Conditional.Public()
+	}
+
 	return enc(public)
 }
 
@@ -83,6 +103,24 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -139,6 +177,26 @@ func (plan OnFailurePlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan OnErrorPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		Next *json.RawMessage `json:"on_error"`
+	}{
+		Step: plan.Step.Public(),
+		Next: plan.Next.Public(),
+	})
+}
+
+func (plan OnAbortPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step *json.RawMessage `json:"step"`
+		Next *json.RawMessage `json:"on_abort"`
+	}{
+		Step: plan.Step.Public(),
+		Next: plan.Next.Public(),
+	})
+}
+
 func (plan OnSuccessPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -189,11 +247,21 @@ func (plan TryPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ConditionalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Condition string           `json:"condition"`
+		Step      *json.RawMessage `json:"step"`
+	}{
+		Condition: plan.Condition,
+		Step:      plan.Step.Public(),
+	})
+}
+
 func (plan RetryPlan) Public() *json.RawMessage {
-	public := make([]*json.RawMessage, len(plan))
+	public := make([]*json.RawMessage, len(plan.Attempts))
 
-	for i := 0; i < len(plan); i++ {
-		public[i] = plan[i].Public()
+	for i := 0; i < len(plan.Attempts); i++ {
+		public[i] = plan.Attempts[i].Public()
 	}
 
 	return enc(public)
@@ -15,6 +15,7 @@ type RadarDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 
 	IsPaused() (bool, error)
@@ -22,6 +23,7 @@ type RadarDB interface {
 	Reload() (bool, error)
 
 	GetLatestVersionedResource(resourceName string) (db.SavedVersionedResource, bool, error)
+	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (db.SavedVersionedResource, bool, error)
 	GetResource(resourceName string) (db.SavedResource, bool, error)
 	GetResourceType(resourceTypeName string) (db.SavedResourceType, bool, error)
 	PauseResource(resourceName string) error
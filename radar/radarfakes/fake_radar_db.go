@@ -157,6 +157,23 @@ type FakeRadarDB struct {
 		result2 bool
 		result3 error
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	GetVersionedResourceByVersionStub        func(atcVersion atc.Version, resourceName string) (db.SavedVersionedResource, bool, error)
+	getVersionedResourceByVersionMutex       sync.RWMutex
+	getVersionedResourceByVersionArgsForCall []struct {
+		atcVersion   atc.Version
+		resourceName string
+	}
+	getVersionedResourceByVersionReturns struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -700,6 +717,67 @@ func (fake *FakeRadarDB) AcquireResourceTypeCheckingLockReturns(result1 db.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRadarDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRadarDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (db.SavedVersionedResource, bool, error) {
+	fake.getVersionedResourceByVersionMutex.Lock()
+	fake.getVersionedResourceByVersionArgsForCall = append(fake.getVersionedResourceByVersionArgsForCall, struct {
+		atcVersion   atc.Version
+		resourceName string
+	}{atcVersion, resourceName})
+	fake.recordInvocation("GetVersionedResourceByVersion", []interface{}{atcVersion, resourceName})
+	fake.getVersionedResourceByVersionMutex.Unlock()
+	if fake.GetVersionedResourceByVersionStub != nil {
+		return fake.GetVersionedResourceByVersionStub(atcVersion, resourceName)
+	} else {
+		return fake.getVersionedResourceByVersionReturns.result1, fake.getVersionedResourceByVersionReturns.result2, fake.getVersionedResourceByVersionReturns.result3
+	}
+}
+
+func (fake *FakeRadarDB) GetVersionedResourceByVersionCallCount() int {
+	fake.getVersionedResourceByVersionMutex.RLock()
+	defer fake.getVersionedResourceByVersionMutex.RUnlock()
+	return len(fake.getVersionedResourceByVersionArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetVersionedResourceByVersionArgsForCall(i int) (atc.Version, string) {
+	fake.getVersionedResourceByVersionMutex.RLock()
+	defer fake.getVersionedResourceByVersionMutex.RUnlock()
+	return fake.getVersionedResourceByVersionArgsForCall[i].atcVersion, fake.getVersionedResourceByVersionArgsForCall[i].resourceName
+}
+
+func (fake *FakeRadarDB) GetVersionedResourceByVersionReturns(result1 db.SavedVersionedResource, result2 bool, result3 error) {
+	fake.GetVersionedResourceByVersionStub = nil
+	fake.getVersionedResourceByVersionReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -737,6 +815,10 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
 	defer fake.acquireResourceTypeCheckingLockMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	fake.getVersionedResourceByVersionMutex.RLock()
+	defer fake.getVersionedResourceByVersionMutex.RUnlock()
 	return fake.invocations
 }
 
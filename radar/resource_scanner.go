@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -19,6 +20,7 @@ type resourceScanner struct {
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       credentials.Variables
 }
 
 func NewResourceScanner(
@@ -27,6 +29,7 @@ func NewResourceScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables credentials.Variables,
 ) Scanner {
 	return &resourceScanner{
 		clock:           clock,
@@ -34,6 +37,7 @@ func NewResourceScanner(
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -59,6 +63,11 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return 0, err
 	}
 
+	if savedResource.Config.CheckEvery == atc.CheckEveryNever {
+		logger.Debug("periodic-checking-disabled")
+		return interval, nil
+	}
+
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -85,8 +94,24 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return interval, err
 	}
 
+	fromVersion := atc.Version(vr.Version)
+
+	if savedResource.PinnedVersion != nil {
+		_, found, err := scanner.db.GetVersionedResourceByVersion(savedResource.PinnedVersion, resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-pinned-version", err)
+			return interval, err
+		}
+
+		// check from the pinned version so that it is found again if it has
+		// gone missing, rather than leaving every consuming job blocked
+		if !found {
+			fromVersion = savedResource.PinnedVersion
+		}
+	}
+
 	err = swallowErrResourceScriptFailed(
-		scanner.scan(logger.Session("tick"), savedResource, atc.Version(vr.Version)),
+		scanner.scan(logger.Session("tick"), savedResource, fromVersion),
 	)
 	if err != nil {
 		return interval, err
@@ -217,6 +242,30 @@ func (scanner *resourceScanner) scan(
 		return errPipelineRemoved
 	}
 
+	source, err := credentials.EvaluateSource(scanner.variables, savedResource.Config.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
+	resourceTypes, err := credentials.EvaluateResourceTypes(scanner.variables, scanner.db.Config().ResourceTypes)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
 	res, err := scanner.tracker.Init(
 		logger,
 		resource.TrackerMetadata{
@@ -228,7 +277,7 @@ func (scanner *resourceScanner) scan(
 		resource.ResourceType(savedResource.Config.Type),
 		[]string{},
 		scanner.db.TeamID(),
-		scanner.db.Config().ResourceTypes,
+		resourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -242,7 +291,7 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
 	setErr := scanner.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
@@ -288,7 +337,7 @@ func swallowErrResourceScriptFailed(err error) error {
 
 func (scanner *resourceScanner) checkInterval(resourceConfig atc.ResourceConfig) (time.Duration, error) {
 	interval := scanner.defaultInterval
-	if resourceConfig.CheckEvery != "" {
+	if resourceConfig.CheckEvery != "" && resourceConfig.CheckEvery != atc.CheckEveryNever {
 		configuredInterval, err := time.ParseDuration(resourceConfig.CheckEvery)
 		if err != nil {
 			return 0, err
@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/credentialsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 
@@ -24,10 +26,11 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		fakeClock   *fakeclock.FakeClock
-		interval    time.Duration
+		fakeTracker   *rfakes.FakeTracker
+		fakeRadarDB   *radarfakes.FakeRadarDB
+		fakeClock     *fakeclock.FakeClock
+		fakeVariables *credentialsfakes.FakeVariables
+		interval      time.Duration
 
 		scanner Scanner
 
@@ -43,6 +46,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
+		fakeVariables = new(credentialsfakes.FakeVariables)
 		interval = 1 * time.Minute
 
 		fakeRadarDB.GetPipelineIDReturns(42)
@@ -52,6 +56,7 @@ var _ = Describe("ResourceScanner", func() {
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			fakeVariables,
 		)
 
 		resourceConfig = atc.ResourceConfig{
@@ -210,6 +215,23 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource config disables periodic checking", func() {
+				BeforeEach(func() {
+					savedResource.Config.CheckEvery = "never"
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+					Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(BeZero())
+					Expect(fakeTracker.InitCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval, to notice when the config changes", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+			})
+
 			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeRadarDB.AcquireResourceCheckingLockCallCount()).To(Equal(1))
 
@@ -251,6 +273,124 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is pinned", func() {
+				BeforeEach(func() {
+					savedResource.PinnedVersion = atc.Version{"version": "0"}
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+
+					fakeRadarDB.GetLatestVersionedResourceReturns(
+						db.SavedVersionedResource{
+							ID: 1,
+							VersionedResource: db.VersionedResource{
+								Version: db.Version{
+									"version": "1",
+								},
+							},
+						}, true, nil)
+				})
+
+				Context("when the pinned version is available", func() {
+					BeforeEach(func() {
+						fakeRadarDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 2}, true, nil)
+					})
+
+					It("checks from the current version", func() {
+						pinnedVersion, resourceName := fakeRadarDB.GetVersionedResourceByVersionArgsForCall(0)
+						Expect(pinnedVersion).To(Equal(atc.Version{"version": "0"}))
+						Expect(resourceName).To(Equal("some-resource"))
+
+						_, version := fakeResource.CheckArgsForCall(0)
+						Expect(version).To(Equal(atc.Version{"version": "1"}))
+					})
+				})
+
+				Context("when the pinned version is unavailable", func() {
+					BeforeEach(func() {
+						fakeRadarDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, nil)
+					})
+
+					It("checks from the pinned version", func() {
+						_, version := fakeResource.CheckArgsForCall(0)
+						Expect(version).To(Equal(atc.Version{"version": "0"}))
+					})
+				})
+			})
+
+			Context("when the resource and resource types reference variables", func() {
+				BeforeEach(func() {
+					fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+						switch name {
+						case "private-key":
+							return "some-private-key", true, nil
+						case "registry-password":
+							return "some-password", true, nil
+						}
+
+						return nil, false, nil
+					}
+
+					savedResource.Config.Source = atc.Source{
+						"uri":         "http://example.com",
+						"private_key": "((private-key))",
+					}
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+
+					fakeRadarDB.ConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{savedResource.Config},
+						ResourceTypes: atc.ResourceTypes{
+							{
+								Name:   "some-custom-resource",
+								Type:   "docker-image",
+								Source: atc.Source{"password": "((registry-password))"},
+							},
+						},
+					})
+				})
+
+				It("checks with the evaluated source", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{
+						"uri":         "http://example.com",
+						"private_key": "some-private-key",
+					}))
+				})
+
+				It("initializes the resource with the evaluated resource types", func() {
+					_, _, _, _, _, _, resourceTypes, _ := fakeTracker.InitArgsForCall(0)
+					Expect(resourceTypes).To(Equal(atc.ResourceTypes{
+						{
+							Name:   "some-custom-resource",
+							Type:   "docker-image",
+							Source: atc.Source{"password": "some-password"},
+						},
+					}))
+				})
+
+				It("does not leak the evaluated source into the container identifier", func() {
+					_, _, session, _, _, _, _, _ := fakeTracker.InitArgsForCall(0)
+					Expect(session.ID.CheckSource).To(Equal(savedResource.Config.Source))
+				})
+
+				Context("when a variable is undefined", func() {
+					BeforeEach(func() {
+						fakeVariables.GetStub = nil
+						fakeVariables.GetReturns(nil, false, nil)
+					})
+
+					It("does not check", func() {
+						Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						resource, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(resource).To(Equal(savedResource))
+						Expect(err).To(Equal(credentials.UndefinedVariablesError{Names: []string{"private-key"}}))
+					})
+				})
+			})
+
 			Context("when the check returns versions", func() {
 				var checkedFrom chan atc.Version
 
@@ -514,6 +654,22 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource config disables periodic checking", func() {
+				BeforeEach(func() {
+					savedResource.Config.CheckEvery = "never"
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("still checks, leasing for the default interval", func() {
+					Expect(scanErr).NotTo(HaveOccurred())
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+					_, _, leaseInterval, immediate := fakeRadarDB.AcquireResourceCheckingLockArgsForCall(0)
+					Expect(leaseInterval).To(Equal(interval))
+					Expect(immediate).To(BeTrue())
+				})
+			})
+
 			Context("when the lock is not immediately available", func() {
 				BeforeEach(func() {
 					results := make(chan bool, 4)
@@ -43,6 +43,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			nil,
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
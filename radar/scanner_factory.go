@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/resource"
 )
 
@@ -15,20 +16,24 @@ type scannerFactory struct {
 	tracker         resource.Tracker
 	defaultInterval time.Duration
 	externalURL     string
+	credentials     credentials.Manager
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
 	externalURL string,
+	credentialsManager credentials.Manager,
 ) ScannerFactory {
 	return &scannerFactory{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		externalURL:     externalURL,
+		credentials:     credentialsManager,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	variables := credentials.VariablesFor(f.credentials, db.TeamName(), db.GetPipelineName())
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL, variables)
 }
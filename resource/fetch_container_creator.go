@@ -72,6 +72,7 @@ func (c *fetchContainerCreator) CreateWithVolume(resourceType string, volume wor
 		Tags:      c.tags,
 		TeamID:    c.teamID,
 		Env:       c.metadata.Env(),
+		Limits:    c.session.Metadata.ContainerLimits,
 		Outputs: []worker.VolumeMount{
 			{
 				Volume:    volume,
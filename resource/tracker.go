@@ -98,6 +98,7 @@ func (tracker *tracker) InitWithSources(
 		Tags:      tags,
 		TeamID:    teamID,
 		Env:       metadata.Env(),
+		Limits:    session.Metadata.ContainerLimits,
 	}
 
 	compatibleWorkers, err := tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), resourceTypes)
@@ -215,6 +216,7 @@ func (tracker *tracker) Init(
 			Tags:      tags,
 			TeamID:    teamID,
 			Env:       metadata.Env(),
+			Limits:    session.Metadata.ContainerLimits,
 		},
 		resourceTypes,
 	)
@@ -0,0 +1,81 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/scheduler/buildqueue"
+)
+
+type FakeQueue struct {
+	AdmitStub        func(logger lager.Logger, buildID int) (bool, error)
+	admitMutex       sync.RWMutex
+	admitArgsForCall []struct {
+		logger  lager.Logger
+		buildID int
+	}
+	admitReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQueue) Admit(logger lager.Logger, buildID int) (bool, error) {
+	fake.admitMutex.Lock()
+	fake.admitArgsForCall = append(fake.admitArgsForCall, struct {
+		logger  lager.Logger
+		buildID int
+	}{logger, buildID})
+	fake.recordInvocation("Admit", []interface{}{logger, buildID})
+	fake.admitMutex.Unlock()
+	if fake.AdmitStub != nil {
+		return fake.AdmitStub(logger, buildID)
+	} else {
+		return fake.admitReturns.result1, fake.admitReturns.result2
+	}
+}
+
+func (fake *FakeQueue) AdmitCallCount() int {
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
+	return len(fake.admitArgsForCall)
+}
+
+func (fake *FakeQueue) AdmitArgsForCall(i int) (lager.Logger, int) {
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
+	return fake.admitArgsForCall[i].logger, fake.admitArgsForCall[i].buildID
+}
+
+func (fake *FakeQueue) AdmitReturns(result1 bool, result2 error) {
+	fake.AdmitStub = nil
+	fake.admitReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQueue) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.admitMutex.RLock()
+	defer fake.admitMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeQueue) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.Queue = new(FakeQueue)
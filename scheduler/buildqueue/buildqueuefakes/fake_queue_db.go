@@ -0,0 +1,81 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildqueue"
+)
+
+type FakeQueueDB struct {
+	GetBuildQueuePositionStub        func(buildID int) (db.BuildQueuePosition, bool, error)
+	getBuildQueuePositionMutex       sync.RWMutex
+	getBuildQueuePositionArgsForCall []struct {
+		buildID int
+	}
+	getBuildQueuePositionReturns struct {
+		result1 db.BuildQueuePosition
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQueueDB) GetBuildQueuePosition(buildID int) (db.BuildQueuePosition, bool, error) {
+	fake.getBuildQueuePositionMutex.Lock()
+	fake.getBuildQueuePositionArgsForCall = append(fake.getBuildQueuePositionArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildQueuePosition", []interface{}{buildID})
+	fake.getBuildQueuePositionMutex.Unlock()
+	if fake.GetBuildQueuePositionStub != nil {
+		return fake.GetBuildQueuePositionStub(buildID)
+	} else {
+		return fake.getBuildQueuePositionReturns.result1, fake.getBuildQueuePositionReturns.result2, fake.getBuildQueuePositionReturns.result3
+	}
+}
+
+func (fake *FakeQueueDB) GetBuildQueuePositionCallCount() int {
+	fake.getBuildQueuePositionMutex.RLock()
+	defer fake.getBuildQueuePositionMutex.RUnlock()
+	return len(fake.getBuildQueuePositionArgsForCall)
+}
+
+func (fake *FakeQueueDB) GetBuildQueuePositionArgsForCall(i int) int {
+	fake.getBuildQueuePositionMutex.RLock()
+	defer fake.getBuildQueuePositionMutex.RUnlock()
+	return fake.getBuildQueuePositionArgsForCall[i].buildID
+}
+
+func (fake *FakeQueueDB) GetBuildQueuePositionReturns(result1 db.BuildQueuePosition, result2 bool, result3 error) {
+	fake.GetBuildQueuePositionStub = nil
+	fake.getBuildQueuePositionReturns = struct {
+		result1 db.BuildQueuePosition
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeQueueDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildQueuePositionMutex.RLock()
+	defer fake.getBuildQueuePositionMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeQueueDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.QueueDB = new(FakeQueueDB)
@@ -0,0 +1,75 @@
+package buildqueue
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Queue
+
+type Queue interface {
+	Admit(logger lager.Logger, buildID int) (bool, error)
+}
+
+//go:generate counterfeiter . QueueDB
+
+type QueueDB interface {
+	GetBuildQueuePosition(buildID int) (db.BuildQueuePosition, bool, error)
+}
+
+// NewQueue returns a Queue which limits the number of builds running at once,
+// both across the whole cluster and within each team. A limit of 0 means
+// unlimited.
+func NewQueue(db QueueDB, maxConcurrentBuilds int, maxConcurrentBuildsPerTeam int) Queue {
+	return &queue{
+		db:                         db,
+		maxConcurrentBuilds:        maxConcurrentBuilds,
+		maxConcurrentBuildsPerTeam: maxConcurrentBuildsPerTeam,
+	}
+}
+
+type queue struct {
+	db                         QueueDB
+	maxConcurrentBuilds        int
+	maxConcurrentBuildsPerTeam int
+}
+
+func (q *queue) Admit(logger lager.Logger, buildID int) (bool, error) {
+	if q.maxConcurrentBuilds == 0 && q.maxConcurrentBuildsPerTeam == 0 {
+		return true, nil
+	}
+
+	logger = logger.Session("admit", lager.Data{"build-id": buildID})
+
+	position, found, err := q.db.GetBuildQueuePosition(buildID)
+	if err != nil {
+		logger.Error("failed-to-get-build-queue-position", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Debug("build-not-queued")
+		return false, nil
+	}
+
+	// only as many builds as there are free slots may start, taken from the
+	// front of the queue, so that a busy pipeline cannot jump ahead of builds
+	// that have been waiting longer or have a higher priority
+	if q.maxConcurrentBuilds > 0 && position.Running+position.Ahead >= q.maxConcurrentBuilds {
+		logger.Debug("max-concurrent-builds-reached", lager.Data{
+			"running": position.Running,
+			"ahead":   position.Ahead,
+		})
+		return false, nil
+	}
+
+	if q.maxConcurrentBuildsPerTeam > 0 && position.TeamRunning+position.TeamAhead >= q.maxConcurrentBuildsPerTeam {
+		logger.Debug("max-concurrent-builds-per-team-reached", lager.Data{
+			"running": position.TeamRunning,
+			"ahead":   position.TeamAhead,
+		})
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,140 @@
+package buildqueue_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildqueue"
+	"github.com/concourse/atc/scheduler/buildqueue/buildqueuefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		fakeDB *buildqueuefakes.FakeQueueDB
+
+		maxConcurrentBuilds        int
+		maxConcurrentBuildsPerTeam int
+
+		admitted bool
+		admitErr error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(buildqueuefakes.FakeQueueDB)
+
+		maxConcurrentBuilds = 0
+		maxConcurrentBuildsPerTeam = 0
+	})
+
+	JustBeforeEach(func() {
+		queue := buildqueue.NewQueue(fakeDB, maxConcurrentBuilds, maxConcurrentBuildsPerTeam)
+		admitted, admitErr = queue.Admit(lagertest.NewTestLogger("test"), 42)
+	})
+
+	Context("when there are no limits", func() {
+		It("admits the build without consulting the queue", func() {
+			Expect(admitErr).NotTo(HaveOccurred())
+			Expect(admitted).To(BeTrue())
+			Expect(fakeDB.GetBuildQueuePositionCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when there is a global limit", func() {
+		BeforeEach(func() {
+			maxConcurrentBuilds = 3
+		})
+
+		It("looks up the right build", func() {
+			Expect(fakeDB.GetBuildQueuePositionArgsForCall(0)).To(Equal(42))
+		})
+
+		Context("when the build is within the free slots", func() {
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{
+					Ahead:   1,
+					Running: 1,
+				}, true, nil)
+			})
+
+			It("admits the build", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+		})
+
+		Context("when builds ahead of it will take the free slots", func() {
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{
+					Ahead:   2,
+					Running: 1,
+				}, true, nil)
+			})
+
+			It("does not admit the build", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeFalse())
+			})
+		})
+
+		Context("when the build is no longer queued", func() {
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{}, false, nil)
+			})
+
+			It("does not admit the build", func() {
+				Expect(admitErr).NotTo(HaveOccurred())
+				Expect(admitted).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the queue fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{}, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(admitErr).To(Equal(disaster))
+				Expect(admitted).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when there is a team limit", func() {
+		BeforeEach(func() {
+			maxConcurrentBuildsPerTeam = 2
+		})
+
+		Context("when the team has a free slot for the build", func() {
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{
+					Ahead:       10,
+					Running:     10,
+					TeamRunning: 1,
+				}, true, nil)
+			})
+
+			It("admits the build regardless of other teams", func() {
+				Expect(admitted).To(BeTrue())
+			})
+		})
+
+		Context("when the team's slots are taken", func() {
+			BeforeEach(func() {
+				fakeDB.GetBuildQueuePositionReturns(db.BuildQueuePosition{
+					TeamAhead:   1,
+					TeamRunning: 1,
+				}, true, nil)
+			})
+
+			It("does not admit the build", func() {
+				Expect(admitted).To(BeFalse())
+			})
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/scheduler/buildqueue"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
 )
@@ -49,6 +50,7 @@ type BuildFactory interface {
 func NewBuildStarter(
 	db BuildStarterDB,
 	maxInFlightUpdater maxinflight.Updater,
+	buildQueue buildqueue.Queue,
 	factory BuildFactory,
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
@@ -57,6 +59,7 @@ func NewBuildStarter(
 	return &buildStarter{
 		db:                 db,
 		maxInFlightUpdater: maxInFlightUpdater,
+		buildQueue:         buildQueue,
 		factory:            factory,
 		scanner:            scanner,
 		inputMapper:        inputMapper,
@@ -67,6 +70,7 @@ func NewBuildStarter(
 type buildStarter struct {
 	db                 BuildStarterDB
 	maxInFlightUpdater maxinflight.Updater
+	buildQueue         buildqueue.Queue
 	factory            BuildFactory
 	execEngine         engine.Engine
 	scanner            Scanner
@@ -114,35 +118,8 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := config.JobInputs(jobConfig)
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
-
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
-
-		versions, err := s.db.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
-		}
-
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
-		if err != nil {
-			return false, err
-		}
-	}
-
-	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	buildInputs, found, err := s.determineBuildInputs(logger, nextPendingBuild, jobConfig)
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
 		return false, err
 	}
 	if !found {
@@ -171,6 +148,15 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	admitted, err := s.buildQueue.Admit(logger, nextPendingBuild.ID())
+	if err != nil {
+		return false, err
+	}
+	if !admitted {
+		logger.Debug("waiting-in-build-queue")
+		return false, nil
+	}
+
 	updated, err := s.db.UpdateBuildToScheduled(nextPendingBuild.ID())
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
@@ -209,3 +195,55 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+func (s *buildStarter) determineBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	jobConfig atc.JobConfig,
+) ([]db.BuildInput, bool, error) {
+	if nextPendingBuild.RerunOf() != 0 {
+		// a re-run uses the inputs of the build it re-runs, which were saved
+		// along with it
+		buildInputs, _, err := nextPendingBuild.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-rerun-build-inputs", err)
+			return nil, false, err
+		}
+
+		return buildInputs, true, nil
+	}
+
+	if nextPendingBuild.IsManuallyTriggered() {
+		jobBuildInputs := config.JobInputs(jobConfig)
+		for _, input := range jobBuildInputs {
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
+
+			err := s.scanner.Scan(scanLog, input.Resource)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		versions, err := s.db.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, false, err
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, false, err
+	}
+
+	return buildInputs, found, nil
+}
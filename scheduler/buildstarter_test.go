@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/buildqueue/buildqueuefakes"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
@@ -25,6 +26,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 	var (
 		fakeDB           *schedulerfakes.FakeBuildStarterDB
 		fakeUpdater      *maxinflightfakes.FakeUpdater
+		fakeQueue        *buildqueuefakes.FakeQueue
 		fakeFactory      *schedulerfakes.FakeBuildFactory
 		fakeEngine       *enginefakes.FakeEngine
 		pendingBuilds    []db.Build
@@ -40,13 +42,15 @@ var _ = Describe("I'm a BuildStarter", func() {
 	BeforeEach(func() {
 		fakeDB = new(schedulerfakes.FakeBuildStarterDB)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
+		fakeQueue = new(buildqueuefakes.FakeQueue)
+		fakeQueue.AdmitReturns(true, nil)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeEngine = new(enginefakes.FakeEngine)
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 
-		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeQueue, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
 
 		disaster = errors.New("bad thing")
 	})
@@ -219,6 +223,66 @@ var _ = Describe("I'm a BuildStarter", func() {
 			})
 		})
 
+		Context("when re-running a build", func() {
+			var rerunInputs []db.BuildInput
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}}}
+
+				rerunInputs = []db.BuildInput{{Name: "input-1"}}
+
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.IDReturns(66)
+				createdBuild.IsManuallyTriggeredReturns(true)
+				createdBuild.RerunOfReturns(42)
+				createdBuild.GetResourcesReturns(rerunInputs, nil, nil)
+
+				pendingBuilds = []db.Build{createdBuild}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeDB.UpdateBuildToScheduledReturns(true, nil)
+				fakeFactory.CreateReturns(atc.Plan{}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					atc.ResourceTypes{{Name: "some-resource-type"}},
+					pendingBuilds,
+				)
+			})
+
+			It("starts the build with the inputs of the build it re-runs", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(actualInputs).To(Equal(rerunInputs))
+			})
+
+			It("does not check resources or determine the next inputs", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			Context("when getting the inputs of the build fails", func() {
+				BeforeEach(func() {
+					createdBuild.GetResourcesReturns(nil, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+					Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when not manually triggered", func() {
 			JustBeforeEach(func() {
 				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
@@ -291,6 +355,34 @@ var _ = Describe("I'm a BuildStarter", func() {
 						pendingBuilds = []db.Build{pendingBuild1, pendingBuild2, pendingBuild3}
 					})
 
+					Context("when the build queue does not admit the build", func() {
+						BeforeEach(func() {
+							fakeQueue.AdmitReturns(false, nil)
+						})
+
+						It("asked about the first build", func() {
+							Expect(fakeQueue.AdmitCallCount()).To(Equal(1))
+							_, actualBuildID := fakeQueue.AdmitArgsForCall(0)
+							Expect(actualBuildID).To(Equal(99))
+						})
+
+						It("doesn't schedule any builds", func() {
+							Expect(tryStartErr).NotTo(HaveOccurred())
+							Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when consulting the build queue fails", func() {
+						BeforeEach(func() {
+							fakeQueue.AdmitReturns(false, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(tryStartErr).To(Equal(disaster))
+							Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+						})
+					})
+
 					Context("when marking the build as scheduled fails", func() {
 						BeforeEach(func() {
 							fakeDB.UpdateBuildToScheduledReturns(false, disaster)
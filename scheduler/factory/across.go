@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+type acrossValue struct {
+	Var   string
+	Value string
+}
+
+type acrossCombination []acrossValue
+
+// across expands a step over every combination of its vars' values, running
+// the expanded steps in parallel.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	inParallel := atc.InParallelPlan{}
+
+	for _, combination := range acrossCombinations(planConfig.Across) {
+		nextStep, err := factory.constructPlanFromConfig(
+			combination.apply(planConfig),
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		inParallel.Steps = append(inParallel.Steps, nextStep)
+	}
+
+	return factory.planFactory.NewPlan(inParallel), nil
+}
+
+// acrossCombinations returns every combination of the vars' values, varying
+// the last var fastest.
+func acrossCombinations(vars []atc.AcrossVarConfig) []acrossCombination {
+	combinations := []acrossCombination{{}}
+
+	for _, v := range vars {
+		var expanded []acrossCombination
+
+		for _, combination := range combinations {
+			for _, value := range v.Values {
+				next := make(acrossCombination, len(combination), len(combination)+1)
+				copy(next, combination)
+				expanded = append(expanded, append(next, acrossValue{Var: v.Var, Value: value}))
+			}
+		}
+
+		combinations = expanded
+	}
+
+	return combinations
+}
+
+// apply returns a copy of the step for the combination, named after it, with
+// each var passed as a param and interpolated into the input and output
+// mappings.
+func (combination acrossCombination) apply(planConfig atc.PlanConfig) atc.PlanConfig {
+	planConfig.Across = nil
+
+	pairs := make([]string, len(combination))
+	for i, v := range combination {
+		pairs[i] = fmt.Sprintf("%s: %s", v.Var, v.Value)
+	}
+
+	planConfig.Task = fmt.Sprintf("%s (%s)", planConfig.Task, strings.Join(pairs, ", "))
+
+	params := atc.Params{}
+	for k, v := range planConfig.Params {
+		params[k] = v
+	}
+
+	for _, v := range combination {
+		params[v.Var] = v.Value
+	}
+
+	planConfig.Params = params
+	planConfig.InputMapping = combination.interpolate(planConfig.InputMapping)
+	planConfig.OutputMapping = combination.interpolate(planConfig.OutputMapping)
+
+	return planConfig
+}
+
+func (combination acrossCombination) interpolate(mapping map[string]string) map[string]string {
+	if mapping == nil {
+		return nil
+	}
+
+	interpolated := make(map[string]string, len(mapping))
+	for k, v := range mapping {
+		for _, value := range combination {
+			v = strings.Replace(v, "(("+value.Var+"))", value.Value, -1)
+		}
+
+		interpolated[k] = v
+	}
+
+	return interpolated
+}
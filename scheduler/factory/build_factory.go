@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 )
 
@@ -18,12 +19,14 @@ type BuildFactory interface {
 type buildFactory struct {
 	PipelineID  int
 	planFactory atc.PlanFactory
+	variables   credentials.Variables
 }
 
-func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory) BuildFactory {
+func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory, variables credentials.Variables) BuildFactory {
 	return &buildFactory{
 		PipelineID:  pipelineID,
 		planFactory: planFactory,
+		variables:   variables,
 	}
 }
 
@@ -33,6 +36,11 @@ func (factory *buildFactory) Create(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	resourceTypes, err := credentials.EvaluateResourceTypes(factory.variables, resourceTypes)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
 	plan, err := factory.constructPlanFromJob(job, resources, resourceTypes, inputs)
 	if err != nil {
 		return atc.Plan{}, err
@@ -99,36 +107,63 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.ResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
-	if planConfig.Attempts == 0 {
+	if planConfig.Attempts == nil || planConfig.Attempts.Count == 0 {
 		plan, err = factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 		if err != nil {
 			return atc.Plan{}, err
 		}
 	} else {
-		retryStep := make(atc.RetryPlan, planConfig.Attempts)
+		retryStep := atc.RetryPlan{
+			Attempts:   make([]atc.Plan, planConfig.Attempts.Count),
+			Backoff:    planConfig.Attempts.Backoff,
+			MaxBackoff: planConfig.Attempts.MaxBackoff,
+			On:         planConfig.Attempts.On,
+		}
 
-		for i := 0; i < planConfig.Attempts; i++ {
+		for i := 0; i < planConfig.Attempts.Count; i++ {
 			attempt, err := factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 			if err != nil {
 				return atc.Plan{}, err
 			}
 
-			retryStep[i] = attempt
+			retryStep.Attempts[i] = attempt
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         planConfig.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if planConfig.If != "" {
+		params, err := credentials.EvaluateParams(factory.variables, planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		plan = factory.planFactory.NewPlan(atc.ConditionalPlan{
+			Condition: planConfig.If,
+			Params:    params,
+			Step:      plan,
+		})
+	}
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructUnhookedPlan(
@@ -165,15 +200,32 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		source, err := credentials.EvaluateSource(factory.variables, resource.Source)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := credentials.EvaluateParams(factory.variables, planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		getParams, err := credentials.EvaluateParams(factory.variables, planConfig.GetParams)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		putPlan := atc.PutPlan{
 			Type:          resource.Type,
 			Name:          logicalName,
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
-			Source:        resource.Source,
-			Params:        planConfig.Params,
+			Source:        source,
+			Params:        params,
 			Tags:          planConfig.Tags,
 			ResourceTypes: resourceTypes,
+
+			ContainerLimits: planConfig.ContainerLimits,
 		}
 
 		dependentGetPlan := atc.DependentGetPlan{
@@ -181,10 +233,12 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:          logicalName,
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
-			Params:        planConfig.GetParams,
+			Params:        getParams,
 			Tags:          planConfig.Tags,
-			Source:        resource.Source,
+			Source:        source,
 			ResourceTypes: resourceTypes,
+
+			ContainerLimits: planConfig.ContainerLimits,
 		}
 
 		plan = factory.planFactory.NewPlan(atc.OnSuccessPlan{
@@ -203,6 +257,16 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		source, err := credentials.EvaluateSource(factory.variables, resource.Source)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		params, err := credentials.EvaluateParams(factory.variables, planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		name := planConfig.Get
 		var version db.Version
 		for _, input := range inputs {
@@ -217,14 +281,21 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Name:          name,
 			PipelineID:    factory.PipelineID,
 			Resource:      resourceName,
-			Source:        resource.Source,
-			Params:        planConfig.Params,
+			Source:        source,
+			Params:        params,
 			Version:       atc.Version(version),
 			Tags:          planConfig.Tags,
 			ResourceTypes: resourceTypes,
+
+			ContainerLimits: planConfig.ContainerLimits,
 		})
 
 	case planConfig.Task != "":
+		params, err := credentials.EvaluateParams(factory.variables, planConfig.Params)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
 		plan = factory.planFactory.NewPlan(atc.TaskPlan{
 			Name:              planConfig.Task,
 			PipelineID:        factory.PipelineID,
@@ -233,10 +304,11 @@ func (factory *buildFactory) constructUnhookedPlan(
 			ConfigPath:        planConfig.TaskConfigPath,
 			Tags:              planConfig.Tags,
 			ResourceTypes:     resourceTypes,
-			Params:            planConfig.Params,
+			Params:            params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			Artifacts:         planConfig.Artifacts,
 		})
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
@@ -271,6 +343,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
@@ -294,6 +388,16 @@ type constructionParams struct {
 func (factory *buildFactory) applyHooks(cp constructionParams) (atc.Plan, error) {
 	var err error
 
+	cp, err = factory.abortIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	cp, err = factory.errorIfPresent(cp)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
 	cp, err = factory.failureIfPresent(cp)
 	if err != nil {
 		return atc.Plan{}, err
@@ -354,6 +458,48 @@ func (factory *buildFactory) failureIfPresent(cp constructionParams) (constructi
 	return cp, nil
 }
 
+func (factory *buildFactory) errorIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.hooks.Error != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.hooks.Error,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnErrorPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
+
+func (factory *buildFactory) abortIfPresent(cp constructionParams) (constructionParams, error) {
+	if cp.hooks.Abort != nil {
+		nextPlan, err := factory.constructPlanFromConfig(
+			*cp.hooks.Abort,
+			cp.resources,
+			cp.resourceTypes,
+			cp.inputs,
+		)
+		if err != nil {
+			return constructionParams{}, err
+		}
+
+		cp.plan = factory.planFactory.NewPlan(atc.OnAbortPlan{
+			Step: cp.plan,
+			Next: nextPlan,
+		})
+	}
+
+	return cp, nil
+}
+
 func (factory *buildFactory) ensureIfPresent(cp constructionParams) (constructionParams, error) {
 	if cp.hooks.Ensure != nil {
 		nextPlan, err := factory.constructPlanFromConfig(
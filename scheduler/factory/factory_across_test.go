@@ -0,0 +1,141 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when a task is run across vars", func() {
+		It("runs a task for every combination of their values in parallel", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "unit",
+						TaskConfigPath: "some/config/path.yml",
+						Params:         atc.Params{"some": "param"},
+						InputMapping:   map[string]string{"source": "source-((platform))"},
+						OutputMapping:  map[string]string{"binary": "binary-((platform))-((go_version))"},
+						Across: []atc.AcrossVarConfig{
+							{Var: "go_version", Values: []string{"1.7", "1.8"}},
+							{Var: "platform", Values: []string{"linux", "darwin"}},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := func(name string, goVersion string, platform string) atc.Plan {
+				return expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          name,
+					ConfigPath:    "some/config/path.yml",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					Params: atc.Params{
+						"some":       "param",
+						"go_version": goVersion,
+						"platform":   platform,
+					},
+					InputMapping:  map[string]string{"source": "source-" + platform},
+					OutputMapping: map[string]string{"binary": "binary-" + platform + "-" + goVersion},
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					taskPlan("unit (go_version: 1.7, platform: linux)", "1.7", "linux"),
+					taskPlan("unit (go_version: 1.7, platform: darwin)", "1.7", "darwin"),
+					taskPlan("unit (go_version: 1.8, platform: linux)", "1.8", "linux"),
+					taskPlan("unit (go_version: 1.8, platform: darwin)", "1.8", "darwin"),
+				},
+			})
+			Expect(actual).To(Equal(expected))
+
+			By("giving each task its own plan ID")
+			ids := map[atc.PlanID]bool{}
+			for _, step := range actual.InParallel.Steps {
+				ids[step.ID] = true
+			}
+			Expect(ids).To(HaveLen(4))
+		})
+
+		Context("when the task has hooks and attempts", func() {
+			It("applies them to each task", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:     "unit",
+							Attempts: &atc.AttemptsConfig{Count: 2},
+							Across: []atc.AcrossVarConfig{
+								{Var: "platform", Values: []string{"linux", "darwin"}},
+							},
+							Failure: &atc.PlanConfig{
+								Task: "alert",
+							},
+						},
+					},
+				}, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskPlan := func(platform string) atc.Plan {
+					return expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "unit (platform: " + platform + ")",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+						Params:        atc.Params{"platform": platform},
+					})
+				}
+
+				hookedTaskPlan := func(platform string) atc.Plan {
+					return expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+						Step: expectedPlanFactory.NewPlan(atc.RetryPlan{
+							Attempts: []atc.Plan{
+								taskPlan(platform),
+								taskPlan(platform),
+							},
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "alert",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					})
+				}
+
+				expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						hookedTaskPlan("linux"),
+						hookedTaskPlan("darwin"),
+					},
+				})
+				Expect(actual).To(Equal(expected))
+			})
+		})
+	})
+})
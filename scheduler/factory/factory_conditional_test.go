@@ -0,0 +1,157 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Conditional", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when I have a step with a condition", func() {
+		It("wraps the step in a conditional plan with the step's params", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:   "some thing",
+						Params: atc.Params{"platform": "linux"},
+						If:     `params.platform == "linux"`,
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+				Condition: `params.platform == "linux"`,
+				Params:    atc.Params{"platform": "linux"},
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some thing",
+					PipelineID:    42,
+					Params:        atc.Params{"platform": "linux"},
+					ResourceTypes: resourceTypes,
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when a step with a condition has hooks and attempts", func() {
+		It("skips the attempts and the hooks along with the step", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-resource",
+						If:       "inputs.some-resource.triggered",
+						Attempts: &atc.AttemptsConfig{Count: 2},
+						Failure: &atc.PlanConfig{
+							Task: "some failure",
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			getPlan := atc.GetPlan{
+				Type:          "git",
+				Name:          "some-resource",
+				Resource:      "some-resource",
+				PipelineID:    42,
+				Source:        atc.Source{"uri": "git://some-resource"},
+				ResourceTypes: resourceTypes,
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+				Condition: "inputs.some-resource.triggered",
+				Step: expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.RetryPlan{
+						Attempts: []atc.Plan{
+							expectedPlanFactory.NewPlan(getPlan),
+							expectedPlanFactory.NewPlan(getPlan),
+						},
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some failure",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when a step with a condition is run across vars", func() {
+		It("evaluates the condition for each combination, with its var as a param", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "unit",
+						If:   `params.platform != "windows"`,
+						Across: []atc.AcrossVarConfig{
+							{Var: "platform", Values: []string{"linux", "windows"}},
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+						Condition: `params.platform != "windows"`,
+						Params:    atc.Params{"platform": "linux"},
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "unit (platform: linux)",
+							PipelineID:    42,
+							Params:        atc.Params{"platform": "linux"},
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+					expectedPlanFactory.NewPlan(atc.ConditionalPlan{
+						Condition: `params.platform != "windows"`,
+						Params:    atc.Params{"platform": "windows"},
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "unit (platform: windows)",
+							PipelineID:    42,
+							Params:        atc.Params{"platform": "windows"},
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+				},
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
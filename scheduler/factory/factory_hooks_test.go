@@ -22,7 +22,7 @@ var _ = Describe("Factory Hooks", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
 
 		resources = atc.ResourceConfigs{
 			{
@@ -654,6 +654,94 @@ var _ = Describe("Factory Hooks", func() {
 			Expect(actual).To(testhelpers.MatchPlan(expected))
 		})
 
+		It("can build a job with abort, error and failure hooks at the same level", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+						Failure: &atc.PlanConfig{
+							Task: "those who failed to resist our will",
+						},
+						Error: &atc.PlanConfig{
+							Task: "those who errored resisting our will",
+						},
+						Abort: &atc.PlanConfig{
+							Task: "those who gave up resisting our will",
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnErrorPlan{
+					Step: expectedPlanFactory.NewPlan(atc.OnAbortPlan{
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "those who resist our will",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "those who gave up resisting our will",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "those who errored resisting our will",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "those who failed to resist our will",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		It("can build a job with job-level error and abort hooks", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+					},
+				},
+				Error: &atc.PlanConfig{
+					Task: "job error",
+				},
+				Abort: &atc.PlanConfig{
+					Task: "job abort",
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.OnErrorPlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnAbortPlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "those who resist our will",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "job abort",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "job error",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
 		It("can build a job with multiple ensure, failure and success hooks", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
 				Plan: atc.PlanSequence{
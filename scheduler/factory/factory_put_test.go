@@ -23,7 +23,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
 
 			resources = atc.ResourceConfigs{
 				{
@@ -117,7 +117,7 @@ var _ = Describe("Factory Put", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
 
 			resources = atc.ResourceConfigs{
 				{
@@ -21,7 +21,7 @@ var _ = Describe("Factory Retry Step", func() {
 	BeforeEach(func() {
 		actualPlanFactory = atc.NewPlanFactory(123)
 		expectedPlanFactory = atc.NewPlanFactory(123)
-		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
 
 		resourceTypes = atc.ResourceTypes{
 			{
@@ -38,28 +38,30 @@ var _ = Describe("Factory Retry Step", func() {
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 					},
 				},
 			}, nil, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:          "second task",
-					PipelineID:    42,
-					ResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:          "second task",
-					PipelineID:    42,
-					ResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:          "second task",
-					PipelineID:    42,
-					ResourceTypes: resourceTypes,
-				}),
+				Attempts: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "second task",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "second task",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "second task",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				},
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -72,7 +74,7 @@ var _ = Describe("Factory Retry Step", func() {
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 						Success: &atc.PlanConfig{
 							Task: "second task",
 						},
@@ -83,21 +85,23 @@ var _ = Describe("Factory Retry Step", func() {
 
 			expected := expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
 				Step: expectedPlanFactory.NewPlan(atc.RetryPlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:          "second task",
-						PipelineID:    42,
-						ResourceTypes: resourceTypes,
-					}),
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:          "second task",
-						PipelineID:    42,
-						ResourceTypes: resourceTypes,
-					}),
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:          "second task",
-						PipelineID:    42,
-						ResourceTypes: resourceTypes,
-					}),
+					Attempts: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "second task",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "second task",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "second task",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					},
 				}),
 				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
 					Name:          "second task",
@@ -24,7 +24,7 @@ var _ = Describe("Factory Task", func() {
 		BeforeEach(func() {
 			actualPlanFactory = atc.NewPlanFactory(123)
 			expectedPlanFactory = atc.NewPlanFactory(123)
-			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory, nil)
 
 			resources = atc.ResourceConfigs{
 				{
@@ -181,5 +181,41 @@ var _ = Describe("Factory Task", func() {
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
 		})
+
+		Context("when artifacts are specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:      "some-task",
+							Artifacts: []string{"some-output"},
+							TaskConfig: &atc.TaskConfig{
+								Outputs: []atc.TaskOutputConfig{
+									{Name: "some-output"},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("creates build plan with the artifacts to retain", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					Artifacts:     []string{"some-output"},
+					Config: &atc.TaskConfig{
+						Outputs: []atc.TaskOutputConfig{
+							{Name: "some-output"},
+						},
+					},
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
 	})
 })
@@ -0,0 +1,136 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/credentialsfakes"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Variables", func() {
+	var (
+		buildFactory  factory.BuildFactory
+		fakeVariables *credentialsfakes.FakeVariables
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credentialsfakes.FakeVariables)
+		fakeVariables.GetStub = func(name string) (interface{}, bool, error) {
+			switch name {
+			case "private-key":
+				return "some-private-key", true, nil
+			case "registry-password":
+				return "some-password", true, nil
+			case "api-token":
+				return "some-token", true, nil
+			}
+
+			return nil, false, nil
+		}
+
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory, fakeVariables)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource", "private_key": "((private-key))"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"password": "((registry-password))"},
+			},
+		}
+	})
+
+	Context("with a get and a task referencing variables", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:    "some-resource",
+						Params: atc.Params{"depth": 1},
+					},
+					{
+						Task:   "some-task",
+						Params: atc.Params{"TOKEN": "((api-token))"},
+					},
+				},
+			}
+		})
+
+		It("resolves the variables into the plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedResourceTypes := atc.ResourceTypes{
+				{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"password": "some-password"},
+				},
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:          "git",
+					Name:          "some-resource",
+					Resource:      "some-resource",
+					PipelineID:    42,
+					Source:        atc.Source{"uri": "git://some-resource", "private_key": "some-private-key"},
+					Params:        atc.Params{"depth": 1},
+					ResourceTypes: expectedResourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					Params:        atc.Params{"TOKEN": "some-token"},
+					ResourceTypes: expectedResourceTypes,
+				}),
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		It("does not modify the given config", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[0].Source["private_key"]).To(Equal("((private-key))"))
+			Expect(resourceTypes[0].Source["password"]).To(Equal("((registry-password))"))
+			Expect(input.Plan[1].Params["TOKEN"]).To(Equal("((api-token))"))
+		})
+	})
+
+	Context("with a put referencing an undefined variable", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Put:    "some-resource",
+						Params: atc.Params{"secret": "((nope))"},
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).To(Equal(credentials.UndefinedVariablesError{Names: []string{"nope"}}))
+		})
+	})
+})
@@ -21,6 +21,13 @@ type FakeTransformerDB struct {
 		result2 bool
 		result3 error
 	}
+	GetPinnedResourceVersionsStub        func() (map[string]atc.Version, error)
+	getPinnedResourceVersionsMutex       sync.RWMutex
+	getPinnedResourceVersionsArgsForCall []struct{}
+	getPinnedResourceVersionsReturns     struct {
+		result1 map[string]atc.Version
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -61,11 +68,39 @@ func (fake *FakeTransformerDB) GetVersionedResourceByVersionReturns(result1 db.S
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTransformerDB) GetPinnedResourceVersions() (map[string]atc.Version, error) {
+	fake.getPinnedResourceVersionsMutex.Lock()
+	fake.getPinnedResourceVersionsArgsForCall = append(fake.getPinnedResourceVersionsArgsForCall, struct{}{})
+	fake.recordInvocation("GetPinnedResourceVersions", []interface{}{})
+	fake.getPinnedResourceVersionsMutex.Unlock()
+	if fake.GetPinnedResourceVersionsStub != nil {
+		return fake.GetPinnedResourceVersionsStub()
+	} else {
+		return fake.getPinnedResourceVersionsReturns.result1, fake.getPinnedResourceVersionsReturns.result2
+	}
+}
+
+func (fake *FakeTransformerDB) GetPinnedResourceVersionsCallCount() int {
+	fake.getPinnedResourceVersionsMutex.RLock()
+	defer fake.getPinnedResourceVersionsMutex.RUnlock()
+	return len(fake.getPinnedResourceVersionsArgsForCall)
+}
+
+func (fake *FakeTransformerDB) GetPinnedResourceVersionsReturns(result1 map[string]atc.Version, result2 error) {
+	fake.GetPinnedResourceVersionsStub = nil
+	fake.getPinnedResourceVersionsReturns = struct {
+		result1 map[string]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransformerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getVersionedResourceByVersionMutex.RLock()
 	defer fake.getVersionedResourceByVersionMutex.RUnlock()
+	fake.getPinnedResourceVersionsMutex.RLock()
+	defer fake.getPinnedResourceVersionsMutex.RUnlock()
 	return fake.invocations
 }
 
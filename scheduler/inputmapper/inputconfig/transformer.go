@@ -17,6 +17,7 @@ type Transformer interface {
 
 type TransformerDB interface {
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (db.SavedVersionedResource, bool, error)
+	GetPinnedResourceVersions() (map[string]atc.Version, error)
 }
 
 func NewTransformer(db TransformerDB) Transformer {
@@ -28,9 +29,14 @@ type transformer struct {
 }
 
 func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName string, inputs []config.JobInput) (algorithm.InputConfigs, error) {
+	pinnedVersions, err := i.db.GetPinnedResourceVersions()
+	if err != nil {
+		return nil, err
+	}
+
 	inputConfigs := algorithm.InputConfigs{}
 
-	for _, input := range inputs {
+	for _, input := range config.ApplyResourcePins(inputs, pinnedVersions) {
 		if input.Version == nil {
 			input.Version = &atc.VersionConfig{Latest: true}
 		}
@@ -188,6 +188,49 @@ var _ = Describe("Transformer", func() {
 					})
 				})
 			})
+
+			Context("when the input's resource is pinned", func() {
+				BeforeEach(func() {
+					jobInputs = []config.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Every: true},
+					}}
+
+					fakeDB.GetPinnedResourceVersionsReturns(map[string]atc.Version{
+						"r1": {"version": "v2"},
+					}, nil)
+					fakeDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 98}, true, nil)
+				})
+
+				It("uses the resource's pinned version in place of the configured one", func() {
+					actualVersion, actualResource := fakeDB.GetVersionedResourceByVersionArgsForCall(0)
+					Expect(actualVersion).To(Equal(atc.Version{"version": "v2"}))
+					Expect(actualResource).To(Equal("r1"))
+
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:            "job-input-1",
+						UseEveryVersion: false,
+						PinnedVersionID: 98,
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{},
+						JobID:           1,
+					}))
+				})
+			})
+
+			Context("when getting the pinned resource versions fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("bad thing")
+					fakeDB.GetPinnedResourceVersionsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tranformErr).To(Equal(disaster))
+				})
+			})
 		})
 
 		Context("when an input has things that don't exist", func() {
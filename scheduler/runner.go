@@ -28,6 +28,13 @@ type BuildScheduler interface {
 		resourceConfigs atc.ResourceConfigs,
 		resourceTypes atc.ResourceTypes,
 	) (db.Build, Waiter, error)
+	RerunImmediately(
+		logger lager.Logger,
+		originalBuild db.Build,
+		jobConfig atc.JobConfig,
+		resourceConfigs atc.ResourceConfigs,
+		resourceTypes atc.ResourceTypes,
+	) (db.Build, Waiter, error)
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
 }
 
@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
@@ -28,7 +29,10 @@ type SchedulerDB interface {
 	Reload() (bool, error)
 	Config() atc.Config
 	CreateJobBuild(job string) (db.Build, error)
+	RerunJobBuild(originalBuild db.Build) (db.Build, error)
 	EnsurePendingBuildExists(jobName string) error
+	GetJobTriggersEvaluatedAt(jobName string) (time.Time, bool, error)
+	SetJobTriggersEvaluatedAt(jobName string, evaluatedAt time.Time) error
 	GetAllPendingBuilds() (map[string][]db.Build, error)
 	GetPendingBuildsForJob(jobName string) ([]db.Build, error)
 }
@@ -51,6 +55,9 @@ func (s *Scheduler) Schedule(
 	for _, jobConfig := range jobConfigs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, jobConfig)
+		if err == nil {
+			err = s.ensureTriggeredBuildExists(logger, jobConfig)
+		}
 		jobSchedulingTime[jobConfig.Name] = time.Since(jStart)
 
 		if err != nil {
@@ -110,6 +117,56 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+// ensureTriggeredBuildExists queues a build of the job if one of its cron
+// triggers has fired since they were last evaluated. Fire times missed while
+// nothing was scheduling are coalesced into a single build.
+func (s *Scheduler) ensureTriggeredBuildExists(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+) error {
+	if len(jobConfig.Triggers) == 0 {
+		return nil
+	}
+
+	logger = logger.Session("evaluate-triggers", lager.Data{"job": jobConfig.Name})
+
+	now := time.Now()
+
+	evaluatedAt, found, err := s.DB.GetJobTriggersEvaluatedAt(jobConfig.Name)
+	if err != nil {
+		logger.Error("failed-to-get-triggers-evaluated-at", err)
+		return err
+	}
+
+	if found {
+		nextTrigger, fires, err := cron.NextTrigger(jobConfig.Triggers, evaluatedAt)
+		if err != nil {
+			logger.Error("failed-to-evaluate-triggers", err)
+			return err
+		}
+
+		if !fires || nextTrigger.After(now) {
+			return nil
+		}
+
+		logger.Info("triggered", lager.Data{"trigger-time": nextTrigger})
+
+		err = s.DB.EnsurePendingBuildExists(jobConfig.Name)
+		if err != nil {
+			logger.Error("failed-to-ensure-pending-build-exists", err)
+			return err
+		}
+	}
+
+	err = s.DB.SetJobTriggersEvaluatedAt(jobConfig.Name, now)
+	if err != nil {
+		logger.Error("failed-to-set-triggers-evaluated-at", err)
+		return err
+	}
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -149,6 +206,45 @@ func (s *Scheduler) TriggerImmediately(
 	return build, wg, nil
 }
 
+func (s *Scheduler) RerunImmediately(
+	logger lager.Logger,
+	originalBuild db.Build,
+	jobConfig atc.JobConfig,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("rerun-immediately", lager.Data{
+		"job_name":          jobConfig.Name,
+		"original_build_id": originalBuild.ID(),
+	})
+
+	build, err := s.DB.RerunJobBuild(originalBuild)
+	if err != nil {
+		logger.Error("failed-to-rerun-job-build", err)
+		return nil, nil, err
+	}
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		nextPendingBuilds, err := s.DB.GetPendingBuildsForJob(jobConfig.Name)
+		if err != nil {
+			logger.Error("failed-to-get-next-pending-build-for-job", err)
+			return
+		}
+
+		err = s.BuildStarter.TryStartPendingBuildsForJob(logger, jobConfig, resourceConfigs, resourceTypes, nextPendingBuilds)
+		if err != nil {
+			logger.Error("failed-to-start-next-pending-build-for-job", err, lager.Data{"job-name": jobConfig.Name})
+			return
+		}
+	}()
+
+	return build, wg, nil
+}
+
 func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error {
 	versions, err := s.DB.LoadVersionsDB()
 	if err != nil {
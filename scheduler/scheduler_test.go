@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -232,6 +233,85 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a cron trigger", func() {
+			BeforeEach(func() {
+				jobConfigs = atc.JobConfigs{
+					{
+						Name:     "some-job",
+						Triggers: []atc.TriggerConfig{{Cron: "0 2 * * *"}},
+					},
+				}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the triggers have never been evaluated", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobTriggersEvaluatedAtReturns(time.Time{}, false, nil)
+				})
+
+				It("records the evaluation without triggering a build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(BeZero())
+
+					Expect(fakeDB.SetJobTriggersEvaluatedAtCallCount()).To(Equal(1))
+					jobName, evaluatedAt := fakeDB.SetJobTriggersEvaluatedAtArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(evaluatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+			})
+
+			Context("when a trigger has fired since they were last evaluated", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobTriggersEvaluatedAtReturns(time.Now().Add(-48*time.Hour), true, nil)
+				})
+
+				It("ensures a pending build exists and records the evaluation", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+
+					Expect(fakeDB.GetJobTriggersEvaluatedAtArgsForCall(0)).To(Equal("some-job"))
+
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+					Expect(fakeDB.EnsurePendingBuildExistsArgsForCall(0)).To(Equal("some-job"))
+
+					Expect(fakeDB.SetJobTriggersEvaluatedAtCallCount()).To(Equal(1))
+				})
+
+				Context("when ensuring the pending build fails", func() {
+					BeforeEach(func() {
+						fakeDB.EnsurePendingBuildExistsReturns(disaster)
+					})
+
+					It("returns the error without recording the evaluation", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+						Expect(fakeDB.SetJobTriggersEvaluatedAtCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when no trigger has fired since they were last evaluated", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobTriggersEvaluatedAtReturns(time.Now(), true, nil)
+				})
+
+				It("leaves the job alone", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeDB.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					Expect(fakeDB.SetJobTriggersEvaluatedAtCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the last evaluation fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobTriggersEvaluatedAtReturns(time.Time{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(scheduleErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("TriggerImmediately", func() {
@@ -316,6 +396,73 @@ var _ = Describe("Scheduler", func() {
 		})
 	})
 
+	Describe("RerunImmediately", func() {
+		var (
+			originalBuild *dbfakes.FakeBuild
+			rerunBuild    db.Build
+			rerunErr      error
+		)
+
+		BeforeEach(func() {
+			originalBuild = new(dbfakes.FakeBuild)
+			originalBuild.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			rerunBuild, waiter, rerunErr = scheduler.RerunImmediately(
+				lagertest.NewTestLogger("test"),
+				originalBuild,
+				atc.JobConfig{Name: "some-job"},
+				atc.ResourceConfigs{{Name: "some-resource"}},
+				atc.ResourceTypes{{Name: "some-resource-type"}})
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeDB.RerunJobBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(rerunErr).To(Equal(disaster))
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating the build succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+			var nextPendingBuilds []db.Build
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.RerunOfReturns(42)
+				fakeDB.RerunJobBuildReturns(createdBuild, nil)
+
+				nextPendingBuilds = []db.Build{createdBuild}
+				fakeDB.GetPendingBuildsForJobReturns(nextPendingBuilds, nil)
+			})
+
+			It("re-runs the original build", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerunBuild).To(Equal(createdBuild))
+
+				Expect(fakeDB.RerunJobBuildCallCount()).To(Equal(1))
+				Expect(fakeDB.RerunJobBuildArgsForCall(0)).To(Equal(originalBuild))
+			})
+
+			It("tries to start the job's pending builds", func() {
+				Expect(fakeDB.GetPendingBuildsForJobArgsForCall(0)).To(Equal("some-job"))
+
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				_, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+				Expect(b).To(Equal(nextPendingBuilds))
+			})
+		})
+	})
+
 	Describe("SaveNextInputMapping", func() {
 		var saveErr error
 
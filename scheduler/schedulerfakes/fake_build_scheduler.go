@@ -48,6 +48,20 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturns struct {
 		result1 error
 	}
+	RerunImmediatelyStub        func(logger lager.Logger, originalBuild db.Build, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error)
+	rerunImmediatelyMutex       sync.RWMutex
+	rerunImmediatelyArgsForCall []struct {
+		logger          lager.Logger
+		originalBuild   db.Build
+		jobConfig       atc.JobConfig
+		resourceConfigs atc.ResourceConfigs
+		resourceTypes   atc.ResourceTypes
+	}
+	rerunImmediatelyReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -162,6 +176,45 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) RerunImmediately(logger lager.Logger, originalBuild db.Build, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error) {
+	fake.rerunImmediatelyMutex.Lock()
+	fake.rerunImmediatelyArgsForCall = append(fake.rerunImmediatelyArgsForCall, struct {
+		logger          lager.Logger
+		originalBuild   db.Build
+		jobConfig       atc.JobConfig
+		resourceConfigs atc.ResourceConfigs
+		resourceTypes   atc.ResourceTypes
+	}{logger, originalBuild, jobConfig, resourceConfigs, resourceTypes})
+	fake.recordInvocation("RerunImmediately", []interface{}{logger, originalBuild, jobConfig, resourceConfigs, resourceTypes})
+	fake.rerunImmediatelyMutex.Unlock()
+	if fake.RerunImmediatelyStub != nil {
+		return fake.RerunImmediatelyStub(logger, originalBuild, jobConfig, resourceConfigs, resourceTypes)
+	} else {
+		return fake.rerunImmediatelyReturns.result1, fake.rerunImmediatelyReturns.result2, fake.rerunImmediatelyReturns.result3
+	}
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyCallCount() int {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return len(fake.rerunImmediatelyArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyArgsForCall(i int) (lager.Logger, db.Build, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return fake.rerunImmediatelyArgsForCall[i].logger, fake.rerunImmediatelyArgsForCall[i].originalBuild, fake.rerunImmediatelyArgsForCall[i].jobConfig, fake.rerunImmediatelyArgsForCall[i].resourceConfigs, fake.rerunImmediatelyArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunImmediatelyStub = nil
+	fake.rerunImmediatelyReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -171,6 +224,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -83,6 +83,34 @@ type FakeSchedulerDB struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetJobTriggersEvaluatedAtStub        func(jobName string) (time.Time, bool, error)
+	getJobTriggersEvaluatedAtMutex       sync.RWMutex
+	getJobTriggersEvaluatedAtArgsForCall []struct {
+		jobName string
+	}
+	getJobTriggersEvaluatedAtReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	SetJobTriggersEvaluatedAtStub        func(jobName string, evaluatedAt time.Time) error
+	setJobTriggersEvaluatedAtMutex       sync.RWMutex
+	setJobTriggersEvaluatedAtArgsForCall []struct {
+		jobName     string
+		evaluatedAt time.Time
+	}
+	setJobTriggersEvaluatedAtReturns struct {
+		result1 error
+	}
+	RerunJobBuildStub        func(originalBuild db.Build) (db.Build, error)
+	rerunJobBuildMutex       sync.RWMutex
+	rerunJobBuildArgsForCall []struct {
+		originalBuild db.Build
+	}
+	rerunJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -352,6 +380,109 @@ func (fake *FakeSchedulerDB) GetPendingBuildsForJobReturns(result1 []db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeSchedulerDB) GetJobTriggersEvaluatedAt(jobName string) (time.Time, bool, error) {
+	fake.getJobTriggersEvaluatedAtMutex.Lock()
+	fake.getJobTriggersEvaluatedAtArgsForCall = append(fake.getJobTriggersEvaluatedAtArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetJobTriggersEvaluatedAt", []interface{}{jobName})
+	fake.getJobTriggersEvaluatedAtMutex.Unlock()
+	if fake.GetJobTriggersEvaluatedAtStub != nil {
+		return fake.GetJobTriggersEvaluatedAtStub(jobName)
+	} else {
+		return fake.getJobTriggersEvaluatedAtReturns.result1, fake.getJobTriggersEvaluatedAtReturns.result2, fake.getJobTriggersEvaluatedAtReturns.result3
+	}
+}
+
+func (fake *FakeSchedulerDB) GetJobTriggersEvaluatedAtCallCount() int {
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	return len(fake.getJobTriggersEvaluatedAtArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) GetJobTriggersEvaluatedAtArgsForCall(i int) string {
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	return fake.getJobTriggersEvaluatedAtArgsForCall[i].jobName
+}
+
+func (fake *FakeSchedulerDB) GetJobTriggersEvaluatedAtReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobTriggersEvaluatedAtStub = nil
+	fake.getJobTriggersEvaluatedAtReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSchedulerDB) SetJobTriggersEvaluatedAt(jobName string, evaluatedAt time.Time) error {
+	fake.setJobTriggersEvaluatedAtMutex.Lock()
+	fake.setJobTriggersEvaluatedAtArgsForCall = append(fake.setJobTriggersEvaluatedAtArgsForCall, struct {
+		jobName     string
+		evaluatedAt time.Time
+	}{jobName, evaluatedAt})
+	fake.recordInvocation("SetJobTriggersEvaluatedAt", []interface{}{jobName, evaluatedAt})
+	fake.setJobTriggersEvaluatedAtMutex.Unlock()
+	if fake.SetJobTriggersEvaluatedAtStub != nil {
+		return fake.SetJobTriggersEvaluatedAtStub(jobName, evaluatedAt)
+	} else {
+		return fake.setJobTriggersEvaluatedAtReturns.result1
+	}
+}
+
+func (fake *FakeSchedulerDB) SetJobTriggersEvaluatedAtCallCount() int {
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	return len(fake.setJobTriggersEvaluatedAtArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) SetJobTriggersEvaluatedAtArgsForCall(i int) (string, time.Time) {
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	return fake.setJobTriggersEvaluatedAtArgsForCall[i].jobName, fake.setJobTriggersEvaluatedAtArgsForCall[i].evaluatedAt
+}
+
+func (fake *FakeSchedulerDB) SetJobTriggersEvaluatedAtReturns(result1 error) {
+	fake.SetJobTriggersEvaluatedAtStub = nil
+	fake.setJobTriggersEvaluatedAtReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSchedulerDB) RerunJobBuild(originalBuild db.Build) (db.Build, error) {
+	fake.rerunJobBuildMutex.Lock()
+	fake.rerunJobBuildArgsForCall = append(fake.rerunJobBuildArgsForCall, struct {
+		originalBuild db.Build
+	}{originalBuild})
+	fake.recordInvocation("RerunJobBuild", []interface{}{originalBuild})
+	fake.rerunJobBuildMutex.Unlock()
+	if fake.RerunJobBuildStub != nil {
+		return fake.RerunJobBuildStub(originalBuild)
+	} else {
+		return fake.rerunJobBuildReturns.result1, fake.rerunJobBuildReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) RerunJobBuildCallCount() int {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	return len(fake.rerunJobBuildArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) RerunJobBuildArgsForCall(i int) db.Build {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	return fake.rerunJobBuildArgsForCall[i].originalBuild
+}
+
+func (fake *FakeSchedulerDB) RerunJobBuildReturns(result1 db.Build, result2 error) {
+	fake.RerunJobBuildStub = nil
+	fake.rerunJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -373,6 +504,12 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPendingBuildsMutex.RUnlock()
 	fake.getPendingBuildsForJobMutex.RLock()
 	defer fake.getPendingBuildsForJobMutex.RUnlock()
+	fake.getJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.getJobTriggersEvaluatedAtMutex.RUnlock()
+	fake.setJobTriggersEvaluatedAtMutex.RLock()
+	defer fake.setJobTriggersEvaluatedAtMutex.RUnlock()
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
 	return fake.invocations
 }
 
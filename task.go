@@ -2,6 +2,7 @@ package atc
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -31,6 +32,13 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Limits on the resources available to the task's container.
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+
+	// Test reports written by the task to one of its outputs, to be recorded
+	// against the build.
+	Reports []TaskReportConfig `json:"reports,omitempty" yaml:"reports,omitempty" mapstructure:"reports"`
 }
 
 type ImageResource struct {
@@ -110,6 +118,14 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.ContainerLimits != nil {
+		config.ContainerLimits = other.ContainerLimits
+	}
+
+	if len(other.Reports) != 0 {
+		config.Reports = other.Reports
+	}
+
 	return config
 }
 
@@ -126,6 +142,14 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
 
+	if config.ContainerLimits != nil {
+		for _, message := range config.ContainerLimits.Validate() {
+			messages = append(messages, "  "+message)
+		}
+	}
+
+	messages = append(messages, config.validateReports()...)
+
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
 	}
@@ -327,6 +351,44 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateReports() []string {
+	messages := []string{}
+
+	for i, report := range config.Reports {
+		if report.Path == "" {
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing a path", i))
+		} else if _, _, found := config.OutputContaining(report.Path); !found {
+			messages = append(messages, fmt.Sprintf("  report '%s' is not within any output", report.Path))
+		}
+
+		if report.Format != TaskReportFormatJUnit {
+			messages = append(messages, fmt.Sprintf("  report in position %d has unknown format '%s'", i, report.Format))
+		}
+	}
+
+	return messages
+}
+
+// OutputContaining returns the output whose directory contains the given
+// path, along with the path relative to the output's directory.
+func (config TaskConfig) OutputContaining(filePath string) (TaskOutputConfig, string, bool) {
+	cleanPath := path.Clean(filePath)
+
+	for _, output := range config.Outputs {
+		outputPath := path.Clean(output.resolvePath())
+
+		if outputPath == "." {
+			return output, cleanPath, true
+		}
+
+		if strings.HasPrefix(cleanPath, outputPath+"/") {
+			return output, strings.TrimPrefix(cleanPath, outputPath+"/"), true
+		}
+	}
+
+	return TaskOutputConfig{}, "", false
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -360,6 +422,13 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+const TaskReportFormatJUnit = "junit"
+
+type TaskReportConfig struct {
+	Path   string `json:"path" yaml:"path"`
+	Format string `json:"format" yaml:"format"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -244,6 +244,88 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when container limits are given", func() {
+			BeforeEach(func() {
+				validConfig.ContainerLimits = &ContainerLimits{
+					CPU:    512,
+					Memory: 1024 * 1024 * 1024,
+				}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when the cpu shares are too low", func() {
+				BeforeEach(func() {
+					invalidConfig.ContainerLimits = &ContainerLimits{CPU: 1}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.cpu must be at least 2 shares")))
+				})
+			})
+
+			Context("when the memory limit is too low", func() {
+				BeforeEach(func() {
+					invalidConfig.ContainerLimits = &ContainerLimits{Memory: 1024}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  container_limits.memory must be at least 4194304 bytes")))
+				})
+			})
+		})
+
+		Context("when reports are given", func() {
+			BeforeEach(func() {
+				validConfig.Outputs = []TaskOutputConfig{{Name: "out"}}
+				validConfig.Reports = []TaskReportConfig{
+					{Path: "out/junit.xml", Format: "junit"},
+				}
+
+				invalidConfig = validConfig
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when the report is not within an output", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{
+						{Path: "elsewhere/junit.xml", Format: "junit"},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report 'elsewhere/junit.xml' is not within any output")))
+				})
+			})
+
+			Context("when the report has no path", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{{Format: "junit"}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 is missing a path")))
+				})
+			})
+
+			Context("when the report format is unknown", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{
+						{Path: "out/results.tap", Format: "tap"},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 has unknown format 'tap'")))
+				})
+			})
+		})
+
 		Describe("input overlapping checks", func() {
 			Context("when two inputs have the same name", func() {
 				BeforeEach(func() {
@@ -659,6 +741,36 @@ run: {path: a/file}
 
 		})
 
+		It("overrides the container limits", func() {
+			Expect(TaskConfig{
+				Image:           "some-image",
+				ContainerLimits: &ContainerLimits{CPU: 512},
+			}.Merge(TaskConfig{
+				ContainerLimits: &ContainerLimits{Memory: 1024 * 1024 * 1024},
+			})).To(
+
+				Equal(TaskConfig{
+					Image:           "some-image",
+					ContainerLimits: &ContainerLimits{Memory: 1024 * 1024 * 1024},
+				}))
+
+		})
+
+		It("overrides the reports", func() {
+			Expect(TaskConfig{
+				Image:   "some-image",
+				Reports: []TaskReportConfig{{Path: "out/a.xml", Format: "junit"}},
+			}.Merge(TaskConfig{
+				Reports: []TaskReportConfig{{Path: "out/b.xml", Format: "junit"}},
+			})).To(
+
+				Equal(TaskConfig{
+					Image:   "some-image",
+					Reports: []TaskReportConfig{{Path: "out/b.xml", Format: "junit"}},
+				}))
+
+		})
+
 		It("overrides the run config", func() {
 			Expect(TaskConfig{
 				Run: TaskRunConfig{
@@ -12,6 +12,10 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+	LDAPAuth     *LDAPAuth     `json:"ldap_auth,omitempty"`
+
+	// Roles grants roles within the team to its users and groups
+	Roles TeamRoleBindings `json:"roles,omitempty"`
 }
 
 type BasicAuth struct {
@@ -54,3 +58,26 @@ type GenericOAuth struct {
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 	Scope         string            `json:"scope,omitempty"`
 }
+
+type LDAPAuth struct {
+	Host               string `json:"host,omitempty"`
+	InsecureNoSSL      bool   `json:"insecure_no_ssl,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	CACert             string `json:"ca_cert,omitempty"`
+
+	BindDN       string `json:"bind_dn,omitempty"`
+	BindPassword string `json:"bind_password,omitempty"`
+
+	UserSearchBaseDN   string `json:"user_search_base_dn,omitempty"`
+	UserSearchFilter   string `json:"user_search_filter,omitempty"`
+	UserSearchUsername string `json:"user_search_username,omitempty"`
+
+	GroupSearchBaseDN    string `json:"group_search_base_dn,omitempty"`
+	GroupSearchFilter    string `json:"group_search_filter,omitempty"`
+	GroupSearchGroupAttr string `json:"group_search_group_attr,omitempty"`
+	GroupSearchNameAttr  string `json:"group_search_name_attr,omitempty"`
+
+	// Groups restricts access to members of any of the groups; without any,
+	// every user found by the user search has access.
+	Groups []string `json:"groups,omitempty"`
+}
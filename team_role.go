@@ -0,0 +1,89 @@
+package atc
+
+// TeamRole is what a member of a team may do within it. Each role may do
+// everything the roles after it may do:
+//
+//   - owners also manage the team's auth, roles, and webhooks
+//   - members also configure pipelines and run one-off builds
+//   - operators also trigger, abort, and pause builds and resources
+//   - viewers can only read the team's pipelines and builds
+type TeamRole string
+
+const (
+	TeamRoleOwner    TeamRole = "owner"
+	TeamRoleMember   TeamRole = "member"
+	TeamRoleOperator TeamRole = "operator"
+	TeamRoleViewer   TeamRole = "viewer"
+)
+
+var teamRoleRanks = map[TeamRole]int{
+	TeamRoleOwner:    4,
+	TeamRoleMember:   3,
+	TeamRoleOperator: 2,
+	TeamRoleViewer:   1,
+}
+
+func (role TeamRole) IsValid() bool {
+	_, found := teamRoleRanks[role]
+	return found
+}
+
+// Allows reports whether the role may do what the required role may do.
+func (role TeamRole) Allows(required TeamRole) bool {
+	return teamRoleRanks[role] >= teamRoleRanks[required]
+}
+
+// A TeamRoleBinding grants a role within a team to users, as identified by
+// the team's auth provider (e.g. a basic auth username or a GitHub login),
+// and to the provider's groups (e.g. a GitHub organization, or a team within
+// one as "org/team").
+type TeamRoleBinding struct {
+	Role   TeamRole `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+type TeamRoleBindings []TeamRoleBinding
+
+// RoleFor returns the greatest role bound to the user or any of their groups.
+// Teams without any bindings make everyone who can log in an owner, and
+// everyone else who can log in to a team with bindings is a viewer.
+func (bindings TeamRoleBindings) RoleFor(user string, groups []string) TeamRole {
+	if len(bindings) == 0 {
+		return TeamRoleOwner
+	}
+
+	role := TeamRoleViewer
+
+	for _, binding := range bindings {
+		if role.Allows(binding.Role) {
+			continue
+		}
+
+		if binding.binds(user, groups) {
+			role = binding.Role
+		}
+	}
+
+	return role
+}
+
+func (binding TeamRoleBinding) binds(user string, groups []string) bool {
+	if user != "" {
+		for _, boundUser := range binding.Users {
+			if boundUser == user {
+				return true
+			}
+		}
+	}
+
+	for _, boundGroup := range binding.Groups {
+		for _, group := range groups {
+			if boundGroup == group {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamRoleBindings", func() {
+	Describe("RoleFor", func() {
+		var bindings atc.TeamRoleBindings
+
+		BeforeEach(func() {
+			bindings = atc.TeamRoleBindings{
+				{Role: atc.TeamRoleOperator, Groups: []string{"some-org"}},
+				{Role: atc.TeamRoleOwner, Users: []string{"some-owner"}},
+				{Role: atc.TeamRoleMember, Users: []string{"some-member"}, Groups: []string{"some-org/developers"}},
+			}
+		})
+
+		It("is the greatest role bound to the user or their groups", func() {
+			Expect(bindings.RoleFor("some-owner", nil)).To(Equal(atc.TeamRoleOwner))
+			Expect(bindings.RoleFor("some-owner", []string{"some-org"})).To(Equal(atc.TeamRoleOwner))
+			Expect(bindings.RoleFor("some-member", []string{"some-org"})).To(Equal(atc.TeamRoleMember))
+			Expect(bindings.RoleFor("someone", []string{"some-org"})).To(Equal(atc.TeamRoleOperator))
+			Expect(bindings.RoleFor("someone", []string{"some-org", "some-org/developers"})).To(Equal(atc.TeamRoleMember))
+		})
+
+		It("is viewer for anyone else", func() {
+			Expect(bindings.RoleFor("someone", []string{"other-org"})).To(Equal(atc.TeamRoleViewer))
+			Expect(bindings.RoleFor("", nil)).To(Equal(atc.TeamRoleViewer))
+		})
+
+		It("is owner for everyone when there are no bindings", func() {
+			Expect(atc.TeamRoleBindings{}.RoleFor("someone", nil)).To(Equal(atc.TeamRoleOwner))
+		})
+	})
+
+	Describe("Allows", func() {
+		It("allows the role and those after it", func() {
+			Expect(atc.TeamRoleMember.Allows(atc.TeamRoleOwner)).To(BeFalse())
+			Expect(atc.TeamRoleMember.Allows(atc.TeamRoleMember)).To(BeTrue())
+			Expect(atc.TeamRoleMember.Allows(atc.TeamRoleOperator)).To(BeTrue())
+			Expect(atc.TeamRoleMember.Allows(atc.TeamRoleViewer)).To(BeTrue())
+			Expect(atc.TeamRoleViewer.Allows(atc.TeamRoleOperator)).To(BeFalse())
+		})
+
+		It("allows nothing for an unknown role", func() {
+			Expect(atc.TeamRole("bogus").Allows(atc.TeamRoleViewer)).To(BeFalse())
+		})
+	})
+})
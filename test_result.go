@@ -0,0 +1,42 @@
+package atc
+
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusErrored TestStatus = "errored"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+// TestResult is the outcome of a single test case, as read from a task's
+// test report.
+type TestResult struct {
+	PlanID PlanID `json:"plan_id,omitempty"`
+
+	Suite  string     `json:"suite"`
+	Name   string     `json:"name"`
+	Status TestStatus `json:"status"`
+
+	// Duration of the test, in seconds.
+	Duration float64 `json:"duration"`
+}
+
+// JobTestHistory is the outcome of a single test across a job's recent
+// builds, newest first.
+type JobTestHistory struct {
+	Suite string `json:"suite"`
+	Name  string `json:"name"`
+
+	// Flaky is set when the test both passed and failed within the builds.
+	Flaky bool `json:"flaky"`
+
+	Runs []TestRun `json:"runs"`
+}
+
+type TestRun struct {
+	BuildID   int        `json:"build_id"`
+	BuildName string     `json:"build_name"`
+	Status    TestStatus `json:"status"`
+	Duration  float64    `json:"duration"`
+}
@@ -92,6 +92,13 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for i, p := range plan.InParallel.Steps {
+			plan.InParallel.Steps[i], subIDs = stripIDs(p)
+			ids = append(ids, subIDs...)
+		}
+	}
+
 	if plan.Do != nil {
 		for i, p := range *plan.Do {
 			(*plan.Do)[i], subIDs = stripIDs(p)
@@ -115,6 +122,22 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		ids = append(ids, subIDs...)
 	}
 
+	if plan.OnError != nil {
+		plan.OnError.Step, subIDs = stripIDs(plan.OnError.Step)
+		ids = append(ids, subIDs...)
+
+		plan.OnError.Next, subIDs = stripIDs(plan.OnError.Next)
+		ids = append(ids, subIDs...)
+	}
+
+	if plan.OnAbort != nil {
+		plan.OnAbort.Step, subIDs = stripIDs(plan.OnAbort.Step)
+		ids = append(ids, subIDs...)
+
+		plan.OnAbort.Next, subIDs = stripIDs(plan.OnAbort.Next)
+		ids = append(ids, subIDs...)
+	}
+
 	if plan.Ensure != nil {
 		plan.Ensure.Step, subIDs = stripIDs(plan.Ensure.Step)
 		ids = append(ids, subIDs...)
@@ -133,5 +156,10 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		ids = append(ids, subIDs...)
 	}
 
+	if plan.Conditional != nil {
+		plan.Conditional.Step, subIDs = stripIDs(plan.Conditional.Step)
+		ids = append(ids, subIDs...)
+	}
+
 	return plan, ids
 }
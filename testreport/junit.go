@@ -0,0 +1,94 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string `xml:"name,attr"`
+	ClassName string `xml:"classname,attr"`
+	Time      string `xml:"time,attr"`
+
+	Failure *struct{} `xml:"failure"`
+	Error   *struct{} `xml:"error"`
+	Skipped *struct{} `xml:"skipped"`
+}
+
+// ParseJUnit reads the test cases out of a JUnit XML report. Both a single
+// <testsuite> and a <testsuites> document are accepted, and suites may be
+// nested.
+func ParseJUnit(r io.Reader) ([]atc.TestResult, error) {
+	var root junitSuite
+	err := xml.NewDecoder(r).Decode(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	return junitResults(root, ""), nil
+}
+
+// junitDuration parses a test case's time in seconds. Durations are
+// informational, so reporters that write them oddly are tolerated: commas are
+// taken as thousands separators alongside a decimal point ("1,200.5"), or as
+// a decimal comma on their own ("1,5").
+func junitDuration(time string) float64 {
+	if strings.Count(time, ",") == 1 && !strings.Contains(time, ".") {
+		time = strings.Replace(time, ",", ".", 1)
+	} else {
+		time = strings.Replace(time, ",", "", -1)
+	}
+
+	duration, _ := strconv.ParseFloat(time, 64)
+	return duration
+}
+
+func junitResults(suite junitSuite, parentName string) []atc.TestResult {
+	suiteName := suite.Name
+	if suiteName == "" {
+		suiteName = parentName
+	}
+
+	results := []atc.TestResult{}
+
+	for _, testCase := range suite.Cases {
+		result := atc.TestResult{
+			Suite:  suiteName,
+			Name:   testCase.Name,
+			Status: atc.TestStatusPassed,
+		}
+
+		if testCase.ClassName != "" {
+			result.Suite = testCase.ClassName
+		}
+
+		result.Duration = junitDuration(testCase.Time)
+
+		switch {
+		case testCase.Error != nil:
+			result.Status = atc.TestStatusErrored
+		case testCase.Failure != nil:
+			result.Status = atc.TestStatusFailed
+		case testCase.Skipped != nil:
+			result.Status = atc.TestStatusSkipped
+		}
+
+		results = append(results, result)
+	}
+
+	for _, child := range suite.Suites {
+		results = append(results, junitResults(child, suiteName)...)
+	}
+
+	return results
+}
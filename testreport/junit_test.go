@@ -0,0 +1,74 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/testreport"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseJUnit", func() {
+	It("reads the test cases of a <testsuites> document", func() {
+		results, err := testreport.ParseJUnit(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="api" tests="4">
+    <testcase name="creates widgets" time="0.25"/>
+    <testcase name="deletes widgets" time="1,200.5">
+      <failure message="expected 204">stack trace</failure>
+    </testcase>
+    <testcase name="lists widgets" time="0">
+      <error message="panic"/>
+    </testcase>
+    <testcase name="renames widgets">
+      <skipped/>
+    </testcase>
+  </testsuite>
+  <testsuite name="db">
+    <testcase classname="db.Migrations" name="migrates" time="3"/>
+    <testsuite>
+      <testcase name="nested" time="0.1"/>
+    </testsuite>
+  </testsuite>
+</testsuites>
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(Equal([]atc.TestResult{
+			{Suite: "api", Name: "creates widgets", Status: atc.TestStatusPassed, Duration: 0.25},
+			{Suite: "api", Name: "deletes widgets", Status: atc.TestStatusFailed, Duration: 1200.5},
+			{Suite: "api", Name: "lists widgets", Status: atc.TestStatusErrored, Duration: 0},
+			{Suite: "api", Name: "renames widgets", Status: atc.TestStatusSkipped, Duration: 0},
+			{Suite: "db.Migrations", Name: "migrates", Status: atc.TestStatusPassed, Duration: 3},
+			{Suite: "db", Name: "nested", Status: atc.TestStatusPassed, Duration: 0.1},
+		}))
+	})
+
+	It("reads the test cases of a single <testsuite> document", func() {
+		results, err := testreport.ParseJUnit(strings.NewReader(`<testsuite name="unit"><testcase name="works" time="1.5"/></testsuite>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(Equal([]atc.TestResult{
+			{Suite: "unit", Name: "works", Status: atc.TestStatusPassed, Duration: 1.5},
+		}))
+	})
+
+	It("reads durations written with a decimal comma or thousands separators", func() {
+		results, err := testreport.ParseJUnit(strings.NewReader(`<testsuite name="unit">
+  <testcase name="decimal comma" time="1,5"/>
+  <testcase name="thousands" time="1,234,567"/>
+  <testcase name="thousands and decimal point" time="2,000.25"/>
+</testsuite>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(Equal([]atc.TestResult{
+			{Suite: "unit", Name: "decimal comma", Status: atc.TestStatusPassed, Duration: 1.5},
+			{Suite: "unit", Name: "thousands", Status: atc.TestStatusPassed, Duration: 1234567},
+			{Suite: "unit", Name: "thousands and decimal point", Status: atc.TestStatusPassed, Duration: 2000.25},
+		}))
+	})
+
+	It("returns an error for malformed reports", func() {
+		_, err := testreport.ParseJUnit(strings.NewReader(`<testsuite><testcase`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -313,10 +313,13 @@ type BuildStep
   | BuildStepDo (Array BuildPlan)
   | BuildStepOnSuccess HookedPlan
   | BuildStepOnFailure HookedPlan
+  | BuildStepOnError HookedPlan
+  | BuildStepOnAbort HookedPlan
   | BuildStepEnsure HookedPlan
   | BuildStepTry BuildPlan
   | BuildStepRetry (Array BuildPlan)
   | BuildStepTimeout BuildPlan
+  | BuildStepConditional BuildPlan
 
 type alias HookedPlan =
   { step : BuildPlan
@@ -339,13 +342,17 @@ decodeBuildPlan' =
         , "put" := lazy (\_ -> decodeBuildStepPut)
         , "dependent_get" := lazy (\_ -> decodeBuildStepDependentGet)
         , "aggregate" := lazy (\_ -> decodeBuildStepAggregate)
+        , "in_parallel" := lazy (\_ -> decodeBuildStepInParallel)
         , "do" := lazy (\_ -> decodeBuildStepDo)
         , "on_success" := lazy (\_ -> decodeBuildStepOnSuccess)
         , "on_failure" := lazy (\_ -> decodeBuildStepOnFailure)
+        , "on_error" := lazy (\_ -> decodeBuildStepOnError)
+        , "on_abort" := lazy (\_ -> decodeBuildStepOnAbort)
         , "ensure" := lazy (\_ -> decodeBuildStepEnsure)
         , "try" := lazy (\_ -> decodeBuildStepTry)
         , "retry" := lazy (\_ -> decodeBuildStepRetry)
         , "timeout" := lazy (\_ -> decodeBuildStepTimeout)
+        , "conditional" := lazy (\_ -> decodeBuildStepConditional)
         ]
 
 decodeBuildStepTask : Json.Decode.Decoder BuildStep
@@ -374,6 +381,11 @@ decodeBuildStepAggregate =
   Json.Decode.succeed BuildStepAggregate
     |: (Json.Decode.array (lazy (\_ -> decodeBuildPlan')))
 
+decodeBuildStepInParallel : Json.Decode.Decoder BuildStep
+decodeBuildStepInParallel =
+  Json.Decode.succeed BuildStepAggregate
+    |: ("steps" := Json.Decode.array (lazy (\_ -> decodeBuildPlan')))
+
 decodeBuildStepDo : Json.Decode.Decoder BuildStep
 decodeBuildStepDo =
   Json.Decode.succeed BuildStepDo
@@ -393,6 +405,20 @@ decodeBuildStepOnFailure =
       |: ("step" := lazy (\_ -> decodeBuildPlan'))
       |: ("on_failure" := lazy (\_ -> decodeBuildPlan'))
 
+decodeBuildStepOnError : Json.Decode.Decoder BuildStep
+decodeBuildStepOnError =
+  Json.Decode.map BuildStepOnError <|
+    Json.Decode.succeed HookedPlan
+      |: ("step" := lazy (\_ -> decodeBuildPlan'))
+      |: ("on_error" := lazy (\_ -> decodeBuildPlan'))
+
+decodeBuildStepOnAbort : Json.Decode.Decoder BuildStep
+decodeBuildStepOnAbort =
+  Json.Decode.map BuildStepOnAbort <|
+    Json.Decode.succeed HookedPlan
+      |: ("step" := lazy (\_ -> decodeBuildPlan'))
+      |: ("on_abort" := lazy (\_ -> decodeBuildPlan'))
+
 decodeBuildStepEnsure : Json.Decode.Decoder BuildStep
 decodeBuildStepEnsure =
   Json.Decode.map BuildStepEnsure <|
@@ -415,6 +441,11 @@ decodeBuildStepTimeout =
   Json.Decode.succeed BuildStepTimeout
     |: ("step" := lazy (\_ -> decodeBuildPlan'))
 
+decodeBuildStepConditional : Json.Decode.Decoder BuildStep
+decodeBuildStepConditional =
+  Json.Decode.succeed BuildStepConditional
+    |: ("step" := lazy (\_ -> decodeBuildPlan'))
+
 
 
 -- Job
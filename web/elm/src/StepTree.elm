@@ -35,10 +35,13 @@ type StepTree
   | Do (Array StepTree)
   | OnSuccess HookedStep
   | OnFailure HookedStep
+  | OnError HookedStep
+  | OnAbort HookedStep
   | Ensure HookedStep
   | Try StepTree
   | Retry StepID (Array StepTree) Int TabFocus
   | Timeout StepTree
+  | Conditional StepTree
 
 type TabFocus
   = Auto
@@ -135,6 +138,12 @@ init resources plan =
     Concourse.BuildStepOnFailure hookedPlan ->
       initHookedStep resources OnFailure hookedPlan
 
+    Concourse.BuildStepOnError hookedPlan ->
+      initHookedStep resources OnError hookedPlan
+
+    Concourse.BuildStepOnAbort hookedPlan ->
+      initHookedStep resources OnAbort hookedPlan
+
     Concourse.BuildStepEnsure hookedPlan ->
       initHookedStep resources Ensure hookedPlan
 
@@ -155,6 +164,9 @@ init resources plan =
     Concourse.BuildStepTimeout plan ->
       initWrappedStep resources Timeout plan
 
+    Concourse.BuildStepConditional plan ->
+      initWrappedStep resources Conditional plan
+
 treeIsActive : StepTree -> Bool
 treeIsActive tree =
   case tree of
@@ -170,6 +182,12 @@ treeIsActive tree =
     OnFailure {step} ->
       treeIsActive step
 
+    OnError {step} ->
+      treeIsActive step
+
+    OnAbort {step} ->
+      treeIsActive step
+
     Ensure {step} ->
       treeIsActive step
 
@@ -179,6 +197,9 @@ treeIsActive tree =
     Timeout tree ->
       treeIsActive tree
 
+    Conditional tree ->
+      treeIsActive tree
+
     Retry _ trees _ _ ->
       List.any treeIsActive (Array.toList trees)
 
@@ -329,6 +350,12 @@ getStep tree =
     OnFailure {step} ->
       step
 
+    OnError {step} ->
+      step
+
+    OnAbort {step} ->
+      step
+
     Ensure {step} ->
       step
 
@@ -338,6 +365,9 @@ getStep tree =
     Timeout step ->
       step
 
+    Conditional step ->
+      step
+
     _ ->
       Debug.crash "impossible"
 
@@ -350,6 +380,12 @@ updateStep update tree =
     OnFailure hookedStep ->
       OnFailure { hookedStep | step = update hookedStep.step }
 
+    OnError hookedStep ->
+      OnError { hookedStep | step = update hookedStep.step }
+
+    OnAbort hookedStep ->
+      OnAbort { hookedStep | step = update hookedStep.step }
+
     Ensure hookedStep ->
       Ensure { hookedStep | step = update hookedStep.step }
 
@@ -359,6 +395,9 @@ updateStep update tree =
     Timeout step ->
       Timeout (update step)
 
+    Conditional step ->
+      Conditional (update step)
+
     _ ->
       Debug.crash "impossible"
 
@@ -375,6 +414,12 @@ getHook tree =
     OnFailure {hook} ->
       hook
 
+    OnError {hook} ->
+      hook
+
+    OnAbort {hook} ->
+      hook
+
     Ensure {hook} ->
       hook
 
@@ -390,6 +435,12 @@ updateHook update tree =
     OnFailure hookedStep ->
       OnFailure { hookedStep | hook = update hookedStep.hook }
 
+    OnError hookedStep ->
+      OnError { hookedStep | hook = update hookedStep.hook }
+
+    OnAbort hookedStep ->
+      OnAbort { hookedStep | hook = update hookedStep.hook }
+
     Ensure hookedStep ->
       Ensure { hookedStep | hook = update hookedStep.hook }
 
@@ -477,6 +528,9 @@ viewTree model tree =
     Timeout step ->
       viewTree model step
 
+    Conditional step ->
+      viewTree model step
+
     Aggregate steps ->
       Html.div [class "aggregate"]
         (Array.toList <| Array.map (viewSeq model) steps)
@@ -491,6 +545,12 @@ viewTree model tree =
     OnFailure {step, hook} ->
       viewHooked "failure" model step hook
 
+    OnError {step, hook} ->
+      viewHooked "error" model step hook
+
+    OnAbort {step, hook} ->
+      viewHooked "abort" model step hook
+
     Ensure {step, hook} ->
       viewHooked "ensure" model step hook
 
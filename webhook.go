@@ -0,0 +1,21 @@
+package atc
+
+type Webhook struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             int    `json:"id"`
+	BuildID        int    `json:"build_id"`
+	BuildStatus    string `json:"build_status"`
+	Payload        string `json:"payload"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	Error          string `json:"error,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	NextAttemptAt  int64  `json:"next_attempt_at,omitempty"`
+	DeliveredAt    int64  `json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,143 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ForbiddenDestinationError is returned when a webhook's URL resolves only to
+// addresses that webhooks may not be delivered to.
+type ForbiddenDestinationError struct {
+	Host string
+}
+
+func (err ForbiddenDestinationError) Error() string {
+	return fmt.Sprintf("webhook destination '%s' is a loopback, private, or link-local address", err.Host)
+}
+
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+// NewClient returns the client with which webhooks are delivered. Each
+// request is given up on after the timeout.
+//
+// Unless private destinations are allowed, the client refuses to connect to
+// loopback, private, and link-local addresses, so that whoever configures a
+// webhook cannot use it to reach services only visible to the ATC. Addresses
+// are checked as they are dialed, so this holds for redirects and for names
+// which resolve differently over time. Proxies from the environment are not
+// used in this case, as only the proxy's address could be checked.
+func NewClient(timeout time.Duration, allowPrivateDestinations bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+
+	if !allowPrivateDestinations {
+		transport.Proxy = nil
+		transport.DialContext = publicDialer{dialer: dialer}.DialContext
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+type publicDialer struct {
+	dialer *net.Dialer
+}
+
+func (d publicDialer) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialErr error
+	for _, ip := range ips {
+		if isPrivate(ip) {
+			continue
+		}
+
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+
+		dialErr = err
+	}
+
+	if dialErr != nil {
+		return nil, dialErr
+	}
+
+	return nil, ForbiddenDestinationError{Host: host}
+}
+
+func isPrivate(ip net.IP) bool {
+	if ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified() {
+		return true
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isForbiddenDestination reports whether a request failed because of where
+// it was going, which no amount of retrying will change.
+func isForbiddenDestination(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+
+	_, ok := err.(ForbiddenDestinationError)
+	return ok
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks[i] = network
+	}
+
+	return networks
+}
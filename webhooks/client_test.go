@@ -0,0 +1,80 @@
+package webhooks_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/concourse/atc/webhooks"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when private destinations are not allowed", func() {
+		var client *http.Client
+
+		BeforeEach(func() {
+			client = webhooks.NewClient(time.Second, false)
+		})
+
+		It("refuses to connect to loopback addresses", func() {
+			_, err := client.Post(server.URL()+"/hook", "application/json", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*url.Error).Err).To(Equal(webhooks.ForbiddenDestinationError{Host: "127.0.0.1"}))
+
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("refuses to connect to names of loopback addresses", func() {
+			_, err := client.Post("http://localhost:1/hook", "application/json", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*url.Error).Err).To(Equal(webhooks.ForbiddenDestinationError{Host: "localhost"}))
+		})
+
+		It("refuses to connect to link-local addresses", func() {
+			_, err := client.Get("http://169.254.169.254/latest/meta-data")
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*url.Error).Err).To(Equal(webhooks.ForbiddenDestinationError{Host: "169.254.169.254"}))
+		})
+	})
+
+	Context("when private destinations are allowed", func() {
+		var client *http.Client
+
+		BeforeEach(func() {
+			client = webhooks.NewClient(100*time.Millisecond, true)
+		})
+
+		It("connects to them", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+
+			resp, err := client.Post(server.URL()+"/hook", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("gives up on receivers which take longer than the timeout", func() {
+			server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Second)
+			})
+
+			_, err := client.Post(server.URL()+"/hook", "application/json", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
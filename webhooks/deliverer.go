@@ -0,0 +1,194 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	SignatureHeader = "X-Concourse-Signature"
+	DeliveryHeader  = "X-Concourse-Delivery"
+)
+
+const maxConcurrentDeliveries = 10
+
+//go:generate counterfeiter . DeliveryDB
+
+type DeliveryDB interface {
+	GetPendingWebhookDeliveries() ([]db.PendingWebhookDelivery, error)
+	SucceedWebhookDelivery(deliveryID int, responseStatus int) error
+	RetryWebhookDelivery(deliveryID int, responseStatus int, cause string, retryIn time.Duration) error
+	FailWebhookDelivery(deliveryID int, responseStatus int, cause string) error
+}
+
+type Deliverer interface {
+	Run() error
+}
+
+type deliverer struct {
+	logger lager.Logger
+	db     DeliveryDB
+	client *http.Client
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func NewDeliverer(
+	logger lager.Logger,
+	db DeliveryDB,
+	client *http.Client,
+	maxAttempts int,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration,
+) Deliverer {
+	return &deliverer{
+		logger: logger,
+		db:     db,
+		client: client,
+
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+	}
+}
+
+func (d *deliverer) Run() error {
+	pending, err := d.db.GetPendingWebhookDeliveries()
+	if err != nil {
+		d.logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	// deliveries are made concurrently so that a slow receiver holds up only
+	// its own deliveries, each of which gives up after the client's timeout
+	slots := make(chan struct{}, maxConcurrentDeliveries)
+	errs := make(chan error, len(pending))
+
+	wg := new(sync.WaitGroup)
+	for _, p := range pending {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(p db.PendingWebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			errs <- d.run(p)
+		}(p)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// run makes a single delivery and records how it went, returning an error
+// only if it could not be recorded.
+func (d *deliverer) run(p db.PendingWebhookDelivery) error {
+	logger := d.logger.Session("deliver", lager.Data{
+		"webhook":  p.Webhook.Name,
+		"team-id":  p.Webhook.TeamID,
+		"delivery": p.Delivery.ID,
+		"build":    p.Delivery.BuildID,
+	})
+
+	responseStatus, err := d.deliver(p)
+	if err == nil {
+		err = d.db.SucceedWebhookDelivery(p.Delivery.ID, responseStatus)
+		if err != nil {
+			logger.Error("failed-to-mark-delivery-as-succeeded", err)
+			return err
+		}
+
+		return nil
+	}
+
+	logger.Info("delivery-failed", lager.Data{"error": err.Error(), "attempts": p.Delivery.Attempts + 1})
+
+	if p.Delivery.Attempts+1 >= d.maxAttempts || isForbiddenDestination(err) {
+		err = d.db.FailWebhookDelivery(p.Delivery.ID, responseStatus, err.Error())
+		if err != nil {
+			logger.Error("failed-to-mark-delivery-as-failed", err)
+			return err
+		}
+
+		return nil
+	}
+
+	err = d.db.RetryWebhookDelivery(p.Delivery.ID, responseStatus, err.Error(), d.backoff(p.Delivery.Attempts))
+	if err != nil {
+		logger.Error("failed-to-schedule-retry", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *deliverer) deliver(p db.PendingWebhookDelivery) (int, error) {
+	payload := []byte(p.Delivery.Payload)
+
+	req, err := http.NewRequest("POST", p.Webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.Itoa(p.Delivery.ID))
+
+	if p.Webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(p.Webhook.Secret, payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the delay after every failed attempt, up to maxBackoff.
+func (d *deliverer) backoff(previousAttempts int) time.Duration {
+	delay := d.initialBackoff
+	for i := 0; i < previousAttempts; i++ {
+		delay *= 2
+		if delay >= d.maxBackoff {
+			return d.maxBackoff
+		}
+	}
+
+	return delay
+}
+
+// Sign returns the value of the signature header for a payload, which is the
+// hex-encoded HMAC-SHA256 of the payload keyed by the webhook's secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,254 @@
+package webhooks_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/webhooks"
+	"github.com/concourse/atc/webhooks/webhooksfakes"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeDB    *webhooksfakes.FakeDeliveryDB
+		server    *ghttp.Server
+		deliverer webhooks.Deliverer
+
+		pending        db.PendingWebhookDelivery
+		earlierPending []db.PendingWebhookDelivery
+		pendingErr     error
+		runErr         error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(webhooksfakes.FakeDeliveryDB)
+		server = ghttp.NewServer()
+		earlierPending = nil
+		pendingErr = nil
+
+		deliverer = webhooks.NewDeliverer(
+			lagertest.NewTestLogger("test"),
+			fakeDB,
+			&http.Client{},
+			3,
+			10*time.Second,
+			15*time.Second,
+		)
+
+		pending = db.PendingWebhookDelivery{
+			Delivery: db.WebhookDelivery{
+				ID:          42,
+				BuildID:     128,
+				BuildStatus: db.StatusSucceeded,
+				Payload:     `{"id":128,"status":"succeeded"}`,
+				Status:      db.WebhookDeliveryPending,
+			},
+			Webhook: db.SavedWebhook{
+				ID:     1,
+				TeamID: 2,
+				Webhook: db.Webhook{
+					Name:   "some-webhook",
+					URL:    server.URL() + "/hook",
+					Secret: "some-secret",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		fakeDB.GetPendingWebhookDeliveriesReturns(append(earlierPending, pending), pendingErr)
+		runErr = deliverer.Run()
+	})
+
+	Context("when the receiver responds successfully", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/hook"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+					ghttp.VerifyHeaderKV(webhooks.DeliveryHeader, "42"),
+					ghttp.VerifyHeaderKV(webhooks.SignatureHeader, webhooks.Sign("some-secret", []byte(`{"id":128,"status":"succeeded"}`))),
+					ghttp.VerifyBody([]byte(`{"id":128,"status":"succeeded"}`)),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("posts the signed payload and marks the delivery as succeeded", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(fakeDB.SucceedWebhookDeliveryCallCount()).To(Equal(1))
+			deliveryID, responseStatus := fakeDB.SucceedWebhookDeliveryArgsForCall(0)
+			Expect(deliveryID).To(Equal(42))
+			Expect(responseStatus).To(Equal(http.StatusNoContent))
+
+			Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(BeZero())
+			Expect(fakeDB.FailWebhookDeliveryCallCount()).To(BeZero())
+		})
+
+		Context("when the webhook has no secret", func() {
+			BeforeEach(func() {
+				pending.Webhook.Secret = ""
+
+				server.SetHandler(0, func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header).NotTo(HaveKey(webhooks.SignatureHeader))
+					w.WriteHeader(http.StatusOK)
+				})
+			})
+
+			It("does not sign the payload", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(fakeDB.SucceedWebhookDeliveryCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the receiver responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("schedules a retry after the initial backoff", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(Equal(1))
+			deliveryID, responseStatus, cause, retryIn := fakeDB.RetryWebhookDeliveryArgsForCall(0)
+			Expect(deliveryID).To(Equal(42))
+			Expect(responseStatus).To(Equal(http.StatusInternalServerError))
+			Expect(cause).To(ContainSubstring("500"))
+			Expect(retryIn).To(Equal(10 * time.Second))
+		})
+
+		Context("when it has already been attempted", func() {
+			BeforeEach(func() {
+				pending.Delivery.Attempts = 1
+			})
+
+			It("backs off up to the maximum", func() {
+				Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(Equal(1))
+				_, _, _, retryIn := fakeDB.RetryWebhookDeliveryArgsForCall(0)
+				Expect(retryIn).To(Equal(15 * time.Second))
+			})
+		})
+
+		Context("when this was the last attempt", func() {
+			BeforeEach(func() {
+				pending.Delivery.Attempts = 2
+			})
+
+			It("marks the delivery as failed", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(BeZero())
+
+				Expect(fakeDB.FailWebhookDeliveryCallCount()).To(Equal(1))
+				deliveryID, responseStatus, _ := fakeDB.FailWebhookDeliveryArgsForCall(0)
+				Expect(deliveryID).To(Equal(42))
+				Expect(responseStatus).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Context("when the receiver cannot be reached", func() {
+		BeforeEach(func() {
+			pending.Webhook.URL = "http://127.0.0.1:1/hook"
+		})
+
+		It("schedules a retry with no response status", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(Equal(1))
+			_, responseStatus, cause, _ := fakeDB.RetryWebhookDeliveryArgsForCall(0)
+			Expect(responseStatus).To(BeZero())
+			Expect(cause).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when the receiver is on a private network", func() {
+		BeforeEach(func() {
+			deliverer = webhooks.NewDeliverer(
+				lagertest.NewTestLogger("test"),
+				fakeDB,
+				webhooks.NewClient(time.Second, false),
+				3,
+				10*time.Second,
+				15*time.Second,
+			)
+		})
+
+		It("fails the delivery without attempting it or retrying", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+
+			Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(BeZero())
+			Expect(fakeDB.FailWebhookDeliveryCallCount()).To(Equal(1))
+			deliveryID, responseStatus, cause := fakeDB.FailWebhookDeliveryArgsForCall(0)
+			Expect(deliveryID).To(Equal(42))
+			Expect(responseStatus).To(BeZero())
+			Expect(cause).To(ContainSubstring("loopback, private, or link-local"))
+		})
+	})
+
+	Context("when an earlier delivery's receiver is slow to respond", func() {
+		var slowServer *ghttp.Server
+
+		BeforeEach(func() {
+			slowServer = ghttp.NewServer()
+
+			slowPending := pending
+			slowPending.Delivery.ID = 43
+			slowPending.Webhook.URL = slowServer.URL() + "/hook"
+			earlierPending = []db.PendingWebhookDelivery{slowPending}
+
+			delivered := make(chan struct{})
+
+			slowServer.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-delivered:
+					w.WriteHeader(http.StatusOK)
+				case <-time.After(5 * time.Second):
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			})
+
+			server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+				close(delivered)
+				w.WriteHeader(http.StatusOK)
+			})
+		})
+
+		AfterEach(func() {
+			slowServer.Close()
+		})
+
+		It("makes the later delivery while waiting", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDB.SucceedWebhookDeliveryCallCount()).To(Equal(2))
+			Expect(fakeDB.RetryWebhookDeliveryCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting pending deliveries fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			pendingErr = disaster
+		})
+
+		It("returns the error without delivering anything", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
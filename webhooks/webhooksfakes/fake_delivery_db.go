@@ -0,0 +1,211 @@
+// This file was generated by counterfeiter
+package webhooksfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/webhooks"
+)
+
+type FakeDeliveryDB struct {
+	GetPendingWebhookDeliveriesStub        func() ([]db.PendingWebhookDelivery, error)
+	getPendingWebhookDeliveriesMutex       sync.RWMutex
+	getPendingWebhookDeliveriesArgsForCall []struct{}
+	getPendingWebhookDeliveriesReturns     struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}
+	SucceedWebhookDeliveryStub        func(deliveryID int, responseStatus int) error
+	succeedWebhookDeliveryMutex       sync.RWMutex
+	succeedWebhookDeliveryArgsForCall []struct {
+		deliveryID     int
+		responseStatus int
+	}
+	succeedWebhookDeliveryReturns struct {
+		result1 error
+	}
+	RetryWebhookDeliveryStub        func(deliveryID int, responseStatus int, cause string, retryIn time.Duration) error
+	retryWebhookDeliveryMutex       sync.RWMutex
+	retryWebhookDeliveryArgsForCall []struct {
+		deliveryID     int
+		responseStatus int
+		cause          string
+		retryIn        time.Duration
+	}
+	retryWebhookDeliveryReturns struct {
+		result1 error
+	}
+	FailWebhookDeliveryStub        func(deliveryID int, responseStatus int, cause string) error
+	failWebhookDeliveryMutex       sync.RWMutex
+	failWebhookDeliveryArgsForCall []struct {
+		deliveryID     int
+		responseStatus int
+		cause          string
+	}
+	failWebhookDeliveryReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDeliveryDB) GetPendingWebhookDeliveries() ([]db.PendingWebhookDelivery, error) {
+	fake.getPendingWebhookDeliveriesMutex.Lock()
+	fake.getPendingWebhookDeliveriesArgsForCall = append(fake.getPendingWebhookDeliveriesArgsForCall, struct{}{})
+	fake.recordInvocation("GetPendingWebhookDeliveries", []interface{}{})
+	fake.getPendingWebhookDeliveriesMutex.Unlock()
+	if fake.GetPendingWebhookDeliveriesStub != nil {
+		return fake.GetPendingWebhookDeliveriesStub()
+	} else {
+		return fake.getPendingWebhookDeliveriesReturns.result1, fake.getPendingWebhookDeliveriesReturns.result2
+	}
+}
+
+func (fake *FakeDeliveryDB) GetPendingWebhookDeliveriesCallCount() int {
+	fake.getPendingWebhookDeliveriesMutex.RLock()
+	defer fake.getPendingWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getPendingWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeDeliveryDB) GetPendingWebhookDeliveriesReturns(result1 []db.PendingWebhookDelivery, result2 error) {
+	fake.GetPendingWebhookDeliveriesStub = nil
+	fake.getPendingWebhookDeliveriesReturns = struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeliveryDB) SucceedWebhookDelivery(deliveryID int, responseStatus int) error {
+	fake.succeedWebhookDeliveryMutex.Lock()
+	fake.succeedWebhookDeliveryArgsForCall = append(fake.succeedWebhookDeliveryArgsForCall, struct {
+		deliveryID     int
+		responseStatus int
+	}{deliveryID, responseStatus})
+	fake.recordInvocation("SucceedWebhookDelivery", []interface{}{deliveryID, responseStatus})
+	fake.succeedWebhookDeliveryMutex.Unlock()
+	if fake.SucceedWebhookDeliveryStub != nil {
+		return fake.SucceedWebhookDeliveryStub(deliveryID, responseStatus)
+	} else {
+		return fake.succeedWebhookDeliveryReturns.result1
+	}
+}
+
+func (fake *FakeDeliveryDB) SucceedWebhookDeliveryCallCount() int {
+	fake.succeedWebhookDeliveryMutex.RLock()
+	defer fake.succeedWebhookDeliveryMutex.RUnlock()
+	return len(fake.succeedWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeDeliveryDB) SucceedWebhookDeliveryArgsForCall(i int) (int, int) {
+	fake.succeedWebhookDeliveryMutex.RLock()
+	defer fake.succeedWebhookDeliveryMutex.RUnlock()
+	return fake.succeedWebhookDeliveryArgsForCall[i].deliveryID, fake.succeedWebhookDeliveryArgsForCall[i].responseStatus
+}
+
+func (fake *FakeDeliveryDB) SucceedWebhookDeliveryReturns(result1 error) {
+	fake.SucceedWebhookDeliveryStub = nil
+	fake.succeedWebhookDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeliveryDB) RetryWebhookDelivery(deliveryID int, responseStatus int, cause string, retryIn time.Duration) error {
+	fake.retryWebhookDeliveryMutex.Lock()
+	fake.retryWebhookDeliveryArgsForCall = append(fake.retryWebhookDeliveryArgsForCall, struct {
+		deliveryID     int
+		responseStatus int
+		cause          string
+		retryIn        time.Duration
+	}{deliveryID, responseStatus, cause, retryIn})
+	fake.recordInvocation("RetryWebhookDelivery", []interface{}{deliveryID, responseStatus, cause, retryIn})
+	fake.retryWebhookDeliveryMutex.Unlock()
+	if fake.RetryWebhookDeliveryStub != nil {
+		return fake.RetryWebhookDeliveryStub(deliveryID, responseStatus, cause, retryIn)
+	} else {
+		return fake.retryWebhookDeliveryReturns.result1
+	}
+}
+
+func (fake *FakeDeliveryDB) RetryWebhookDeliveryCallCount() int {
+	fake.retryWebhookDeliveryMutex.RLock()
+	defer fake.retryWebhookDeliveryMutex.RUnlock()
+	return len(fake.retryWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeDeliveryDB) RetryWebhookDeliveryArgsForCall(i int) (int, int, string, time.Duration) {
+	fake.retryWebhookDeliveryMutex.RLock()
+	defer fake.retryWebhookDeliveryMutex.RUnlock()
+	return fake.retryWebhookDeliveryArgsForCall[i].deliveryID, fake.retryWebhookDeliveryArgsForCall[i].responseStatus, fake.retryWebhookDeliveryArgsForCall[i].cause, fake.retryWebhookDeliveryArgsForCall[i].retryIn
+}
+
+func (fake *FakeDeliveryDB) RetryWebhookDeliveryReturns(result1 error) {
+	fake.RetryWebhookDeliveryStub = nil
+	fake.retryWebhookDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeliveryDB) FailWebhookDelivery(deliveryID int, responseStatus int, cause string) error {
+	fake.failWebhookDeliveryMutex.Lock()
+	fake.failWebhookDeliveryArgsForCall = append(fake.failWebhookDeliveryArgsForCall, struct {
+		deliveryID     int
+		responseStatus int
+		cause          string
+	}{deliveryID, responseStatus, cause})
+	fake.recordInvocation("FailWebhookDelivery", []interface{}{deliveryID, responseStatus, cause})
+	fake.failWebhookDeliveryMutex.Unlock()
+	if fake.FailWebhookDeliveryStub != nil {
+		return fake.FailWebhookDeliveryStub(deliveryID, responseStatus, cause)
+	} else {
+		return fake.failWebhookDeliveryReturns.result1
+	}
+}
+
+func (fake *FakeDeliveryDB) FailWebhookDeliveryCallCount() int {
+	fake.failWebhookDeliveryMutex.RLock()
+	defer fake.failWebhookDeliveryMutex.RUnlock()
+	return len(fake.failWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeDeliveryDB) FailWebhookDeliveryArgsForCall(i int) (int, int, string) {
+	fake.failWebhookDeliveryMutex.RLock()
+	defer fake.failWebhookDeliveryMutex.RUnlock()
+	return fake.failWebhookDeliveryArgsForCall[i].deliveryID, fake.failWebhookDeliveryArgsForCall[i].responseStatus, fake.failWebhookDeliveryArgsForCall[i].cause
+}
+
+func (fake *FakeDeliveryDB) FailWebhookDeliveryReturns(result1 error) {
+	fake.FailWebhookDeliveryStub = nil
+	fake.failWebhookDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeliveryDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getPendingWebhookDeliveriesMutex.RLock()
+	defer fake.getPendingWebhookDeliveriesMutex.RUnlock()
+	fake.succeedWebhookDeliveryMutex.RLock()
+	defer fake.succeedWebhookDeliveryMutex.RUnlock()
+	fake.retryWebhookDeliveryMutex.RLock()
+	defer fake.retryWebhookDeliveryMutex.RUnlock()
+	fake.failWebhookDeliveryMutex.RLock()
+	defer fake.failWebhookDeliveryMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDeliveryDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.DeliveryDB = new(FakeDeliveryDB)
@@ -461,6 +461,11 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+			Disk:   garden.DiskLimits{ByteHard: spec.Limits.Disk},
+		},
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -471,6 +476,7 @@ func (worker *gardenWorker) CreateContainer(
 	metadata.WorkerName = worker.name
 	metadata.Handle = gardenContainer.Handle()
 	metadata.User = gardenSpec.Properties["user"]
+	metadata.ContainerLimits = spec.Limits
 
 	id.ResourceTypeVersion = resourceTypeVersion
 
@@ -649,6 +649,32 @@ var _ = Describe("Worker", func() {
 				Expect(actualGardenSpec.Properties["user"]).To(Equal("image-volume-user"))
 			})
 
+			Context("when the spec specifies container limits", func() {
+				BeforeEach(func() {
+					containerSpec.Limits = atc.ContainerLimits{
+						CPU:    512,
+						Memory: 1024 * 1024 * 1024,
+						Disk:   10 * 1024 * 1024 * 1024,
+					}
+				})
+
+				It("applies them to the garden spec", func() {
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+					actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+						CPU:    garden.CPULimits{LimitInShares: 512},
+						Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024 * 1024},
+						Disk:   garden.DiskLimits{ByteHard: 10 * 1024 * 1024 * 1024},
+					}))
+				})
+
+				It("records them in the db", func() {
+					Expect(fakeGardenWorkerDB.CreateContainerCallCount()).To(Equal(1))
+					c, _, _, _ := fakeGardenWorkerDB.CreateContainerArgsForCall(0)
+					Expect(c.ContainerLimits).To(Equal(containerSpec.Limits))
+				})
+			})
+
 			Context("when fetching the image fails", func() {
 				BeforeEach(func() {
 					fakeImage.FetchReturns(nil, nil, nil, errors.New("fetch-err"))
@@ -0,0 +1,53 @@
+package wrappa
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/tedsuo/rata"
+)
+
+type APIAuditWrappa struct {
+	logger  lager.Logger
+	auditDB audit.AuditDB
+}
+
+// NewAPIAuditWrappa records calls to the routes that change something. It
+// must be applied before the APIAuthWrappa, so that only calls which get
+// past auth are recorded, along with who made them.
+func NewAPIAuditWrappa(logger lager.Logger, auditDB audit.AuditDB) Wrappa {
+	return APIAuditWrappa{
+		logger:  logger,
+		auditDB: auditDB,
+	}
+}
+
+func (wrappa APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	methods := map[string]string{}
+	for _, route := range atc.Routes {
+		methods[route.Name] = route.Method
+	}
+
+	for name, handler := range handlers {
+		switch name {
+		// worker heartbeats, fly execute's plumbing, and dry runs
+		case atc.RegisterWorker, atc.CreatePipe, atc.WritePipe, atc.DiffConfig:
+			wrapped[name] = handler
+
+		// a GET, but it runs commands in the container
+		case atc.HijackContainer:
+			wrapped[name] = audit.WrapHandler(wrappa.logger, wrappa.auditDB, name, handler)
+
+		default:
+			if methods[name] == "GET" {
+				wrapped[name] = handler
+			} else {
+				wrapped[name] = audit.WrapHandler(wrappa.logger, wrappa.auditDB, name, handler)
+			}
+		}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,90 @@
+package wrappa_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		logger      lager.Logger
+		fakeAuditDB *auditfakes.FakeAuditDB
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditDB = new(auditfakes.FakeAuditDB)
+	})
+
+	audited := func(name string, handler http.Handler) http.Handler {
+		return audit.WrapHandler(logger, fakeAuditDB, name, handler)
+	}
+
+	Describe("Wrap", func() {
+		var (
+			inputHandlers   rata.Handlers
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPIAuditWrappa(logger, fakeAuditDB).Wrap(inputHandlers)
+		})
+
+		It("audits routes that change something", func() {
+			for _, name := range []string{
+				atc.SaveConfig,
+				atc.PausePipeline,
+				atc.DeletePipeline,
+				atc.CreateJobBuild,
+				atc.AbortBuild,
+				atc.SetTeam,
+				atc.CreateAPIToken,
+				atc.RevokeAPIToken,
+				atc.HijackContainer,
+			} {
+				Expect(wrappedHandlers[name]).To(BeIdenticalTo(audited(name, inputHandlers[name])))
+			}
+		})
+
+		It("does not audit routes that only read", func() {
+			for _, name := range []string{
+				atc.GetConfig,
+				atc.GetPipeline,
+				atc.ListBuilds,
+				atc.BuildEvents,
+				atc.ListAuditEvents,
+			} {
+				Expect(wrappedHandlers[name]).To(BeIdenticalTo(inputHandlers[name]))
+			}
+		})
+
+		It("does not audit plumbing or dry runs", func() {
+			for _, name := range []string{
+				atc.RegisterWorker,
+				atc.CreatePipe,
+				atc.WritePipe,
+				atc.DiffConfig,
+			} {
+				Expect(wrappedHandlers[name]).To(BeIdenticalTo(inputHandlers[name]))
+			}
+		})
+	})
+})
@@ -33,6 +33,56 @@ func NewAPIAuthWrappa(
 	}
 }
 
+// requiredRoles are the roles within a team needed for routes that change
+// it; everything else only needs the team's viewer role.
+var requiredRoles = map[string]atc.TeamRole{
+	atc.SetTeam:               atc.TeamRoleOwner,
+	atc.DestroyTeam:           atc.TeamRoleOwner,
+	atc.ListWebhooks:          atc.TeamRoleOwner,
+	atc.SetWebhook:            atc.TeamRoleOwner,
+	atc.DeleteWebhook:         atc.TeamRoleOwner,
+	atc.ListWebhookDeliveries: atc.TeamRoleOwner,
+	atc.RedeliverWebhook:      atc.TeamRoleOwner,
+	atc.ListAPITokens:         atc.TeamRoleOwner,
+	atc.CreateAPIToken:        atc.TeamRoleOwner,
+	atc.RevokeAPIToken:        atc.TeamRoleOwner,
+	atc.SetLogLevel:           atc.TeamRoleOwner,
+	atc.ListAuditEvents:       atc.TeamRoleOwner,
+
+	atc.GetConfig:          atc.TeamRoleMember,
+	atc.SaveConfig:         atc.TeamRoleMember,
+	atc.DiffConfig:         atc.TeamRoleMember,
+	atc.ListConfigVersions: atc.TeamRoleMember,
+	atc.GetConfigVersion:   atc.TeamRoleMember,
+	atc.RollbackConfig:     atc.TeamRoleMember,
+	atc.DeletePipeline:     atc.TeamRoleMember,
+	atc.RenamePipeline:     atc.TeamRoleMember,
+	atc.OrderPipelines:     atc.TeamRoleMember,
+	atc.ExposePipeline:     atc.TeamRoleMember,
+	atc.HidePipeline:       atc.TeamRoleMember,
+	atc.CreateBuild:        atc.TeamRoleMember,
+	atc.CreatePipe:         atc.TeamRoleMember,
+	atc.ReadPipe:           atc.TeamRoleMember,
+	atc.WritePipe:          atc.TeamRoleMember,
+	atc.HijackContainer:    atc.TeamRoleMember,
+	atc.RegisterWorker:     atc.TeamRoleMember,
+
+	atc.CreateJobBuild:         atc.TeamRoleOperator,
+	atc.RerunJobBuild:          atc.TeamRoleOperator,
+	atc.AbortBuild:             atc.TeamRoleOperator,
+	atc.PauseJob:               atc.TeamRoleOperator,
+	atc.UnpauseJob:             atc.TeamRoleOperator,
+	atc.PausePipeline:          atc.TeamRoleOperator,
+	atc.UnpausePipeline:        atc.TeamRoleOperator,
+	atc.PauseResource:          atc.TeamRoleOperator,
+	atc.UnpauseResource:        atc.TeamRoleOperator,
+	atc.CheckResource:          atc.TeamRoleOperator,
+	atc.EnableResourceVersion:  atc.TeamRoleOperator,
+	atc.DisableResourceVersion: atc.TeamRoleOperator,
+	atc.PinResourceVersion:     atc.TeamRoleOperator,
+	atc.UnpinResource:          atc.TeamRoleOperator,
+}
+
 func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	wrapped := rata.Handlers{}
 
@@ -50,7 +100,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllPipelines,
 			atc.ListPipelines,
 			atc.ListBuilds,
-			atc.MainJobBadge:
+			atc.MainJobBadge,
+			atc.CheckResourceWebhook:
 
 		// pipeline is public or authorized
 		case atc.GetBuild,
@@ -60,7 +111,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
-			atc.BuildEvents:
+			atc.BuildEvents,
+			atc.BuildTestResults,
+			atc.ListBuildArtifacts,
+			atc.GetBuildArtifact:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
@@ -99,29 +153,47 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListAuditEvents:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
+			atc.SearchBuildLogs,
 			atc.CreateJobBuild,
+			atc.RerunJobBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.GetConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ListJobTests,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
+			atc.PinResourceVersion,
 			atc.RenamePipeline,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.DiffConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.RollbackConfig,
+			atc.ListWebhooks,
+			atc.SetWebhook,
+			atc.DeleteWebhook,
+			atc.ListWebhookDeliveries,
+			atc.RedeliverWebhook,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -129,6 +201,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			panic("you missed a spot")
 		}
 
+		if role, found := requiredRoles[name]; found {
+			newHandler = auth.CheckRoleHandler(newHandler, role, rejector)
+		}
+
 		if name == atc.GetAuthToken {
 			newHandler = auth.WrapHandler(newHandler, wrappa.getTokenValidator, wrappa.userContextReader)
 		} else {
@@ -136,6 +136,54 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	withRole := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.CheckRoleHandler(handler, role, auth.UnauthorizedRejector{})
+	}
+
+	authenticatedAs := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			withRole(role, auth.CheckAuthenticationHandler(
+				handler,
+				auth.UnauthorizedRejector{},
+			)),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	authorizedAs := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			withRole(role, auth.CheckAuthorizationHandler(
+				handler,
+				auth.UnauthorizedRejector{},
+			)),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	authenticatedAndAdminAs := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			withRole(role, auth.CheckAdminHandler(
+				handler,
+				auth.UnauthorizedRejector{},
+			)),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
+	checkWritePermissionForBuildAs := func(role atc.TeamRole, handler http.Handler) http.Handler {
+		return auth.WrapHandler(
+			withRole(role, fakeCheckBuildWriteAccessHandlerFactory.HandlerFor(
+				handler,
+				auth.UnauthorizedRejector{},
+			)),
+			fakeAuthValidator,
+			fakeUserContextReader,
+		)
+	}
+
 	Describe("Wrap", func() {
 		var (
 			inputHandlers    rata.Handlers
@@ -162,6 +210,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListTeams:        unauthenticated(inputHandlers[atc.ListTeams]),
 				atc.MainJobBadge:     unauthenticated(inputHandlers[atc.MainJobBadge]),
 
+				atc.CheckResourceWebhook: unauthenticated(inputHandlers[atc.CheckResourceWebhook]),
+
 				// authorized or public pipeline
 				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
 				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
@@ -170,9 +220,12 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized or public pipeline and public job
 				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.BuildTestResults:    checksIfPrivateJob(inputHandlers[atc.BuildTestResults]),
+				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.GetBuildArtifact:    checksIfPrivateJob(inputHandlers[atc.GetBuildArtifact]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild: checkWritePermissionForBuildAs(atc.TeamRoleOperator, inputHandlers[atc.AbortBuild]),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
@@ -188,46 +241,65 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
+				atc.CreateBuild:     authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.CreateBuild]),
+				atc.CreatePipe:      authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.CreatePipe]),
 				atc.GetAuthToken:    authenticatedWithGetTokenValidator(inputHandlers[atc.GetAuthToken]),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackContainer: authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.ReadPipe:        authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.ReadPipe]),
+				atc.RegisterWorker:  authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.RegisterWorker]),
 
-				atc.SetTeam:     authenticated(inputHandlers[atc.SetTeam]),
-				atc.DestroyTeam: authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
+				atc.SetTeam:     authenticatedAs(atc.TeamRoleOwner, inputHandlers[atc.SetTeam]),
+				atc.DestroyTeam: authenticatedAs(atc.TeamRoleOwner, inputHandlers[atc.DestroyTeam]),
+				atc.WritePipe:   authenticatedAs(atc.TeamRoleMember, inputHandlers[atc.WritePipe]),
 				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.SetLogLevel: authenticatedAndAdminAs(atc.TeamRoleOwner, inputHandlers[atc.SetLogLevel]),
+
+				atc.ListAuditEvents: authenticatedAndAdminAs(atc.TeamRoleOwner, inputHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.CheckResource:          authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:         authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:         authorizedAs(atc.TeamRoleMember, inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion: authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:  authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.EnableResourceVersion]),
+				atc.GetConfig:              authorizedAs(atc.TeamRoleMember, inputHandlers[atc.GetConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.ListJobTests:           authorized(inputHandlers[atc.ListJobTests]),
+				atc.OrderPipelines:         authorizedAs(atc.TeamRoleMember, inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:               authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:          authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:          authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.PauseResource]),
+				atc.PinResourceVersion:     authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.PinResourceVersion]),
+				atc.RenamePipeline:         authorizedAs(atc.TeamRoleMember, inputHandlers[atc.RenamePipeline]),
+				atc.RerunJobBuild:          authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.RerunJobBuild]),
+				atc.SearchBuildLogs:        authorized(inputHandlers[atc.SearchBuildLogs]),
+				atc.SaveConfig:             authorizedAs(atc.TeamRoleMember, inputHandlers[atc.SaveConfig]),
+				atc.DiffConfig:             authorizedAs(atc.TeamRoleMember, inputHandlers[atc.DiffConfig]),
+				atc.ListConfigVersions:     authorizedAs(atc.TeamRoleMember, inputHandlers[atc.ListConfigVersions]),
+				atc.GetConfigVersion:       authorizedAs(atc.TeamRoleMember, inputHandlers[atc.GetConfigVersion]),
+				atc.RollbackConfig:         authorizedAs(atc.TeamRoleMember, inputHandlers[atc.RollbackConfig]),
+				atc.UnpauseJob:             authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:        authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:        authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.UnpauseResource]),
+				atc.UnpinResource:          authorizedAs(atc.TeamRoleOperator, inputHandlers[atc.UnpinResource]),
+				atc.ExposePipeline:         authorizedAs(atc.TeamRoleMember, inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:           authorizedAs(atc.TeamRoleMember, inputHandlers[atc.HidePipeline]),
+				atc.ListWebhooks:           authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.ListWebhooks]),
+				atc.SetWebhook:             authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.SetWebhook]),
+				atc.DeleteWebhook:          authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.DeleteWebhook]),
+				atc.ListWebhookDeliveries:  authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.ListWebhookDeliveries]),
+				atc.RedeliverWebhook:       authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.RedeliverWebhook]),
+				atc.ListAPITokens:          authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.ListAPITokens]),
+				atc.CreateAPIToken:         authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.CreateAPIToken]),
+				atc.RevokeAPIToken:         authorizedAs(atc.TeamRoleOwner, inputHandlers[atc.RevokeAPIToken]),
 			}
 		})
 